@@ -2,24 +2,36 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"chatops-bot/internal/bot"
 	"chatops-bot/internal/config"
 	"chatops-bot/internal/executor/http"
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/logging"
 	"chatops-bot/internal/models"
+	"chatops-bot/internal/notifier"
 	"chatops-bot/internal/server"
 	"chatops-bot/internal/service"
 	storage_gorm "chatops-bot/internal/storage/gorm"
 
 	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	migrate_pgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
@@ -36,89 +48,323 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.Logging)
+	if err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
+	slog.SetDefault(logger)
 
-	db, err := gorm.Open(sqlite.Open(cfg.DB.DSN), &gorm.Config{})
+	db, err := openDatabase(cfg.DB)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal(logger, "Failed to connect to database", err)
 	}
 
+	if err := runMigrations(db, cfg.DB.Driver); err != nil {
+		fatal(logger, "Failed to apply migrations", err)
+	}
+	logger.Info("Database migrations applied")
+
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+		fatal(logger, "Failed to get underlying sql.DB", err)
 	}
+	pingInterval := intervalOrDefault(cfg.DB.PingInterval, time.Minute)
+	dbPinger := &databasePinger{db: sqlDB, logger: logger.With("component", "db"), timeout: pingInterval / 2}
 
-	driver, err := sqlite3.WithInstance(sqlDB, &sqlite3.Config{})
+	userRepo, err := storage_gorm.NewGormUserRepository(db)
 	if err != nil {
-		log.Fatalf("Failed to create migrate driver: %v", err)
+		fatal(logger, "Failed to create user repository", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"sqlite3",
-		driver,
-	)
+	incidentRepo, err := storage_gorm.NewGormIncidentRepository(db)
 	if err != nil {
-		log.Fatalf("Failed to create migrate instance: %v", err)
+		fatal(logger, "Failed to create incident repository", err)
 	}
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatalf("Failed to apply migrations: %v", err)
+	featureFlagRepo, err := storage_gorm.NewGormFeatureFlagRepository(db)
+	if err != nil {
+		fatal(logger, "Failed to create feature flag repository", err)
 	}
-	log.Println("Database migrations applied successfully.")
 
-	userRepo, err := storage_gorm.NewGormUserRepository(db)
+	featureFlags, err := service.NewFeatureFlagService(context.Background(), featureFlagRepo, cfg.FeatureFlags)
 	if err != nil {
-		log.Fatalf("Failed to create user repository: %v", err)
+		fatal(logger, "Failed to load feature flags", err)
 	}
 
-	incidentRepo, err := storage_gorm.NewGormIncidentRepository(db)
+	oncallRotation, err := service.NewOnCallRotation(cfg.OnCall)
 	if err != nil {
-		log.Fatalf("Failed to create incident repository: %v", err)
+		fatal(logger, "Failed to parse on-call rotation", err)
 	}
 
-	executorClient := http.NewExecutorClient(cfg.Executor.BaseURL)
-	actionSuggester := service.NewActionSuggester()
+	var executorClient service.ExecutorClient
+	if cfg.Executor.UseMock {
+		executorClient = mock.NewExecutorClientMock()
+		logger.Warn("Using the mock executor, actions are not executed")
+	} else {
+		executorClient = http.NewExecutorClient(cfg.Executor)
+	}
+	var suggestionRules *service.RuleSet
+	if cfg.Suggestions.RulesFile != "" {
+		suggestionRules, err = service.LoadRuleSet(cfg.Suggestions.RulesFile)
+		if err != nil {
+			fatal(logger, "Failed to load suggestion rules", err)
+		}
+		logger.Info("Loaded suggestion rules", "count", len(suggestionRules.Rules), "file", cfg.Suggestions.RulesFile)
+	}
+	actionSuggester := service.NewActionSuggester(suggestionRules)
 
 	notificationChan := make(chan *models.Incident, 10)
 	updateChan := make(chan *models.Incident, 10)
 	topicDeletionChan := make(chan *models.Incident, 10)
+	escalationChan := make(chan *models.Incident, 10)
+	reminderChan := make(chan *models.Incident, 10)
+	snoozeChan := make(chan *models.Incident, 10)
+
+	incidentService := service.NewIncidentService(incidentRepo, userRepo, executorClient, actionSuggester, notificationChan, updateChan, topicDeletionChan, escalationChan, reminderChan, snoozeChan, service.SystemClock{}, logger.With("component", "service"))
+	incidentService.SetFeatureFlags(featureFlags)
+	incidentService.SetActionConcurrency(cfg.Executor.MaxConcurrentActions)
+	incidentService.SetMinSeverity(cfg.Telegram.SeverityLabel, cfg.IncidentService.MinSeverity, cfg.IncidentService.SeverityOrder)
+	if err := incidentService.SetQuietHours(cfg.IncidentService.QuietHours, cfg.Telegram.HighSeverityValues); err != nil {
+		fatal(logger, "Invalid quiet hours", err)
+	}
 
-	incidentService := service.NewIncidentService(incidentRepo, userRepo, executorClient, actionSuggester, notificationChan, updateChan, topicDeletionChan)
+	notifiers := notifier.NewFanout()
+	if cfg.Slack.WebhookURL != "" {
+		notifiers.Register(notifier.NewSlackNotifier(cfg.Slack.WebhookURL, cfg.Telegram.SeverityLabel))
+		logger.Info("Slack notifier enabled")
+	}
+	if cfg.OutboundWebhook.URL != "" {
+		webhookCfg := cfg.OutboundWebhook
+		notifiers.Register(notifier.NewWebhookNotifier(webhookCfg.URL, webhookCfg.Secret, time.Duration(webhookCfg.Timeout)*time.Second, webhookCfg.RetryCount, webhookCfg.QueueSize))
+		logger.Info("Outbound webhook notifier enabled")
+	}
 
 	var wg sync.WaitGroup
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		ticker := time.NewTicker(time.Duration(cfg.IncidentService.TopicDeletionInterval) * time.Second)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ticker.C:
-				log.Println("Running job to delete old incident topics...")
-				incidentService.DeleteOldIncidentTopics(context.Background(), time.Duration(cfg.IncidentService.TopicMaxAge)*time.Second)
-			case <-context.Background().Done():
-				return
-			}
+	runPeriodically(&wg, pingInterval, dbPinger.ping)
+
+	svcCfg := cfg.IncidentService
+	topicMaxAge := time.Duration(svcCfg.TopicMaxAge) * time.Second
+	runPeriodically(&wg, time.Duration(svcCfg.TopicDeletionInterval)*time.Second, func() {
+		logger.Info("Running job to delete old incident topics")
+		incidentService.DeleteOldIncidentTopics(context.Background(), topicMaxAge)
+	})
+
+	escalationPolicy := service.NewEscalationPolicy(svcCfg, cfg.Telegram.SeverityLabel)
+	if escalationPolicy.Enabled() {
+		runPeriodically(&wg, intervalOrDefault(svcCfg.EscalationCheckInterval, time.Minute), func() {
+			incidentService.EscalateUnacknowledgedIncidents(context.Background(), escalationPolicy)
+		})
+	}
+
+	if svcCfg.ArchiveMaxAge > 0 {
+		archiveMaxAge := time.Duration(svcCfg.ArchiveMaxAge) * time.Second
+		archiveOpts := service.ArchiveOptions{
+			KeepAuditRecords: svcCfg.ArchiveKeepAuditRecords,
+			DryRun:           svcCfg.ArchiveDryRun,
 		}
-	}()
+		runPeriodically(&wg, intervalOrDefault(svcCfg.ArchiveInterval, 24*time.Hour), func() {
+			logger.Info("Running job to archive old incidents")
+			incidentService.ArchiveOldIncidents(context.Background(), archiveMaxAge, archiveOpts)
+		})
+	}
+
+	if svcCfg.ReminderInterval > 0 {
+		reminderInterval := time.Duration(svcCfg.ReminderInterval) * time.Second
+		runPeriodically(&wg, intervalOrDefault(svcCfg.ReminderCheckInterval, time.Minute), func() {
+			incidentService.RemindUnacknowledgedIncidents(context.Background(), reminderInterval)
+		})
+	}
+
+	runPeriodically(&wg, intervalOrDefault(svcCfg.SnoozeCheckInterval, time.Minute), func() {
+		incidentService.NotifyExpiredSnoozes(context.Background())
+	})
+
+	runPeriodically(&wg, intervalOrDefault(svcCfg.SilenceCleanupInterval, time.Hour), func() {
+		incidentService.ExpireSilences(context.Background())
+	})
+
+	if svcCfg.QuietHours.Start != "" || svcCfg.QuietHours.End != "" {
+		runPeriodically(&wg, intervalOrDefault(svcCfg.QuietHours.ReleaseInterval, time.Minute), func() {
+			incidentService.ReleaseHeldNotifications(context.Background())
+		})
+	}
 
-	server.Start(context.Background(), incidentService, userRepo, cfg.Server.AppPort, cfg.Server.AlertPort, cfg.Server.WebhookToken)
+	if cfg.Server.APIToken == "" {
+		logger.Warn("API token is not set, the API rejects all requests")
+	}
+	server.Start(context.Background(), logger.With("component", "server"), incidentService, userRepo, sqlDB, cfg.Server)
 
 	if cfg.Telegram.BotToken == "" {
-		log.Println("Telegram bot token is not set. Bot will not start.")
+		logger.Warn("Telegram bot token is not set, bot will not start")
 	} else {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			telegramBot, err := bot.NewBot(cfg.Telegram.BotToken, incidentService, userRepo, actionSuggester, cfg.Telegram.AlertChannelID)
+			telegramBot, err := bot.NewBot(cfg.Telegram, cfg.Actions, incidentService, userRepo, actionSuggester, featureFlags, oncallRotation, notifiers, logger.With("component", "bot"))
 			if err != nil {
-				log.Fatalf("Failed to create bot: %v", err)
+				fatal(logger, "Failed to create bot", err)
 			}
-			telegramBot.Start(notificationChan, updateChan, topicDeletionChan)
+			telegramBot.Start(notificationChan, updateChan, topicDeletionChan, escalationChan, reminderChan, snoozeChan)
 		}()
 	}
 
-	log.Println("Application started. Press Ctrl+C to exit.")
+	logger.Info("Application started, press Ctrl+C to exit")
 	wg.Wait()
 }
+
+func openDatabase(cfg config.DBConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case "", "sqlite":
+		dialector = sqlite.Open(sqliteDSN(cfg.DSN))
+	case "postgres":
+		dialector = postgres.Open(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	configurePool(sqlDB, cfg)
+	return db, nil
+}
+
+// sqliteBusyTimeoutMs is how long a sqlite write waits for a lock held by another
+// connection before failing with "database is locked".
+const sqliteBusyTimeoutMs = 5000
+
+// sqliteDSN adds a busy timeout and WAL journaling to a sqlite DSN unless it sets
+// them itself, so audit writes and background jobs wait for each other instead of
+// failing, and reads do not block writes.
+func sqliteDSN(dsn string) string {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return dsn
+	}
+	var extra []string
+	if !query.Has("_busy_timeout") && !query.Has("_timeout") {
+		extra = append(extra, fmt.Sprintf("_busy_timeout=%d", sqliteBusyTimeoutMs))
+	}
+	if !query.Has("_journal_mode") && !query.Has("_journal") {
+		extra = append(extra, "_journal_mode=WAL")
+	}
+	if len(extra) == 0 {
+		return dsn
+	}
+	if rawQuery != "" {
+		extra = append([]string{rawQuery}, extra...)
+	}
+	return path + "?" + strings.Join(extra, "&")
+}
+
+// configurePool applies the pool limits of cfg; zero values keep the defaults.
+func configurePool(sqlDB *sql.DB, cfg config.DBConfig) {
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	}
+}
+
+// databasePinger logs when the database stops answering pings and when it
+// answers again, so a lost connection shows up in the logs before requests fail.
+type databasePinger struct {
+	db      *sql.DB
+	logger  *slog.Logger
+	timeout time.Duration
+	down    bool
+}
+
+func (p *databasePinger) ping() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	err := p.db.PingContext(ctx)
+	switch {
+	case err != nil:
+		stats := p.db.Stats()
+		p.logger.Error("Database ping failed", "error", err, "open_connections", stats.OpenConnections, "in_use", stats.InUse)
+		p.down = true
+	case p.down:
+		p.logger.Info("Database connection restored")
+		p.down = false
+	}
+}
+
+func runMigrations(db *gorm.DB, driverName string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	var (
+		driver     database.Driver
+		sourceURL  string
+		driverKind string
+	)
+	if driverName == "postgres" {
+		driver, err = migrate_pgx.WithInstance(sqlDB, &migrate_pgx.Config{})
+		sourceURL, driverKind = "file://migrations/postgres", "pgx5"
+	} else {
+		driver, err = sqlite3.WithInstance(sqlDB, &sqlite3.Config{})
+		sourceURL, driverKind = "file://migrations", "sqlite3"
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create migrate driver: %w", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(sourceURL, driverKind, driver)
+	if err != nil {
+		return fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+// runPeriodically runs job every interval in the background. A non-positive
+// interval disables the job; time.NewTicker would panic on it.
+func runPeriodically(wg *sync.WaitGroup, interval time.Duration, job func()) {
+	if interval <= 0 {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			job()
+		}
+	}()
+}
+
+// intervalOrDefault converts a configured interval in seconds, using fallback
+// when it is not set.
+func intervalOrDefault(seconds int64, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/config"
+)
+
+func TestOpenDatabaseAppliesPoolSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.DBConfig
+		wantOpen int
+		wantIdle int
+	}{
+		{"configured", config.DBConfig{MaxOpenConns: 4, MaxIdleConns: 1, ConnMaxLifetime: 300}, 4, 1},
+		{"defaults", config.DBConfig{}, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.DSN = filepath.Join(t.TempDir(), "pool.db")
+			db, err := openDatabase(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { sqlDB.Close() })
+
+			if got := sqlDB.Stats().MaxOpenConnections; got != tt.wantOpen {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantOpen)
+			}
+
+			// Check out more connections than may stay idle, then return them all:
+			// the pool keeps only the idle limit open.
+			ctx := context.Background()
+			var conns []*sql.Conn
+			for i := 0; i < 3; i++ {
+				conn, err := sqlDB.Conn(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				conns = append(conns, conn)
+			}
+			for _, conn := range conns {
+				conn.Close()
+			}
+			if got := sqlDB.Stats().Idle; got != tt.wantIdle {
+				t.Errorf("idle connections = %d, want %d", got, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func TestSqliteDSN(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{"chatops.db", "chatops.db?_busy_timeout=5000&_journal_mode=WAL"},
+		{"chatops.db?cache=shared", "chatops.db?cache=shared&_busy_timeout=5000&_journal_mode=WAL"},
+		{"chatops.db?_timeout=100", "chatops.db?_timeout=100&_journal_mode=WAL"},
+		{"chatops.db?_busy_timeout=100&_journal=DELETE", "chatops.db?_busy_timeout=100&_journal=DELETE"},
+	}
+	for _, tt := range tests {
+		if got := sqliteDSN(tt.dsn); got != tt.want {
+			t.Errorf("sqliteDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+}
+
+func TestIntervalOrDefault(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    time.Duration
+	}{
+		{0, time.Hour},
+		{-5, time.Hour},
+		{90, 90 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := intervalOrDefault(tt.seconds, time.Hour); got != tt.want {
+			t.Errorf("intervalOrDefault(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestRunPeriodicallyDisabled(t *testing.T) {
+	var wg sync.WaitGroup
+	for _, interval := range []time.Duration{0, -time.Second} {
+		runPeriodically(&wg, interval, func() { t.Error("disabled job ran") })
+	}
+	// Wait returns at once only if no job goroutine was started.
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a disabled job was started")
+	}
+}
@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+)
+
+func (b *Bot) handleGrantAdmin(c telebot.Context) error {
+	return b.changeAdmin(c, true)
+}
+
+func (b *Bot) handleRevokeAdmin(c telebot.Context) error {
+	return b.changeAdmin(c, false)
+}
+
+// changeAdmin handles "/grant_admin @username" and "/revoke_admin @username".
+// Admins listed in the configuration cannot be revoked from chat: their rights
+// are restored on their next message anyway.
+func (b *Bot) changeAdmin(c telebot.Context, isAdmin bool) error {
+	user := requestUser(c)
+	if !user.IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	usageKey := "admins.grant_usage"
+	if !isAdmin {
+		usageKey = "admins.revoke_usage"
+	}
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send(b.t(c, usageKey))
+	}
+	username := strings.TrimPrefix(args[0], "@")
+	if username == "" {
+		return c.Send(b.t(c, usageKey))
+	}
+
+	ctx := requestContext(c)
+	target, err := b.service.FindUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return c.Send(b.t(c, "assign.user_not_found", username))
+		}
+		b.logger.ErrorContext(ctx, "Failed to find user", "username", username, "error", err)
+		return c.Send(b.t(c, "admins.failed"))
+	}
+	name := userDisplayName(target)
+	if target.IsAdmin == isAdmin {
+		if isAdmin {
+			return c.Send(b.t(c, "admins.already_admin", name))
+		}
+		return c.Send(b.t(c, "admins.not_admin", name))
+	}
+	if !isAdmin && b.adminIDs[target.TelegramID] {
+		return c.Send(b.t(c, "admins.bootstrap", name))
+	}
+
+	if err := b.service.SetUserAdmin(ctx, user.ID, target, isAdmin); err != nil {
+		if errors.Is(err, service.ErrLastAdmin) {
+			return c.Send(b.t(c, "admins.last_admin"))
+		}
+		b.logger.ErrorContext(ctx, "Failed to change admin rights", "user_id", target.ID, "is_admin", isAdmin, "error", err)
+		return c.Send(b.t(c, "admins.failed"))
+	}
+	if isAdmin {
+		return c.Send(b.t(c, "admins.granted", name))
+	}
+	return c.Send(b.t(c, "admins.revoked", name))
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// newCommandContext returns a command update from user with the given arguments,
+// as if authMiddleware had authenticated it.
+func newCommandContext(user *models.User, args ...string) *fakeContext {
+	c := &fakeContext{
+		args:    args,
+		sender:  &telebot.User{ID: user.TelegramID},
+		chat:    &telebot.Chat{ID: user.TelegramID},
+		message: &telebot.Message{ID: 1, Chat: &telebot.Chat{ID: user.TelegramID}},
+		store:   make(map[string]interface{}),
+	}
+	c.store["ctx"] = context.WithValue(context.Background(), "user", user)
+	return c
+}
+
+func TestChangeAdmin(t *testing.T) {
+	tb := newTestBot(t)
+	ctx := context.Background()
+	alice := tb.user(t, 1, "en")
+	bob := tb.user(t, 2, "en")
+	carol := tb.user(t, 3, "en")
+	if err := tb.users.SetAdmin(ctx, alice.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	alice.IsAdmin = true
+	tb.adminIDs[carol.TelegramID] = true
+
+	steps := []struct {
+		name  string
+		actor *models.User
+		grant bool
+		args  []string
+		want  string
+	}{
+		{"not an admin", bob, true, []string{"@user3"}, "This command is available to admins only."},
+		{"no argument", alice, true, nil, "Usage: /grant_admin @username"},
+		{"unknown user", alice, false, []string{"@nobody"}, "User @nobody not found. They need to message the bot at least once."},
+		{"grant", alice, true, []string{"@user2"}, "@user2 is now an admin."},
+		{"grant twice", alice, true, []string{"user2"}, "@user2 is already an admin."},
+		{"revoke", alice, false, []string{"@user2"}, "@user2 is no longer an admin."},
+		{"revoke a non-admin", alice, false, []string{"@user2"}, "@user2 is not an admin."},
+		{"grant a configured admin", alice, true, []string{"@user3"}, "@user3 is now an admin."},
+		{"revoke a configured admin", alice, false, []string{"@user3"}, "@user3 is listed in telegram.admin_ids in the configuration: remove them there to revoke their rights."},
+	}
+	for _, step := range steps {
+		c := newCommandContext(step.actor, step.args...)
+		if err := tb.changeAdmin(c, step.grant); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if len(c.sent) != 1 || c.sent[0] != step.want {
+			t.Errorf("%s: sent %q, want %q", step.name, c.sent, step.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		user *models.User
+		want bool
+	}{{alice, true}, {bob, false}, {carol, true}} {
+		got, err := tb.users.FindByID(ctx, tt.user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.IsAdmin != tt.want {
+			t.Errorf("%s: is_admin = %v, want %v", got.Username, got.IsAdmin, tt.want)
+		}
+	}
+}
+
+func TestChangeAdminKeepsLastAdmin(t *testing.T) {
+	tb := newTestBot(t)
+	alice := tb.user(t, 1, "en")
+	if err := tb.users.SetAdmin(context.Background(), alice.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	alice.IsAdmin = true
+
+	c := newCommandContext(alice, "@user1")
+	if err := tb.changeAdmin(c, false); err != nil {
+		t.Fatal(err)
+	}
+	if want := "The last admin's rights cannot be revoked."; len(c.sent) != 1 || c.sent[0] != want {
+		t.Errorf("sent %q, want %q", c.sent, want)
+	}
+}
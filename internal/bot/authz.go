@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"context"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	statusChangeAction = "update_status"
+	commentAction      = "comment"
+)
+
+// isAuthorized reports whether the current user may run the given action.
+// Read-only actions are open to everyone; mutating ones require admin rights
+// while the admin_gating flag is enabled. Exec always requires admin rights.
+func (b *Bot) isAuthorized(c telebot.Context, action string) bool {
+	if models.ActionType(action) == models.ActionExecInPod {
+		return requestUser(c).IsAdmin
+	}
+	if !b.flags.IsEnabled(models.FlagAdminGating) {
+		return true
+	}
+	if models.ActionType(action).IsReadOnly() {
+		return true
+	}
+	user := requestUser(c)
+	return user.IsAdmin
+}
+
+// denyUnauthorized rejects the callback and records the denied attempt in the
+// incident's audit log.
+func (b *Bot) denyUnauthorized(c telebot.Context, incidentID uint, action string, params map[string]string) error {
+	ctx := requestContext(c)
+	b.logger.WarnContext(ctx, "User is not allowed to run action", "incident_id", incidentID, "telegram_id", c.Sender().ID, "user", c.Sender().Username, "action", action)
+	req := models.ActionRequest{
+		Action:     action,
+		IncidentID: incidentID,
+		UserID:     requestUser(c).ID,
+		Parameters: params,
+	}
+	if err := b.service.RecordDeniedAction(ctx, req); err != nil {
+		b.logger.ErrorContext(ctx, "Failed to record denied action", "incident_id", incidentID, "action", action, "error", err)
+	}
+	return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.unauthorized"), ShowAlert: true})
+}
+
+func (b *Bot) syncAdminFlag(ctx context.Context, user *models.User) {
+	if !b.adminIDs[user.TelegramID] || user.IsAdmin {
+		return
+	}
+	if err := b.userRepo.SetAdmin(ctx, user.ID, true); err != nil {
+		b.logger.ErrorContext(ctx, "Failed to grant admin rights", "telegram_id", user.TelegramID, "error", err)
+		return
+	}
+	user.IsAdmin = true
+	b.logger.InfoContext(ctx, "Granted admin rights to bootstrap admin", "telegram_id", user.TelegramID, "user", user.Username)
+}
@@ -2,14 +2,23 @@ package bot
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
+	"unicode/utf8"
 
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/i18n"
+	"chatops-bot/internal/logging"
 	"chatops-bot/internal/models"
+	"chatops-bot/internal/notifier"
 	"chatops-bot/internal/service"
 
 	"gopkg.in/telebot.v3"
@@ -31,17 +40,39 @@ const (
 	getPodLogsPrefix            = "gpl:"
 	describePodPrefix           = "dp:"
 	describeDeploymentPrefix    = "dd:"
+	describeNodePrefix          = "dn:"
 	rollbackDeploymentPrefix    = "rbd:"
+	historyPagePrefix           = "hp:"
+	activePagePrefix            = "ap:"
+	acknowledgePrefix           = "ack:"
+	snoozePrefix                = "snz:"
+	commentPrefix               = "cm:"
+	reopenIncidentPrefix        = "ro:"
+	restoreReplicasPrefix       = "rrp:"
+	showKubectlPrefix           = "kc:"
+	pauseRolloutPrefix          = "prd:"
+	resumeRolloutPrefix         = "rsd:"
 )
 
+const defaultHistoryPageSize = 10
+
+const defaultLogTailLines = 100
+
+// logTailOptions are the log sizes offered under fetched logs.
+var logTailOptions = []int{50, 100, 500, 1000}
+
 type awaitingInputState struct {
 	Request   *models.ActionRequest
 	MessageID int
 	ChatID    int64
+	// CurrentReplicas is the desired replica count shown when scaling was
+	// requested; 0 when unknown.
+	CurrentReplicas int
 }
 
 type userState struct {
 	AwaitingRejectReasonFor    uint
+	AwaitingCommentFor         uint
 	AwaitingReplicaCountFor    *awaitingInputState
 	AwaitingHardwareRequestFor *awaitingInputState
 }
@@ -51,24 +82,47 @@ type Bot struct {
 	service             *service.IncidentService
 	userRepo            service.UserRepository
 	suggester           *service.ActionSuggester
+	flags               *service.FeatureFlagService
+	oncall              *service.OnCallRotation
+	clock               service.Clock
 	userStates          map[int64]*userState
 	mu                  sync.RWMutex
-	viewRegistry        map[uint]map[string]telebot.Editable
+	viewRegistry        map[uint]map[string]telebot.Editable // per-process cache of the persisted views, see incidentViews
 	registryMu          sync.RWMutex
-	alertChannelID      int64
+	router              alertRouter
+	resolvedChannelID   int64
+	escalationChannelID int64
+	historyPageSize     int
+	destructiveActions  map[string]bool
+	execCommands        []string
+	logTailLines        int
+	maxReplicas         int
+	pendingActions      *pendingActionStore
+	logFollows          *logFollowStore
+	callbackDedupe      *callbackDeduper
+	callbackTokens      *callbackTokenStore
+	limiter             *outboundLimiter
+	editRetries         *editRetryQueue
+	adminIDs            map[int64]bool
+	notifiers           *notifier.Fanout
 	ignoreNextUpdateFor map[uint]bool
 	ignoreMu            sync.Mutex
+	tr                  *i18n.Translator
+	channelLanguage     string
+	severityPolicy      severityPolicy
+	messageTemplate     *template.Template
+	logger              *slog.Logger
 }
 
-func isHighSeverity(incident *models.Incident) bool {
-	if severity, ok := incident.Labels["severity"]; ok {
-		return severity == "critical" || severity == "high"
+func NewBot(cfg config.TelegramConfig, actionsCfg config.ActionsConfig, service *service.IncidentService, userRepo service.UserRepository, suggester *service.ActionSuggester, flags *service.FeatureFlagService, oncall *service.OnCallRotation, notifiers *notifier.Fanout, logger *slog.Logger) (*Bot, error) {
+	if logger == nil {
+		logger = slog.Default()
 	}
-	return false
-}
-
-func NewBot(token string, service *service.IncidentService, userRepo service.UserRepository, suggester *service.ActionSuggester, alertChannelID int64) (*Bot, error) {
-	pref := telebot.Settings{Token: token, Poller: &telebot.LongPoller{Timeout: 10 * time.Second}}
+	messageTemplate, err := loadIncidentTemplate(cfg.MessageTemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	pref := telebot.Settings{Token: cfg.BotToken, Poller: &telebot.LongPoller{Timeout: 10 * time.Second}}
 	b, err := telebot.NewBot(pref)
 	if err != nil {
 		return nil, err
@@ -78,95 +132,177 @@ func NewBot(token string, service *service.IncidentService, userRepo service.Use
 		service:             service,
 		userRepo:            userRepo,
 		suggester:           suggester,
+		flags:               flags,
+		oncall:              oncall,
+		clock:               service.Clock(),
 		userStates:          make(map[int64]*userState),
 		viewRegistry:        make(map[uint]map[string]telebot.Editable),
-		alertChannelID:      alertChannelID,
+		router:              newAlertRouter(cfg),
+		resolvedChannelID:   cfg.ResolvedChannelID,
+		escalationChannelID: cfg.EscalationChannelID,
+		historyPageSize:     cfg.HistoryPageSize,
+		destructiveActions:  make(map[string]bool),
+		execCommands:        actionsCfg.ExecAllowlist,
+		logTailLines:        actionsCfg.LogTailLines,
+		maxReplicas:         actionsCfg.MaxReplicas,
+		pendingActions:      newPendingActionStore(),
+		logFollows:          newLogFollowStore(),
+		callbackDedupe:      newCallbackDeduper(callbackDedupeTTL),
+		limiter:             newOutboundLimiter(telegramMessagesPerSecond),
+		editRetries:         newEditRetryQueue(maxPendingEditRetries),
+		adminIDs:            make(map[int64]bool),
+		notifiers:           notifiers,
 		ignoreNextUpdateFor: make(map[uint]bool),
+		tr:                  i18n.NewTranslator(),
+		channelLanguage:     cfg.Language,
+		severityPolicy:      newSeverityPolicy(cfg),
+		messageTemplate:     messageTemplate,
+		logger:              logger,
+	}
+	destructiveActions := actionsCfg.DestructiveActions
+	if len(destructiveActions) == 0 {
+		destructiveActions = defaultDestructiveActions
+	}
+	for _, action := range destructiveActions {
+		botInstance.destructiveActions[action] = true
 	}
+	for _, id := range cfg.AdminIDs {
+		botInstance.adminIDs[id] = true
+	}
+	if botInstance.channelLanguage == "" {
+		botInstance.channelLanguage = i18n.DefaultLanguage
+	}
+	if botInstance.historyPageSize <= 0 {
+		botInstance.historyPageSize = defaultHistoryPageSize
+	}
+	if botInstance.logTailLines <= 0 {
+		botInstance.logTailLines = defaultLogTailLines
+	}
+	if botInstance.maxReplicas <= 0 {
+		botInstance.maxReplicas = defaultMaxReplicas
+	}
+	callbackTokenTTL := time.Duration(cfg.CallbackTokenTTL) * time.Second
+	if callbackTokenTTL <= 0 {
+		callbackTokenTTL = defaultCallbackTokenTTL
+	}
+	callbackTokenCapacity := cfg.CallbackTokenCapacity
+	if callbackTokenCapacity <= 0 {
+		callbackTokenCapacity = defaultCallbackTokenCapacity
+	}
+	botInstance.callbackTokens = newCallbackTokenStore(callbackTokenTTL, callbackTokenCapacity)
 	b.Use(botInstance.authMiddleware())
 	return botInstance, nil
 }
 
-func (b *Bot) Start(notifChan, updateChan, topicDeletionChan <-chan *models.Incident) {
+func (b *Bot) Start(notifChan, updateChan, topicDeletionChan, escalationChan, reminderChan, snoozeChan <-chan *models.Incident) {
 	b.registerHandlers()
 	go b.startNotifier(notifChan)
 	go b.startUpdateListener(updateChan)
 	go b.startTopicDeletionListener(topicDeletionChan)
-	log.Println("Telegram bot starting...")
+	go b.startEscalationListener(escalationChan)
+	go b.startReminderListener(reminderChan)
+	go b.startSnoozeListener(snoozeChan)
+	b.logger.Info("Telegram bot starting")
 	b.bot.Start()
 }
 
 func (b *Bot) startNotifier(notifChan <-chan *models.Incident) {
-	log.Println("Notification listener started.")
+	b.logger.Info("Notification listener started")
 	for incident := range notifChan {
-		log.Printf("Received notification for new incident: %s", incident.Summary)
+		b.logger.Info("Received notification for new incident", "incident_id", incident.ID, "summary", incident.Summary)
 
-		if b.alertChannelID == 0 {
-			log.Println("Alert channel ID is not configured, skipping notification.")
+		if incident.IsSnoozed(time.Now()) {
+			b.logger.Info("Incident is snoozed, skipping notification", "incident_id", incident.ID, "snoozed_until", incident.SnoozedUntil.Format(time.RFC3339))
 			continue
 		}
 
-		chat := &telebot.Chat{ID: b.alertChannelID}
-
-		if isHighSeverity(incident) {
-			b.handleHighSeverityIncident(chat, incident)
-		} else {
-			b.handleLowSeverityIncident(chat, incident)
+		reopened := incident.LastAuditAction() == "reopen"
+		if incident.TelegramMessageID.Valid && reopened && b.needsRepost(incident) {
+			b.logger.Info("Reopened incident lost its messages, posting it again", "incident_id", incident.ID)
+			b.updateIncidentView(incident)
+			b.notifyTelegram(incident)
+			continue
+		}
+		if incident.TelegramMessageID.Valid && reopened {
+			b.sendIncidentReminder(incident, b.tr.T(b.channelLanguage, "notify.reopened", incident.ID))
+			continue
+		}
+		if incident.TelegramMessageID.Valid {
+			b.logger.Info("Incident is already posted, refreshing its views", "incident_id", incident.ID)
+			b.updateIncidentView(incident)
+			continue
 		}
+		b.notifyTelegram(incident)
+		b.notifiers.NotifyNewIncident(incident)
+	}
+}
+
+func (b *Bot) notifyTelegram(incident *models.Incident) {
+	chatID := b.router.channelFor(incident)
+	if chatID == 0 {
+		b.logger.Warn("Alert channel ID is not configured, skipping notification", "incident_id", incident.ID)
+		return
+	}
+
+	chat := &telebot.Chat{ID: chatID}
+
+	if b.severityPolicy.usesTopic(incident) {
+		b.handleHighSeverityIncident(chat, incident)
+	} else {
+		b.handleLowSeverityIncident(chat, incident)
 	}
 }
 
 func (b *Bot) handleHighSeverityIncident(chat *telebot.Chat, incident *models.Incident) {
-	topicName := fmt.Sprintf("Инцидент #%d", incident.ID)
+	topicName := b.tr.T(b.channelLanguage, "notify.topic_name", incident.ID)
+	b.limiter.Wait()
 	topic, err := b.bot.CreateTopic(chat, &telebot.Topic{Name: topicName})
 	if err != nil {
-		log.Printf("Failed to create topic for incident %d: %v. Falling back to main channel.", incident.ID, err)
+		b.logger.Error("Failed to create topic, falling back to main channel", "incident_id", incident.ID, "error", err)
 		b.handleLowSeverityIncident(chat, incident)
 		return
 	}
 	b.service.SetTelegramTopicID(context.Background(), incident.ID, int64(topic.ThreadID))
+	incident.TelegramTopicID = sql.NullInt64{Int64: int64(topic.ThreadID), Valid: true}
 
 	message := b.formatIncidentMessage(incident, false)
 	suggestedActions := b.suggester.SuggestActions(incident)
-	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, false)
+	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, false, false)
 	topicSendOpts := &telebot.SendOptions{
 		ThreadID:              topic.ThreadID,
 		ParseMode:             telebot.ModeMarkdownV2,
 		ReplyMarkup:           &telebot.ReplyMarkup{InlineKeyboard: keyboard},
 		DisableWebPagePreview: true,
 	}
-	msg, err := b.bot.Send(chat, message, topicSendOpts)
+	msg, err := b.send(chat, message, topicSendOpts)
 	if err != nil {
-		log.Printf("Failed to send notification to topic %d: %v", topic.ThreadID, err)
+		b.logger.Error("Failed to send notification to topic", "incident_id", incident.ID, "topic_id", topic.ThreadID, "error", err)
 		return
 	}
 
-	b.service.SetTelegramMessageID(context.Background(), incident.ID, msg.Chat.ID, int64(msg.ID))
-	b.addIncidentView(incident.ID, msg)
+	b.setPrimaryMessage(incident, msg)
 
 	summaryMessage := b.formatIncidentMessage(incident, false)
-	channelIDForLink := strings.TrimPrefix(strconv.FormatInt(b.alertChannelID, 10), "-100")
-	topicURL := fmt.Sprintf("https://t.me/c/%s/%d", channelIDForLink, topic.ThreadID)
 	linkKeyboard := [][]telebot.InlineButton{
-		{{Text: "Перейти к обсуждению", URL: topicURL}},
+		{{Text: b.tr.T(b.channelLanguage, "notify.go_to_topic"), URL: topicURL(chat.ID, int64(topic.ThreadID))}},
 	}
 	summarySendOpts := &telebot.SendOptions{
 		ParseMode:   telebot.ModeMarkdownV2,
 		ReplyMarkup: &telebot.ReplyMarkup{InlineKeyboard: linkKeyboard},
 	}
-	summaryMsg, err := b.bot.Send(chat, summaryMessage, summarySendOpts)
+	summaryMsg, err := b.send(chat, summaryMessage, summarySendOpts)
 	if err != nil {
-		log.Printf("Failed to send summary notification to channel %d: %v", b.alertChannelID, err)
+		b.logger.Error("Failed to send summary notification to channel", "incident_id", incident.ID, "chat_id", chat.ID, "error", err)
 	} else {
-		b.addIncidentView(incident.ID, summaryMsg)
+		b.addIncidentView(incident.ID, summaryMsg, models.TelegramMessageSummary)
 	}
 }
 
 func (b *Bot) startTopicDeletionListener(deletionChan <-chan *models.Incident) {
-	log.Println("Topic deletion listener started.")
+	b.logger.Info("Topic deletion listener started")
 	for incident := range deletionChan {
 		if !incident.TelegramChatID.Valid || !incident.TelegramTopicID.Valid {
-			log.Printf("Cannot delete topic for incident %d: missing chat or topic ID.", incident.ID)
+			b.logger.Warn("Cannot delete topic: missing chat or topic ID", "incident_id", incident.ID)
 			continue
 		}
 
@@ -175,101 +311,291 @@ func (b *Bot) startTopicDeletionListener(deletionChan <-chan *models.Incident) {
 
 		err := b.bot.DeleteTopic(chat, topic)
 		if err != nil {
-			log.Printf("Failed to delete topic %d for incident %d: %v", topic.ThreadID, incident.ID, err)
+			b.logger.Error("Failed to delete topic", "incident_id", incident.ID, "topic_id", topic.ThreadID, "error", err)
 		} else {
-			log.Printf("Successfully deleted topic %d for incident %d.", topic.ThreadID, incident.ID)
+			b.logger.Info("Deleted topic", "incident_id", incident.ID, "topic_id", topic.ThreadID)
 			b.service.SetTelegramTopicID(context.Background(), incident.ID, 0)
 		}
 	}
 }
 
+func (b *Bot) startEscalationListener(escalationChan <-chan *models.Incident) {
+	b.logger.Info("Escalation listener started")
+	for incident := range escalationChan {
+		// Without a dedicated escalation channel the incident is re-posted to its alert channel.
+		chatID := b.escalationChannelID
+		if chatID == 0 {
+			chatID = b.alertChatID(incident)
+		}
+
+		minutes := b.activeMinutes(incident)
+		message := b.tr.T(b.channelLanguage, "notify.escalation_banner", minutes, incident.EscalationLevel)
+		if b.oncall != nil && b.oncall.Configured() {
+			user, _ := b.oncall.Current(b.clock.Now())
+			message += b.tr.T(b.channelLanguage, "notify.escalation_oncall", escapeMarkdown(strings.TrimPrefix(user, "@")))
+		}
+		message += b.formatIncidentMessage(incident, false)
+
+		var keyboard [][]telebot.InlineButton
+		if incident.TelegramTopicID.Valid && incident.TelegramTopicID.Int64 != 0 {
+			url := topicURL(b.alertChatID(incident), incident.TelegramTopicID.Int64)
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "notify.go_to_topic"), URL: url}})
+		}
+
+		sendOpts := &telebot.SendOptions{
+			ParseMode:             telebot.ModeMarkdownV2,
+			ReplyMarkup:           &telebot.ReplyMarkup{InlineKeyboard: keyboard},
+			DisableWebPagePreview: true,
+		}
+		if _, err := b.send(&telebot.Chat{ID: chatID}, message, sendOpts); err != nil {
+			b.logger.Error("Failed to send escalation", "incident_id", incident.ID, "level", incident.EscalationLevel, "error", err)
+		}
+		b.notifyAssignee(incident, models.NotifyEscalation, "prefs.dm_escalation", incident.ID, incident.EscalationLevel, incident.Summary)
+	}
+}
+
+// activeMinutes is how long the incident has been active by the service clock.
+// It counts from ActiveSince, so a reopened incident is not reported as firing
+// since it was first created.
+func (b *Bot) activeMinutes(incident *models.Incident) int {
+	since := incident.ActiveSince
+	if since.IsZero() {
+		since = incident.CreatedAt
+	}
+	return int(b.clock.Now().Sub(since).Minutes())
+}
+
+func (b *Bot) startReminderListener(reminderChan <-chan *models.Incident) {
+	b.logger.Info("Reminder listener started")
+	for incident := range reminderChan {
+		minutes := b.activeMinutes(incident)
+		b.sendIncidentReminder(incident, b.tr.T(b.channelLanguage, "notify.still_firing", incident.ID, minutes))
+		b.notifyAssignee(incident, models.NotifyReminder, "prefs.dm_reminder", incident.ID, minutes, incident.Summary)
+	}
+}
+
+// needsRepost reports whether a reopened incident has nothing left to reply to in the
+// alert channel: its topic was deleted, or its message was removed when it was closed.
+func (b *Bot) needsRepost(incident *models.Incident) bool {
+	if b.severityPolicy.usesTopic(incident) && (!incident.TelegramTopicID.Valid || incident.TelegramTopicID.Int64 == 0) {
+		return true
+	}
+	primary := &telebot.StoredMessage{
+		MessageID: strconv.FormatInt(incident.TelegramMessageID.Int64, 10),
+		ChatID:    b.alertChatID(incident),
+	}
+	_, ok := b.incidentViews(incident.ID)[getViewRegistryKey(primary)]
+	return !ok
+}
+
+// startSnoozeListener re-notifies about active incidents whose snooze lapsed.
+func (b *Bot) startSnoozeListener(snoozeChan <-chan *models.Incident) {
+	b.logger.Info("Snooze listener started")
+	for incident := range snoozeChan {
+		if !incident.TelegramMessageID.Valid {
+			b.logger.Info("Snoozed incident was never posted, posting it now", "incident_id", incident.ID)
+			b.notifyTelegram(incident)
+			b.notifiers.NotifyNewIncident(incident)
+			continue
+		}
+		b.sendIncidentReminder(incident, b.tr.T(b.channelLanguage, "notify.snooze_expired", incident.ID))
+	}
+}
+
+// sendIncidentReminder refreshes the incident views and replies to the incident's
+// primary message, inside its topic for high-severity incidents.
+func (b *Bot) sendIncidentReminder(incident *models.Incident, text string) {
+	freshIncident, err := b.service.GetIncidentByID(context.Background(), incident.ID)
+	if err != nil {
+		b.logger.Error("Failed to fetch incident for reminder", "incident_id", incident.ID, "error", err)
+		return
+	}
+	if !freshIncident.TelegramMessageID.Valid {
+		b.logger.Warn("Incident has no Telegram message, skipping reminder", "incident_id", incident.ID)
+		return
+	}
+	b.updateIncidentView(freshIncident)
+
+	opts := &telebot.SendOptions{
+		ReplyTo: &telebot.Message{ID: int(freshIncident.TelegramMessageID.Int64)},
+	}
+	if freshIncident.TelegramTopicID.Valid && freshIncident.TelegramTopicID.Int64 != 0 {
+		opts.ThreadID = int(freshIncident.TelegramTopicID.Int64)
+	}
+	if _, err := b.send(&telebot.Chat{ID: freshIncident.TelegramChatID.Int64}, text, opts); err != nil {
+		b.logger.Error("Failed to send reminder", "incident_id", freshIncident.ID, "error", err)
+	}
+}
+
 func (b *Bot) handleLowSeverityIncident(chat *telebot.Chat, incident *models.Incident) {
 	message := b.formatIncidentMessage(incident, false)
 	suggestedActions := b.suggester.SuggestActions(incident)
-	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, false)
+	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, false, false)
 	sendOpts := &telebot.SendOptions{
 		ParseMode:             telebot.ModeMarkdownV2,
 		ReplyMarkup:           &telebot.ReplyMarkup{InlineKeyboard: keyboard},
 		DisableWebPagePreview: true,
 	}
-	msg, err := b.bot.Send(chat, message, sendOpts)
+	msg, err := b.send(chat, message, sendOpts)
 	if err != nil {
-		log.Printf("Failed to send low-severity notification to channel %d: %v", b.alertChannelID, err)
+		b.logger.Error("Failed to send low-severity notification to channel", "incident_id", incident.ID, "chat_id", chat.ID, "error", err)
 		return
 	}
 
+	b.setPrimaryMessage(incident, msg)
+}
+
+// setPrimaryMessage stores msg as the incident's main message, also on the
+// incident itself so the notifiers that get it next can link to the message.
+func (b *Bot) setPrimaryMessage(incident *models.Incident, msg *telebot.Message) {
 	b.service.SetTelegramMessageID(context.Background(), incident.ID, msg.Chat.ID, int64(msg.ID))
-	b.addIncidentView(incident.ID, msg)
+	incident.TelegramChatID = sql.NullInt64{Int64: msg.Chat.ID, Valid: true}
+	incident.TelegramMessageID = sql.NullInt64{Int64: int64(msg.ID), Valid: true}
+	b.addIncidentView(incident.ID, msg, models.TelegramMessagePrimary)
 }
 
 func (b *Bot) startUpdateListener(updateChan <-chan *models.Incident) {
-	log.Println("Update listener started.")
+	b.logger.Info("Update listener started")
 	for incident := range updateChan {
-		log.Printf("Received update for incident ID %d", incident.ID)
+		b.logger.Debug("Received incident update", "incident_id", incident.ID)
+		b.notifiers.NotifyUpdate(incident)
+		if incident.Status != models.StatusActive {
+			b.logFollows.StopIncident(incident.ID)
+		}
 
 		b.ignoreMu.Lock()
 		if b.ignoreNextUpdateFor[incident.ID] {
 			delete(b.ignoreNextUpdateFor, incident.ID)
 			b.ignoreMu.Unlock()
-			log.Printf("Ignoring update for incident %d because a dynamic view is being shown.", incident.ID)
+			b.logger.Debug("Ignoring update because a dynamic view is being shown", "incident_id", incident.ID)
 			continue
 		}
 		b.ignoreMu.Unlock()
 
 		if !incident.TelegramChatID.Valid || !incident.TelegramMessageID.Valid {
-			log.Printf("Incident %d does not have a Telegram message ID, skipping update.", incident.ID)
+			b.logger.Debug("Incident has no Telegram message ID, skipping update", "incident_id", incident.ID)
 			continue
 		}
 
 		freshIncident, err := b.service.GetIncidentByID(context.Background(), incident.ID)
 		if err != nil {
-			log.Printf("Error fetching incident %d for update: %v", incident.ID, err)
+			b.logger.Error("Failed to fetch incident for update", "incident_id", incident.ID, "error", err)
 			continue
 		}
 
+		closing := isClosingUpdate(freshIncident)
+		if !closing && freshIncident.IsSnoozed(time.Now()) {
+			b.logger.Debug("Incident is snoozed, skipping update", "incident_id", incident.ID)
+			continue
+		}
+		if closing && b.resolvedChannelID != 0 {
+			b.removeAlertChannelViews(freshIncident)
+		}
+
 		b.updateIncidentView(freshIncident)
 
+		if closing && b.resolvedChannelID != 0 {
+			b.notifyResolvedChannel(freshIncident)
+		}
+
 		if freshIncident.Status == models.StatusResolved || freshIncident.Status == models.StatusRejected {
 			if freshIncident.TelegramTopicID.Valid {
 				topic := &telebot.Topic{ThreadID: int(freshIncident.TelegramTopicID.Int64)}
 				err := b.bot.CloseTopic(&telebot.Chat{ID: freshIncident.TelegramChatID.Int64}, topic)
 				if err != nil {
-					log.Printf("Failed to close topic %d for incident %d: %v", freshIncident.TelegramTopicID.Int64, freshIncident.ID, err)
+					b.logger.Error("Failed to close topic", "incident_id", freshIncident.ID, "topic_id", freshIncident.TelegramTopicID.Int64, "error", err)
 				}
 			}
 		}
 	}
 }
 
-func (b *Bot) registerHandlers() {
-	b.bot.Handle("/start", b.handleStart)
-	b.bot.Handle("/help", b.handleHelp)
-	b.bot.Handle("/incidents", b.handleListIncidents)
-	b.bot.Handle("/history", b.handleHistory)
-	b.bot.Handle("/delete_incident_topic", b.handleDeleteIncidentTopic)
-	b.bot.Handle(telebot.OnCallback, b.handleCallback)
-	b.bot.Handle(telebot.OnText, b.handleTextMessage)
+func isClosingUpdate(incident *models.Incident) bool {
+	if incident.Status != models.StatusResolved && incident.Status != models.StatusRejected {
+		return false
+	}
+	if len(incident.AuditLog) == 0 {
+		return false
+	}
+	last := incident.AuditLog[len(incident.AuditLog)-1]
+	return last.Action == "update_status" && last.Parameters["new_status"] == string(incident.Status)
 }
 
-func (b *Bot) handleStart(c telebot.Context) error {
-	return c.Send("Добро пожаловать! Используйте /help для просмотра доступных команд.")
+func (b *Bot) notifyResolvedChannel(incident *models.Incident) {
+	chat := &telebot.Chat{ID: b.resolvedChannelID}
+	message := b.formatIncidentMessage(incident, false)
+	keyboard := b.buildClosedIncidentViewKeyboard(incident, false)
+	sendOpts := &telebot.SendOptions{
+		ParseMode:             telebot.ModeMarkdownV2,
+		ReplyMarkup:           &telebot.ReplyMarkup{InlineKeyboard: keyboard},
+		DisableWebPagePreview: true,
+	}
+	msg, err := b.send(chat, message, sendOpts)
+	if err != nil {
+		b.logger.Error("Failed to send resolved notification to channel", "incident_id", incident.ID, "chat_id", b.resolvedChannelID, "error", err)
+		return
+	}
+	b.addIncidentView(incident.ID, msg, models.TelegramMessageResolved)
 }
 
-func (b *Bot) handleHelp(c telebot.Context) error {
-	helpText := `
-*Доступные команды:*
+// removeAlertChannelViews deletes the incident's messages from the firing channel so it
+// only shows active problems. The message inside the incident topic is kept.
+func (b *Bot) removeAlertChannelViews(incident *models.Incident) {
+	views := b.incidentViews(incident.ID)
+	hasTopic := incident.TelegramTopicID.Valid && incident.TelegramTopicID.Int64 != 0
+	alertChatID := b.alertChatID(incident)
+	for key, editable := range views {
+		msgSig, chatID := editable.MessageSig()
+		if chatID != alertChatID {
+			continue
+		}
+		if hasTopic && incident.TelegramMessageID.Valid && msgSig == strconv.FormatInt(incident.TelegramMessageID.Int64, 10) {
+			continue
+		}
+		if err := b.bot.Delete(editable); err != nil {
+			b.logger.Error("Failed to delete view from alert channel", "incident_id", incident.ID, "view", key, "error", err)
+		}
+		b.forgetIncidentView(incident.ID, editable)
+	}
+}
 
-*/incidents* - Показать список активных инцидентов.
-  • *Использование:* /incidents
-  • *Просмотр конкретного инцидента:* /incidents <ID>
+func (b *Bot) registerHandlers() {
+	b.bot.Handle("/start", b.requireUser(b.handleStart))
+	b.bot.Handle("/help", b.requireUser(b.handleHelp))
+	b.bot.Handle("/incidents", b.requireUser(b.handleListIncidents))
+	b.bot.Handle("/history", b.requireUser(b.handleHistory))
+	b.bot.Handle("/delete_incident_topic", b.requireUser(b.handleDeleteIncidentTopic))
+	b.bot.Handle("/flags", b.requireUser(b.handleFlags))
+	b.bot.Handle("/oncall", b.requireUser(b.handleOnCall))
+	b.bot.Handle("/stats", b.requireUser(b.handleStats))
+	b.bot.Handle("/ack", b.requireUser(b.handleAck))
+	b.bot.Handle("/ack_all", b.requireUser(b.handleAckAll))
+	b.bot.Handle("/find", b.requireUser(b.handleFind))
+	b.bot.Handle("/resolve_all", b.requireUser(b.handleResolveAll))
+	b.bot.Handle("/assign", b.requireUser(b.handleAssign))
+	b.bot.Handle("/comment", b.requireUser(b.handleComment))
+	b.bot.Handle("/tag", b.requireUser(b.handleTag))
+	b.bot.Handle("/untag", b.requireUser(b.handleUntag))
+	b.bot.Handle("/lang", b.requireUser(b.handleLang))
+	b.bot.Handle("/notify", b.requireUser(b.handleNotify))
+	b.bot.Handle("/whoami", b.requireUser(b.handleWhoami))
+	b.bot.Handle("/users", b.requireUser(b.handleUsers))
+	b.bot.Handle("/grant_admin", b.requireUser(b.handleGrantAdmin))
+	b.bot.Handle("/revoke_admin", b.requireUser(b.handleRevokeAdmin))
+	b.bot.Handle("/refresh", b.requireUser(b.handleRefresh))
+	b.bot.Handle("/silence", b.requireUser(b.handleSilence))
+	b.bot.Handle("/silences", b.requireUser(b.handleSilences))
+	b.bot.Handle("/unsilence", b.requireUser(b.handleUnsilence))
+	b.bot.Handle(telebot.OnCallback, b.requireUser(b.handleCallback))
+	b.bot.Handle(telebot.OnText, b.requireUser(b.handleTextMessage))
+}
 
-*/history* - Показать историю закрытых инцидентов.
-  • *Использование:* /history
-  • *Просмотр конкретного инцидента:* /history <ID>
+func (b *Bot) handleStart(c telebot.Context) error {
+	return c.Send(b.t(c, "start.welcome"))
+}
 
-*/help* - Показать это сообщение.
-`
-	return c.Send(helpText, &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+func (b *Bot) handleHelp(c telebot.Context) error {
+	return b.sendMarkdown(c, b.t(c, "help.text"), &telebot.SendOptions{ParseMode: telebot.ModeMarkdownV2})
 }
 
 func (b *Bot) handleListIncidents(c telebot.Context) error {
@@ -277,9 +603,9 @@ func (b *Bot) handleListIncidents(c telebot.Context) error {
 	if len(args) == 1 {
 		incidentID, err := strconv.ParseUint(args[0], 10, 32)
 		if err == nil {
-			incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+			incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 			if err != nil {
-				return c.Send("Не удалось найти инцидент.")
+				return c.Send(b.t(c, "common.incident_not_found"))
 			}
 
 			message := b.formatIncidentMessage(incident, false)
@@ -290,50 +616,98 @@ func (b *Bot) handleListIncidents(c telebot.Context) error {
 				keyboard = b.buildClosedIncidentViewKeyboard(incident, false)
 			}
 
-			msg, err := b.bot.Send(c.Chat(), message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+			msg, err := b.safeSend(c.Chat(), message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 			if err == nil {
-				b.addIncidentView(incident.ID, msg)
+				b.addIncidentView(incident.ID, msg, models.TelegramMessageView)
 			}
 			return err
 		}
 	}
 
-	incidents, err := b.service.ListActiveIncidents(c.Get("ctx").(context.Context))
+	text, keyboard, err := b.buildActivePage(requestContext(c), b.lang(c), 0)
 	if err != nil {
-		return c.Send("Не удалось получить список инцидентов.")
+		return c.Send(b.t(c, "incidents.list_failed"))
 	}
-	if len(incidents) == 0 {
-		return c.Send("Активных инцидентов нет.")
+	return c.Send(text, &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) handleActivePage(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_page")})
+	}
+
+	text, keyboard, err := b.buildActivePage(requestContext(c), b.lang(c), offset)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "incidents.list_failed")})
+	}
+	err = c.Edit(text, &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+	if isBenignEditError(err) || isMessageGoneError(err) {
+		return c.Respond()
+	}
+	return err
+}
+
+func (b *Bot) buildActivePage(ctx context.Context, lang string, offset int) (string, [][]telebot.InlineButton, error) {
+	limit := b.historyPageSize
+	incidents, total, err := b.service.ListActiveIncidentsPage(ctx, limit, offset)
+	if err != nil {
+		return "", nil, err
 	}
+	if total == 0 {
+		return b.tr.T(lang, "incidents.none"), nil, nil
+	}
+
 	var keyboard [][]telebot.InlineButton
 	for _, inc := range incidents {
 		row := []telebot.InlineButton{{
 			Text: fmt.Sprintf("🚨 #%d %s (%s)", inc.ID, inc.Summary, inc.Status),
-			Data: viewIncidentPrefix + strconv.FormatUint(uint64(inc.ID), 10),
+			Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(inc.ID), 10)),
 		}}
 		keyboard = append(keyboard, row)
 	}
-	return c.Send("Активные инциденты:", &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+
+	var navRow []telebot.InlineButton
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.prev"), Data: b.callbackData(fmt.Sprintf("%s%d", activePagePrefix, prevOffset))})
+	}
+	if int64(offset+len(incidents)) < total {
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.next"), Data: b.callbackData(fmt.Sprintf("%s%d", activePagePrefix, offset+limit))})
+	}
+	if len(navRow) > 0 {
+		keyboard = append(keyboard, navRow)
+	}
+
+	text := b.tr.T(lang, "incidents.active_count", total)
+	if int64(len(incidents)) < total {
+		text += "\n" + b.tr.T(lang, "incidents.shown_range", offset+1, offset+len(incidents))
+	}
+	return text, keyboard, nil
 }
 
 func (b *Bot) handleDeleteIncidentTopic(c telebot.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return c.Send("Пожалуйста, укажите ID инцидента. \nИспользование: `/delete_incident_topic <ID>`")
+		return c.Send(b.t(c, "topic.delete_usage"))
 	}
 
 	incidentID, err := strconv.ParseUint(args[0], 10, 32)
 	if err != nil {
-		return c.Send("Неверный ID инцидента. Пожалуйста, введите число.")
+		return c.Send(b.t(c, "common.invalid_id"))
 	}
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Send(fmt.Sprintf("Инцидент с ID %d не найден.", incidentID))
+		return c.Send(b.t(c, "topic.incident_not_found", incidentID))
 	}
 
 	if !incident.TelegramTopicID.Valid || incident.TelegramTopicID.Int64 == 0 {
-		return c.Send(fmt.Sprintf("У инцидента #%d нет связанного топика для удаления.", incident.ID))
+		return c.Send(b.t(c, "topic.none", incident.ID))
 	}
 
 	chat := &telebot.Chat{ID: incident.TelegramChatID.Int64}
@@ -341,14 +715,308 @@ func (b *Bot) handleDeleteIncidentTopic(c telebot.Context) error {
 
 	err = b.bot.DeleteTopic(chat, topic)
 	if err != nil {
-		log.Printf("Failed to manually delete topic %d for incident %d: %v", topic.ThreadID, incident.ID, err)
-		return c.Send(fmt.Sprintf("Не удалось удалить топик для инцидента #%d. Ошибка: %v", incident.ID, err))
+		b.logger.ErrorContext(requestContext(c), "Failed to manually delete topic", "incident_id", incident.ID, "topic_id", topic.ThreadID, "error", err)
+		return c.Send(b.t(c, "topic.delete_failed", incident.ID, err))
 	}
 
-	log.Printf("Manually deleted topic %d for incident %d by user %s.", topic.ThreadID, incident.ID, c.Sender().Username)
+	b.logger.InfoContext(requestContext(c), "Manually deleted topic", "incident_id", incident.ID, "topic_id", topic.ThreadID, "user", c.Sender().Username)
 	b.service.SetTelegramTopicID(context.Background(), incident.ID, 0)
 
-	return c.Send(fmt.Sprintf("Топик для инцидента #%d успешно удален.", incident.ID))
+	return c.Send(b.t(c, "topic.deleted", incident.ID))
+}
+
+func (b *Bot) handleFlags(c telebot.Context) error {
+	user := requestUser(c)
+	if !user.IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+
+	args := c.Args()
+	if len(args) == 2 {
+		var enabled bool
+		switch strings.ToLower(args[1]) {
+		case "on", "true", "1":
+			enabled = true
+		case "off", "false", "0":
+			enabled = false
+		default:
+			return c.Send(b.t(c, "flags.invalid_value"))
+		}
+		if err := b.flags.Set(requestContext(c), args[0], enabled); err != nil {
+			return c.Send(b.t(c, "flags.set_failed", err))
+		}
+		b.logger.InfoContext(requestContext(c), "Feature flag changed", "flag", args[0], "enabled", enabled, "user", user.Username)
+	} else if len(args) != 0 {
+		return c.Send(b.t(c, "flags.usage"))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(b.t(c, "flags.header") + "\n")
+	for _, flag := range b.flags.List() {
+		icon := "⚪️"
+		if flag.Enabled {
+			icon = "🟢"
+		}
+		builder.WriteString(fmt.Sprintf("%s %s\n", icon, flag.Name))
+	}
+	return c.Send(builder.String())
+}
+
+func (b *Bot) handleOnCall(c telebot.Context) error {
+	load, err := b.service.OnCallLoad(requestContext(c), b.oncall)
+	if err != nil {
+		return c.Send(b.t(c, "incidents.list_failed"))
+	}
+
+	var builder strings.Builder
+	if load.User == "" {
+		builder.WriteString(b.t(c, "oncall.not_configured") + "\n")
+	} else {
+		builder.WriteString(b.t(c, "oncall.current", load.User, load.ShiftEnd.Format("02.01 15:04")) + "\n")
+		builder.WriteString(b.t(c, "oncall.assigned", load.Assigned) + "\n")
+	}
+	builder.WriteString(b.t(c, "incidents.active_count", load.Active) + "\n")
+
+	if load.Oldest != nil {
+		builder.WriteString(b.t(c, "oncall.oldest", load.Oldest.ID, load.Oldest.Summary, load.OldestAge.Truncate(time.Minute)) + "\n")
+	} else if load.Active > 0 {
+		builder.WriteString(b.t(c, "oncall.all_acked") + "\n")
+	}
+
+	return c.Send(builder.String())
+}
+
+const maxFindResults = 30
+
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-/]+$`)
+
+func (b *Bot) handleFind(c telebot.Context) error {
+	args := c.Args()
+	if len(args) == 0 {
+		return c.Send(b.t(c, "find.usage"))
+	}
+
+	labels := make(map[string]string)
+	var status models.IncidentStatus
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" || value == "" || !labelKeyPattern.MatchString(key) {
+			return c.Send(b.t(c, "find.invalid_filter", arg))
+		}
+		if key == "status" {
+			switch models.IncidentStatus(value) {
+			case models.StatusActive, models.StatusResolved, models.StatusRejected:
+				status = models.IncidentStatus(value)
+			default:
+				return c.Send(b.t(c, "find.invalid_status"))
+			}
+			continue
+		}
+		labels[key] = value
+	}
+	if len(labels) == 0 {
+		return c.Send(b.t(c, "find.no_labels"))
+	}
+
+	incidents, err := b.service.FindIncidentsByLabels(requestContext(c), labels, status)
+	if err != nil {
+		b.logger.ErrorContext(requestContext(c), "Failed to find incidents by labels", "labels", labels, "error", err)
+		return c.Send(b.t(c, "find.failed"))
+	}
+	if len(incidents) == 0 {
+		return c.Send(b.t(c, "find.none"))
+	}
+
+	text := b.t(c, "find.found", len(incidents))
+	if len(incidents) > maxFindResults {
+		incidents = incidents[:maxFindResults]
+		text += "\n" + b.t(c, "find.truncated", maxFindResults)
+	}
+
+	var keyboard [][]telebot.InlineButton
+	for _, inc := range incidents {
+		icon := "🚨"
+		switch inc.Status {
+		case models.StatusResolved:
+			icon = "✅"
+		case models.StatusRejected:
+			icon = "❌"
+		}
+		keyboard = append(keyboard, []telebot.InlineButton{{
+			Text: fmt.Sprintf("%s #%d %s (%s)", icon, inc.ID, inc.Summary, inc.Status),
+			Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(inc.ID), 10)),
+		}})
+	}
+	return c.Send(text, &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) handleAck(c telebot.Context) error {
+	args := c.Args()
+	if len(args) < 1 || len(args) > 2 {
+		return c.Send(b.t(c, "ack.usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+	force := len(args) == 2 && args[1] == "force"
+
+	ctx := requestContext(c)
+	user := requestUser(c)
+	if err := b.service.Acknowledge(ctx, user.ID, uint(incidentID), force); err != nil {
+		return c.Send(b.acknowledgeErrorText(c, err))
+	}
+	return c.Send(b.t(c, "ack.done", incidentID))
+}
+
+func (b *Bot) handleAssign(c telebot.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Send(b.t(c, "assign.usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+	username := strings.TrimPrefix(args[1], "@")
+	if username == "" {
+		return c.Send(b.t(c, "assign.usage"))
+	}
+
+	ctx := requestContext(c)
+	user := requestUser(c)
+	assignee, err := b.service.FindUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return c.Send(b.t(c, "assign.user_not_found", username))
+		}
+		b.logger.ErrorContext(requestContext(c), "Failed to find user", "username", username, "error", err)
+		return c.Send(b.t(c, "assign.failed"))
+	}
+
+	incident, err := b.service.Assign(ctx, user.ID, uint(incidentID), assignee.TelegramID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIncidentNotActive):
+			return c.Send(b.t(c, "common.incident_closed"))
+		case errors.Is(err, service.ErrUserNotFound):
+			return c.Send(b.t(c, "assign.user_not_found", username))
+		}
+		b.logger.ErrorContext(requestContext(c), "Failed to assign incident", "incident_id", incidentID, "error", err)
+		return c.Send(b.t(c, "assign.failed"))
+	}
+	if assignee.ID != user.ID {
+		b.notifyUser(assignee, models.NotifyAssigned, "prefs.dm_assigned", incident.ID, incident.Summary, userDisplayName(user))
+	}
+	return c.Send(b.t(c, "assign.done", incidentID, userDisplayName(assignee)))
+}
+
+func (b *Bot) handleComment(c telebot.Context) error {
+	args := c.Args()
+	if len(args) < 2 {
+		return c.Send(b.t(c, "comment.usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(c.Message().Payload), args[0]))
+	return b.addComment(c, uint(incidentID), text)
+}
+
+func (b *Bot) handleCommentCallback(c telebot.Context, incidentID uint) error {
+	b.mu.Lock()
+	b.userStates[c.Sender().ID] = &userState{AwaitingCommentFor: incidentID}
+	b.mu.Unlock()
+
+	c.Respond()
+	sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), incidentID)
+	_, err := b.bot.Send(c.Chat(), b.t(c, "comment.prompt", incidentID), sendOpts)
+	return err
+}
+
+func (b *Bot) addComment(c telebot.Context, incidentID uint, text string) error {
+	ctx := requestContext(c)
+	user := requestUser(c)
+	sendOpts, _ := b.getSendOptionsForIncident(ctx, incidentID)
+
+	if err := b.service.AddComment(ctx, user.ID, incidentID, text); err != nil {
+		if errors.Is(err, service.ErrEmptyComment) {
+			_, err = b.bot.Send(c.Chat(), b.t(c, "comment.empty"), sendOpts)
+			return err
+		}
+		b.logger.ErrorContext(requestContext(c), "Failed to add comment", "incident_id", incidentID, "error", err)
+		_, err = b.bot.Send(c.Chat(), b.t(c, "comment.failed"), sendOpts)
+		return err
+	}
+	_, err := b.bot.Send(c.Chat(), b.t(c, "comment.added", incidentID), sendOpts)
+	return err
+}
+
+func (b *Bot) handleAcknowledgeCallback(c telebot.Context, incidentID uint) error {
+	ctx := requestContext(c)
+	user := requestUser(c)
+	if err := b.service.Acknowledge(ctx, user.ID, incidentID, false); err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.acknowledgeErrorText(c, err), ShowAlert: true})
+	}
+	c.Respond(&telebot.CallbackResponse{Text: b.t(c, "ack.done_short")})
+	return b.showIncidentView(c, incidentID, false)
+}
+
+var snoozeDurations = []struct {
+	LabelKey string
+	Duration time.Duration
+}{
+	{"snooze.15m", 15 * time.Minute},
+	{"snooze.1h", time.Hour},
+	{"snooze.4h", 4 * time.Hour},
+}
+
+func (b *Bot) handleSnooze(c telebot.Context, incidentID uint) error {
+	parts := strings.Split(c.Data(), ":")
+	idStr := strconv.FormatUint(uint64(incidentID), 10)
+	if len(parts) < 3 {
+		var row []telebot.InlineButton
+		for _, option := range snoozeDurations {
+			row = append(row, telebot.InlineButton{Text: b.t(c, option.LabelKey), Data: b.callbackData(fmt.Sprintf("%s%s:%s", snoozePrefix, idStr, option.Duration))})
+		}
+		keyboard := [][]telebot.InlineButton{row, {{Text: b.t(c, "common.back"), Data: b.callbackData(viewIncidentPrefix + idStr)}}}
+		return c.Edit(b.t(c, "snooze.prompt"), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+	}
+
+	duration, err := time.ParseDuration(parts[2])
+	if err != nil || duration <= 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_duration")})
+	}
+
+	ctx := requestContext(c)
+	user := requestUser(c)
+	if _, err := b.service.Snooze(ctx, user.ID, incidentID, duration); err != nil {
+		if errors.Is(err, service.ErrIncidentNotActive) {
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_closed"), ShowAlert: true})
+		}
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "snooze.failed")})
+	}
+	c.Respond(&telebot.CallbackResponse{Text: b.t(c, "snooze.done", duration)})
+	return b.showIncidentView(c, incidentID, false)
+}
+
+func (b *Bot) acknowledgeErrorText(c telebot.Context, err error) string {
+	switch {
+	case errors.Is(err, service.ErrAlreadyAcknowledged):
+		return b.t(c, "ack.already_taken")
+	case errors.Is(err, service.ErrIncidentNotActive):
+		return b.t(c, "common.incident_closed")
+	default:
+		return b.t(c, "ack.failed")
+	}
+}
+
+func userDisplayName(user *models.User) string {
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
 }
 
 func (b *Bot) handleHistory(c telebot.Context) error {
@@ -356,9 +1024,9 @@ func (b *Bot) handleHistory(c telebot.Context) error {
 	if len(args) == 1 {
 		incidentID, err := strconv.ParseUint(args[0], 10, 32)
 		if err == nil {
-			incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+			incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 			if err != nil {
-				return c.Send("Не удалось найти инцидент.")
+				return c.Send(b.t(c, "common.incident_not_found"))
 			}
 
 			message := b.formatIncidentMessage(incident, false)
@@ -369,21 +1037,54 @@ func (b *Bot) handleHistory(c telebot.Context) error {
 				keyboard = b.buildClosedIncidentViewKeyboard(incident, false)
 			}
 
-			msg, err := b.bot.Send(c.Chat(), message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+			msg, err := b.safeSend(c.Chat(), message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 			if err == nil {
-				b.addIncidentView(incident.ID, msg)
+				b.addIncidentView(incident.ID, msg, models.TelegramMessageView)
 			}
 			return err
 		}
 	}
 
-	incidents, err := b.service.ListClosed(c.Get("ctx").(context.Context), 10, 0)
+	text, keyboard, err := b.buildHistoryPage(requestContext(c), b.lang(c), 0)
 	if err != nil {
-		return c.Send("Не удалось получить историю инцидентов.")
+		return c.Send(b.t(c, "history.failed"))
 	}
-	if len(incidents) == 0 {
-		return c.Send("История закрытых инцидентов пуста.")
+	return c.Send(text, &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) handleHistoryPage(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_page")})
+	}
+
+	text, keyboard, err := b.buildHistoryPage(requestContext(c), b.lang(c), offset)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "history.failed")})
+	}
+	err = c.Edit(text, &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+	if isBenignEditError(err) || isMessageGoneError(err) {
+		return c.Respond()
 	}
+	return err
+}
+
+func (b *Bot) buildHistoryPage(ctx context.Context, lang string, offset int) (string, [][]telebot.InlineButton, error) {
+	limit := b.historyPageSize
+	incidents, err := b.service.ListClosed(ctx, limit+1, offset)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(incidents) == 0 && offset == 0 {
+		return b.tr.T(lang, "history.empty"), nil, nil
+	}
+
+	hasNext := len(incidents) > limit
+	if hasNext {
+		incidents = incidents[:limit]
+	}
+
 	var keyboard [][]telebot.InlineButton
 	for _, inc := range incidents {
 		icon := "✅"
@@ -392,23 +1093,89 @@ func (b *Bot) handleHistory(c telebot.Context) error {
 		}
 		row := []telebot.InlineButton{{
 			Text: fmt.Sprintf("%s #%d %s (%s)", icon, inc.ID, inc.Summary, inc.Status),
-			Data: viewIncidentPrefix + strconv.FormatUint(uint64(inc.ID), 10),
+			Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(inc.ID), 10)),
 		}}
 		keyboard = append(keyboard, row)
 	}
-	return c.Send("Последние закрытые инциденты:", &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+
+	var navRow []telebot.InlineButton
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.prev"), Data: b.callbackData(fmt.Sprintf("%s%d", historyPagePrefix, prevOffset))})
+	}
+	if hasNext {
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.next"), Data: b.callbackData(fmt.Sprintf("%s%d", historyPagePrefix, offset+limit))})
+	}
+	if len(navRow) > 0 {
+		keyboard = append(keyboard, navRow)
+	}
+
+	text := b.tr.T(lang, "history.recent")
+	if offset > 0 {
+		text = b.tr.T(lang, "history.range", offset+1, offset+len(incidents))
+	}
+	return text, keyboard, nil
 }
 
 func (b *Bot) handleCallback(c telebot.Context) error {
-	data := c.Data()
+	version, payload := decodeCallbackVersion(c.Data())
+	data, ok := b.resolveCallbackData(payload)
+	if !ok {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.button_expired"), ShowAlert: true})
+	}
+	outdated := &telebot.CallbackResponse{Text: b.t(c, "common.message_outdated"), ShowAlert: true}
+	if !isCurrentCallback(version, data) {
+		b.logger.Debug("Ignoring callback in an outdated format", "version", version, "data", data)
+		return c.Respond(outdated)
+	}
+	c.Callback().Data = data
 	parts := strings.Split(data, ":")
 	if len(parts) < 2 {
-		return c.Respond()
+		return c.Respond(outdated)
 	}
 	prefix := parts[0] + ":"
 	incidentID, err := strconv.ParseUint(parts[1], 10, 32)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Invalid incident ID"})
+		return c.Respond(outdated)
+	}
+	if b.callbackDedupe.Seen(callbackDedupeKey(c)) {
+		b.logger.Debug("Ignoring duplicate callback", "incident_id", incidentID, "data", data)
+		return c.Respond()
+	}
+
+	callbackParams := map[string]string{"callback_data": data}
+	switch prefix {
+	case closeIncidentPrefix, setStatusPrefix, reopenIncidentPrefix, setSeverityPrefix:
+		if !b.isAuthorized(c, statusChangeAction) {
+			return b.denyUnauthorized(c, uint(incidentID), statusChangeAction, callbackParams)
+		}
+	case scaleDeploymentPrefix, restoreReplicasPrefix:
+		if !b.isAuthorized(c, string(models.ActionScaleDeployment)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionScaleDeployment), callbackParams)
+		}
+	case allocateHardwarePrefix:
+		if !b.isAuthorized(c, string(models.ActionAllocateHardware)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionAllocateHardware), callbackParams)
+		}
+	case rollbackDeploymentPrefix:
+		if !b.isAuthorized(c, string(models.ActionRollbackDeployment)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionRollbackDeployment), callbackParams)
+		}
+	case pauseRolloutPrefix:
+		if !b.isAuthorized(c, string(models.ActionPauseRollout)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionPauseRollout), callbackParams)
+		}
+	case resumeRolloutPrefix:
+		if !b.isAuthorized(c, string(models.ActionResumeRollout)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionResumeRollout), callbackParams)
+		}
+	case showExecCommandsPrefix, execInPodPrefix:
+		if !b.isAuthorized(c, string(models.ActionExecInPod)) {
+			return b.denyUnauthorized(c, uint(incidentID), string(models.ActionExecInPod), callbackParams)
+		}
 	}
 
 	switch prefix {
@@ -420,6 +1187,8 @@ func (b *Bot) handleCallback(c telebot.Context) error {
 		return b.showCloseOptions(c, uint(incidentID))
 	case setStatusPrefix:
 		return b.handleSetStatus(c)
+	case reopenIncidentPrefix:
+		return b.handleReopenIncident(c)
 	case performActionPrefix:
 		return b.handlePerformAction(c)
 	case viewResourcePrefix:
@@ -428,22 +1197,66 @@ func (b *Bot) handleCallback(c telebot.Context) error {
 		return b.handlePerformResourceAction(c)
 	case scaleDeploymentPrefix:
 		return b.handleScaleDeployment(c)
+	case restoreReplicasPrefix:
+		return b.handleRestoreReplicas(c)
+	case showKubectlPrefix:
+		return b.showKubectlCommands(c)
+	case showSeverityOptionsPrefix:
+		return b.showSeverityOptions(c, uint(incidentID))
+	case setSeverityPrefix:
+		return b.handleSetSeverity(c, uint(incidentID))
 	case allocateHardwarePrefix:
 		return b.handleAllocateHardware(c)
 	case toggleHistoryPrefix:
 		return b.handleToggleHistory(c)
+	case fullHistoryPrefix:
+		return b.handleFullHistory(c, uint(incidentID))
 	case listPodsForDeploymentPrefix:
 		return b.handleListPodsForDeployment(c)
 	case listContainersForPodPrefix:
 		return b.handleListContainersForPod(c)
 	case getPodLogsPrefix:
 		return b.handleGetPodLogs(c)
+	case followPodLogsPrefix:
+		return b.handleFollowPodLogs(c)
+	case stopLogFollowPrefix:
+		return b.handleStopLogFollow(c)
+	case showExecCommandsPrefix:
+		return b.handleShowExecCommands(c)
+	case execInPodPrefix:
+		return b.handleExecInPod(c)
 	case describePodPrefix:
 		return b.handleDescribePod(c)
 	case describeDeploymentPrefix:
 		return b.handleDescribeDeployment(c)
+	case describeNodePrefix:
+		return b.handleDescribeNode(c)
 	case rollbackDeploymentPrefix:
 		return b.handleRollbackDeployment(c)
+	case pauseRolloutPrefix:
+		return b.handleRolloutOperation(c, models.ActionPauseRollout)
+	case resumeRolloutPrefix:
+		return b.handleRolloutOperation(c, models.ActionResumeRollout)
+	case historyPagePrefix:
+		return b.handleHistoryPage(c)
+	case usersPagePrefix:
+		return b.handleUsersPage(c)
+	case activePagePrefix:
+		return b.handleActivePage(c)
+	case acknowledgePrefix:
+		return b.handleAcknowledgeCallback(c, uint(incidentID))
+	case snoozePrefix:
+		return b.handleSnooze(c, uint(incidentID))
+	case commentPrefix:
+		return b.handleCommentCallback(c, uint(incidentID))
+	case confirmActionPrefix:
+		return b.handleConfirmAction(c)
+	case cancelActionPrefix:
+		return b.handleCancelAction(c, uint(incidentID))
+	case confirmResolveAllPrefix:
+		return b.handleConfirmResolveAll(c)
+	case cancelResolveAllPrefix:
+		return b.handleCancelResolveAll(c)
 	default:
 		return c.Respond()
 	}
@@ -463,33 +1276,58 @@ func (b *Bot) handleTextMessage(c telebot.Context) error {
 		b.mu.Unlock()
 
 		reason := c.Text()
-		user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+		user := requestUser(c)
 
-		err := b.service.UpdateStatus(c.Get("ctx").(context.Context), user.ID, incidentID, models.StatusRejected, reason)
+		err := b.service.UpdateStatus(requestContext(c), user.ID, incidentID, models.StatusRejected, reason)
 		if err != nil {
-			return c.Send("Не удалось обновить статус инцидента.")
+			return c.Send(b.t(c, "status.update_failed"))
 		}
-		sendOpts, _ := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), incidentID)
-		b.bot.Send(c.Chat(), "Инцидент отклонен. Спасибо за обратную связь!", sendOpts)
+		sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), incidentID)
+		b.bot.Send(c.Chat(), b.t(c, "status.rejected"), sendOpts)
 		return c.Delete()
 	}
 
+	if state.AwaitingCommentFor != 0 {
+		incidentID := state.AwaitingCommentFor
+		state.AwaitingCommentFor = 0
+		b.mu.Unlock()
+
+		return b.addComment(c, incidentID, c.Text())
+	}
+
 	if state.AwaitingReplicaCountFor != nil {
 		inputState := state.AwaitingReplicaCountFor
+		replicaCount, err := parseReplicaCount(c.Text(), b.maxReplicas)
+		if err != nil {
+			// Keep waiting so the user can simply send another number.
+			b.mu.Unlock()
+			return c.Send(b.t(c, "replicas.invalid", b.replicaCountMessage(b.lang(c), err, c.Text()), b.maxReplicas))
+		}
 		state.AwaitingReplicaCountFor = nil
 		b.mu.Unlock()
 
-		replicaCount, err := strconv.Atoi(c.Text())
-		if err != nil || replicaCount < 0 {
-			return c.Send("Неверное количество реплик. Пожалуйста, введите целое положительное число.")
+		req := inputState.Request
+		req.Parameters["replicas"] = strconv.Itoa(replicaCount)
+
+		largeScaleUp := isLargeScaleUp(inputState.CurrentReplicas, replicaCount)
+		if b.requiresConfirmation(*req) || largeScaleUp {
+			c.Delete()
+			text, markup, err := b.confirmationPrompt(c, *req)
+			if err != nil {
+				return c.Send(b.t(c, "common.error", err))
+			}
+			if largeScaleUp {
+				text = b.t(c, "replicas.large_scale_up", inputState.CurrentReplicas, replicaCount, scaleUpWarnFactor) + text
+			}
+			editable := &telebot.StoredMessage{MessageID: strconv.Itoa(inputState.MessageID), ChatID: inputState.ChatID}
+			_, err = b.bot.Edit(editable, text, markup)
+			return err
 		}
 
-		req := inputState.Request
-		req.Parameters["replicas"] = c.Text()
-		result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), *req)
-		sendOpts, _ := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), req.IncidentID)
+		result, err := b.executeAction(c, *req)
+		sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), req.IncidentID)
 		if err != nil {
-			b.bot.Send(c.Chat(), fmt.Sprintf("Ошибка: %v", err), sendOpts)
+			b.bot.Send(c.Chat(), b.t(c, "common.error", err), sendOpts)
 		} else {
 			b.bot.Send(c.Chat(), result.Message, sendOpts)
 		}
@@ -504,11 +1342,22 @@ func (b *Bot) handleTextMessage(c telebot.Context) error {
 		b.mu.Unlock()
 
 		req := inputState.Request
-		req.Parameters["resources"] = c.Text()
-		result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), *req)
-		sendOpts, _ := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), req.IncidentID)
+		cpu, memory, err := parseHardwareRequest(c.Text())
 		if err != nil {
-			b.bot.Send(c.Chat(), fmt.Sprintf("Ошибка: %v", err), sendOpts)
+			b.mu.Lock()
+			state.AwaitingHardwareRequestFor = inputState
+			b.mu.Unlock()
+			c.Delete()
+			sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), req.IncidentID)
+			_, err = b.bot.Send(c.Chat(), b.t(c, "hardware.invalid_format", err), sendOpts)
+			return err
+		}
+		req.Parameters["cpu"] = cpu
+		req.Parameters["memory"] = memory
+		result, err := b.executeAction(c, *req)
+		sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), req.IncidentID)
+		if err != nil {
+			b.bot.Send(c.Chat(), b.t(c, "common.error", err), sendOpts)
 		} else {
 			b.bot.Send(c.Chat(), result.Message, sendOpts)
 		}
@@ -522,9 +1371,9 @@ func (b *Bot) handleTextMessage(c telebot.Context) error {
 }
 
 func (b *Bot) showIncidentView(c telebot.Context, incidentID uint, historyVisible bool) error {
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), incidentID)
+	incident, err := b.service.GetIncidentByID(requestContext(c), incidentID)
 	if err != nil {
-		return c.EditOrSend("Не удалось найти инцидент.")
+		return c.EditOrSend(b.t(c, "common.incident_not_found"))
 	}
 
 	if incident.Status != models.StatusActive {
@@ -533,39 +1382,49 @@ func (b *Bot) showIncidentView(c telebot.Context, incidentID uint, historyVisibl
 
 	message := b.formatIncidentMessage(incident, historyVisible)
 	keyboard := b.buildIncidentViewKeyboard(incident, historyVisible)
-	err = c.Edit(message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	err = b.editMarkdown(c, message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 	if err == nil {
-		b.addIncidentView(incident.ID, c.Message())
+		b.addIncidentView(incident.ID, c.Message(), models.TelegramMessageView)
+	}
+	if isMessageGoneError(err) && c.Message() != nil {
+		b.forgetIncidentView(incident.ID, c.Message())
 	}
-	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+	if isBenignEditError(err) || isMessageGoneError(err) {
 		return c.Respond()
 	}
 	return err
 }
 
 func (b *Bot) showActionsView(c telebot.Context, incidentID uint, historyVisible bool) error {
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), incidentID)
+	incident, err := b.service.GetIncidentByID(requestContext(c), incidentID)
 	if err != nil {
-		return c.EditOrSend("Не удалось найти инцидент.")
+		return c.EditOrSend(b.t(c, "common.incident_not_found"))
 	}
 	message := b.formatIncidentMessage(incident, historyVisible)
+	executorDown := b.executorDown(c)
+	if executorDown {
+		message = b.executorDownBanner(c) + message
+	}
 	suggestedActions := b.suggester.SuggestActions(incident)
-	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, historyVisible)
-	err = c.Edit(message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	keyboard := b.buildActionsViewKeyboard(incident, suggestedActions, historyVisible, executorDown)
+	err = b.editMarkdown(c, message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 	if err == nil {
-		b.addIncidentView(incident.ID, c.Message())
+		b.addIncidentView(incident.ID, c.Message(), models.TelegramMessageView)
 	}
-	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+	if isMessageGoneError(err) && c.Message() != nil {
+		b.forgetIncidentView(incident.ID, c.Message())
+	}
+	if isBenignEditError(err) || isMessageGoneError(err) {
 		return c.Respond()
 	}
 	return err
 }
 
 func (b *Bot) renderResourceActionsView(c telebot.Context, incidentID uint, resourceType, resourceName string, chatID *int64, messageID *int) error {
-	ctx := c.Get("ctx").(context.Context)
+	ctx := requestContext(c)
 	incident, err := b.service.GetIncidentByID(ctx, incidentID)
 	if err != nil {
-		return c.EditOrSend("Не удалось найти инцидент.")
+		return c.EditOrSend(b.t(c, "common.incident_not_found"))
 	}
 
 	detailsReq := models.ResourceDetailsRequest{
@@ -577,111 +1436,175 @@ func (b *Bot) renderResourceActionsView(c telebot.Context, incidentID uint, reso
 	details, err := b.service.GetResourceDetails(ctx, detailsReq)
 
 	var messageBuilder strings.Builder
-	messageBuilder.WriteString(fmt.Sprintf("*Ресурс: %s `%s`*\n\n", strings.Title(resourceType), escapeMarkdown(resourceName)))
+	executorDown := b.executorDown(c)
+	if executorDown {
+		messageBuilder.WriteString(b.executorDownBanner(c))
+	}
+	messageBuilder.WriteString(b.t(c, "resource.title", strings.Title(resourceType), escapeMarkdown(resourceName)))
 
 	if err != nil {
-		log.Printf("Could not get resource details: %v", err)
-		messageBuilder.WriteString("_Не удалось загрузить детали ресурса\\._\n\n")
+		b.logger.ErrorContext(ctx, "Failed to get resource details", "incident_id", incidentID, "resource_type", resourceType, "resource", resourceName, "error", err)
+		messageBuilder.WriteString(b.t(c, "resource.details_failed"))
 	} else {
-		if resourceType == "deployment" {
-			messageBuilder.WriteString(fmt.Sprintf("∙ *Реплики:* `%s`\n", escapeMarkdown(details.ReplicasInfo)))
-		} else {
-			messageBuilder.WriteString(fmt.Sprintf("∙ *Статус:* `%s`\n", escapeMarkdown(details.Status)))
-			if details.ReplicasInfo != "" {
-				messageBuilder.WriteString(fmt.Sprintf("∙ *Реплики:* `%s`\n", escapeMarkdown(details.ReplicasInfo)))
-			}
-			if details.Restarts > 0 {
-				messageBuilder.WriteString(fmt.Sprintf("∙ *Перезапуски:* `%d`\n", details.Restarts))
-			}
-			messageBuilder.WriteString(fmt.Sprintf("∙ *Возраст:* `%s`\n", escapeMarkdown(details.Age)))
-		}
-
-		if len(details.Resources) > 0 {
-			messageBuilder.WriteString("*Потребление ресурсов:*\n")
-			for _, res := range details.Resources {
-				cpuUsage := float64(res.CpuUsage) / 1000
-				memoryUsage := float64(res.MemoryUsage) / 1024 / 1024
-				messageBuilder.WriteString(fmt.Sprintf(
-					"  ∙ *Контейнер:* `%s`\n    ∙ *CPU:* `%.2f` cores\n    ∙ *Memory:* `%.2f` MiB\n",
-					escapeMarkdown(res.Name),
-					cpuUsage,
-					memoryUsage,
-				))
-			}
-		}
-
-		messageBuilder.WriteString("\n")
+		b.writeResourceDetails(&messageBuilder, b.lang(c), resourceType, details)
 	}
 
-	messageBuilder.WriteString("Выберите действие:")
+	messageBuilder.WriteString(b.t(c, "resource.choose_action"))
 
 	actions := b.suggester.SuggestActionsForResource(incident, resourceType, resourceName)
-	keyboard := b.buildResourceActionsKeyboard(incident, resourceType, resourceName, actions)
+	keyboard := b.buildResourceActionsKeyboard(b.lang(c), incident, resourceType, resourceName, actions, details, executorDown)
 
 	messageText := messageBuilder.String()
 	replyMarkup := &telebot.ReplyMarkup{InlineKeyboard: keyboard}
 
 	if messageID != nil && chatID != nil {
 		editable := &telebot.StoredMessage{MessageID: strconv.Itoa(*messageID), ChatID: *chatID}
-		_, err = b.bot.Edit(editable, messageText, replyMarkup, telebot.ModeMarkdownV2)
+		_, err = b.safeEdit(editable, messageText, replyMarkup, telebot.ModeMarkdownV2)
 	} else {
-		err = c.Edit(messageText, replyMarkup, telebot.ModeMarkdownV2)
+		err = b.editMarkdown(c, messageText, replyMarkup, telebot.ModeMarkdownV2)
 	}
 
-	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+	if isBenignEditError(err) || isMessageGoneError(err) {
 		return c.Respond()
 	}
 	return err
 }
 
+// writeResourceDetails renders the details block of the resource view.
+func (b *Bot) writeResourceDetails(messageBuilder *strings.Builder, lang, resourceType string, details *models.ResourceDetails) {
+	t := func(key string, args ...interface{}) string { return b.tr.T(lang, key, args...) }
+	switch resourceType {
+	case "deployment":
+		statusIcon := "🟢"
+		if details.Status == models.DeploymentDegraded {
+			statusIcon = "🔴"
+		}
+		messageBuilder.WriteString(t("resource.status_icon", statusIcon, escapeMarkdown(details.Status)))
+		messageBuilder.WriteString(t("resource.replicas", escapeMarkdown(details.ReplicasInfo)))
+		messageBuilder.WriteString(t("resource.available_updated", details.AvailableReplicas, details.UpdatedReplicas))
+		if details.Paused {
+			messageBuilder.WriteString(t("resource.rollout_paused"))
+		}
+	case "node":
+		statusIcon := "🟢"
+		switch {
+		case !strings.HasPrefix(details.Status, "Ready"):
+			statusIcon = "🔴"
+		case strings.Contains(details.Status, "SchedulingDisabled"):
+			statusIcon = "🟡"
+		}
+		messageBuilder.WriteString(t("resource.status_icon", statusIcon, escapeMarkdown(details.Status)))
+		messageBuilder.WriteString(t("resource.age", escapeMarkdown(details.Age)))
+		if node := details.Node; node != nil {
+			cpu := formatUsageVsLimit(float64(node.CpuUsage)/1000, float64(node.CpuAllocatable)/1000, "%.2f", "cores", t("resource.unlimited"))
+			memory := formatUsageVsLimit(float64(node.MemoryUsage)/1024/1024/1024, float64(node.MemoryAllocatable)/1024/1024/1024, "%.1f", "GiB", t("resource.unlimited"))
+			messageBuilder.WriteString(t("resource.usage_allocatable"))
+			messageBuilder.WriteString(fmt.Sprintf("  ∙ *CPU:* `%s`\n", escapeMarkdownCodeBlock(cpu)))
+			messageBuilder.WriteString(fmt.Sprintf("  ∙ *Memory:* `%s`\n", escapeMarkdownCodeBlock(memory)))
+			if node.PodCapacity > 0 {
+				messageBuilder.WriteString(t("resource.pods_capacity", node.Pods, node.PodCapacity))
+			} else {
+				messageBuilder.WriteString(t("resource.pods", node.Pods))
+			}
+		}
+	default:
+		messageBuilder.WriteString(t("resource.status", escapeMarkdown(details.Status)))
+		if details.ReplicasInfo != "" {
+			messageBuilder.WriteString(t("resource.replicas", escapeMarkdown(details.ReplicasInfo)))
+		}
+		if details.Restarts > 0 {
+			messageBuilder.WriteString(t("resource.restarts", details.Restarts))
+		}
+		messageBuilder.WriteString(t("resource.age", escapeMarkdown(details.Age)))
+	}
+
+	if len(details.Resources) > 0 {
+		messageBuilder.WriteString(t("resource.usage"))
+		for _, res := range details.Resources {
+			cpu := formatUsageVsLimit(float64(res.CpuUsage)/1000, float64(res.CpuLimits)/1000, "%.2f", "cores", t("resource.unlimited"))
+			memory := formatUsageVsLimit(float64(res.MemoryUsage)/1024/1024, float64(res.MemoryLimits)/1024/1024, "%.0f", "MiB", t("resource.unlimited"))
+			messageBuilder.WriteString(t(
+				"resource.container_usage",
+				escapeMarkdown(res.Name),
+				escapeMarkdownCodeBlock(cpu),
+				escapeMarkdownCodeBlock(memory),
+			))
+		}
+	}
+
+	messageBuilder.WriteString("\n")
+}
+
 func (b *Bot) showResourceActionsView(c telebot.Context) error {
 	parts := strings.Split(c.Data(), ":")
 	if len(parts) < 4 {
-		log.Printf("Invalid callback data for showResourceActionsView: %s", c.Data())
-		return c.Respond(&telebot.CallbackResponse{Text: "Invalid callback data"})
+		b.logger.Warn("Invalid callback data for resource view", "data", c.Data())
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
 	}
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	resourceType := parts[2]
 	resourceName := parts[3]
+	switch resourceType {
+	case "pod", "deployment", "node":
+	default:
+		b.logger.Warn("Unknown resource type for resource view", "data", c.Data())
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
 
 	return b.renderResourceActionsView(c, uint(incidentID), resourceType, resourceName, nil, nil)
 }
 
 func (b *Bot) showCloseOptions(c telebot.Context, incidentID uint) error {
-	keyboard := b.buildCloseOptionsKeyboard(incidentID)
-	return c.Edit("Выберите статус для закрытия инцидента:", &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+	keyboard := b.buildCloseOptionsKeyboard(b.lang(c), incidentID)
+	return c.Edit(b.t(c, "status.close_prompt"), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
 }
 
 func (b *Bot) handleSetStatus(c telebot.Context) error {
 	parts := strings.Split(c.Data(), ":")
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	status := models.IncidentStatus(parts[2])
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 
 	if status == models.StatusRejected {
 		b.mu.Lock()
 		b.userStates[c.Sender().ID] = &userState{AwaitingRejectReasonFor: uint(incidentID)}
 		b.mu.Unlock()
-		return c.Edit("Пожалуйста, введите причину отклонения инцидента одним сообщением.")
+		return c.Edit(b.t(c, "status.reject_reason_prompt"))
 	}
 
-	err := b.service.UpdateStatus(c.Get("ctx").(context.Context), user.ID, uint(incidentID), status, "")
+	err := b.service.UpdateStatus(requestContext(c), user.ID, uint(incidentID), status, "")
 	if err != nil {
-		return c.Send("Не удалось обновить статус инцидента.")
+		return c.Send(b.t(c, "status.update_failed"))
 	}
-	sendOpts, _ := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), uint(incidentID))
-	b.bot.Send(c.Chat(), fmt.Sprintf("Статус инцидента обновлен на '%s'.", status), sendOpts)
+	sendOpts, _ := b.getSendOptionsForIncident(requestContext(c), uint(incidentID))
+	b.bot.Send(c.Chat(), b.t(c, "status.updated", status), sendOpts)
 
 	// Если инцидент закрыт, удаляем его из отслеживаемых
 	if status == models.StatusResolved || status == models.StatusRejected {
 		b.removeIncidentView(uint(incidentID))
-		incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+		incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 		if err == nil {
 			return b.showClosedIncidentView(c, incident, false)
 		}
 	}
 
-	return c.Delete()
+	return c.Delete()
+}
+
+func (b *Bot) handleReopenIncident(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+
+	incident, err := b.service.Reopen(requestContext(c), requestUser(c).ID, uint(incidentID))
+	if errors.Is(err, service.ErrIncidentActive) {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "reopen.already_active")})
+	}
+	if err != nil {
+		b.logger.ErrorContext(requestContext(c), "Failed to reopen incident", "incident_id", incidentID, "error", err)
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "reopen.failed")})
+	}
+	c.Respond(&telebot.CallbackResponse{Text: b.t(c, "reopen.done", incident.ID)})
+	return b.showIncidentView(c, incident.ID, false)
 }
 
 func (b *Bot) handlePerformAction(c telebot.Context) error {
@@ -689,21 +1612,24 @@ func (b *Bot) handlePerformAction(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	actionIndex, err := strconv.Atoi(parts[2])
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Invalid action index"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_action")})
 	}
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
 	actions := b.suggester.SuggestActions(incident)
 	if actionIndex < 0 || actionIndex >= len(actions) {
-		return c.Respond(&telebot.CallbackResponse{Text: "Action no longer valid"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.action_outdated")})
 	}
 	action := actions[actionIndex]
+	if !b.isAuthorized(c, action.Action) {
+		return b.denyUnauthorized(c, uint(incidentID), action.Action, action.Parameters)
+	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	req := models.ActionRequest{
 		Action:     action.Action,
 		IncidentID: uint(incidentID),
@@ -711,9 +1637,20 @@ func (b *Bot) handlePerformAction(c telebot.Context) error {
 		Parameters: action.Parameters,
 	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	switch models.ActionType(action.Action) {
+	case models.ActionScaleDeployment:
+		return b.promptReplicaCount(c, &req, 0)
+	case models.ActionAllocateHardware:
+		return b.promptHardwareRequest(c, &req)
+	}
+
+	if b.requiresConfirmation(req) {
+		return b.promptConfirmation(c, req)
+	}
+
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
@@ -726,21 +1663,24 @@ func (b *Bot) handlePerformResourceAction(c telebot.Context) error {
 	resourceName := parts[3]
 	actionIndex, err := strconv.Atoi(parts[4])
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Invalid action index"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_action")})
 	}
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
 	actions := b.suggester.SuggestActionsForResource(incident, resourceType, resourceName)
 	if actionIndex < 0 || actionIndex >= len(actions) {
-		return c.Respond(&telebot.CallbackResponse{Text: "Action no longer valid"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.action_outdated")})
 	}
 	action := actions[actionIndex]
+	if !b.isAuthorized(c, action.Action) {
+		return b.denyUnauthorized(c, uint(incidentID), action.Action, action.Parameters)
+	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	req := models.ActionRequest{
 		Action:     action.Action,
 		IncidentID: uint(incidentID),
@@ -748,17 +1688,68 @@ func (b *Bot) handlePerformResourceAction(c telebot.Context) error {
 		Parameters: action.Parameters,
 	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	if b.requiresConfirmation(req) {
+		return b.promptConfirmation(c, req)
+	}
+
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
 }
 
+func hasKubectlCommand(actions []models.SuggestedAction) bool {
+	for _, action := range actions {
+		if models.KubectlCommand(action.Action, action.Parameters) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// showKubectlCommands lists the kubectl equivalents of the suggested actions of an
+// incident, or of one of its resources, so they can be copied and run by hand.
+func (b *Bot) showKubectlCommands(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
+	}
+
+	var actions []models.SuggestedAction
+	backCallbackData := b.callbackData(showActionsPrefix + strconv.FormatUint(incidentID, 10))
+	if len(parts) >= 4 {
+		actions = b.suggester.SuggestActionsForResource(incident, parts[2], parts[3])
+		backCallbackData = b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, parts[2], parts[3]))
+	} else {
+		actions = b.suggester.SuggestActions(incident)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(b.t(c, "kubectl.header"))
+	for _, action := range actions {
+		command := models.KubectlCommand(action.Action, action.Parameters)
+		if command == "" {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\n%s\n```\n%s\n```", escapeMarkdown(action.HumanReadable), escapeMarkdownCodeBlock(command)))
+	}
+
+	keyboard := [][]telebot.InlineButton{{{Text: b.t(c, "common.back"), Data: backCallbackData}}}
+	err = b.editMarkdown(c, builder.String(), &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	if isBenignEditError(err) {
+		return c.Respond()
+	}
+	return err
+}
+
 func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.ActionRequest, result models.ActionResult) error {
 	actionType := models.ActionType(req.Action)
-	if actionType == models.ActionGetPodLogs || actionType == models.ActionDescribePod || actionType == models.ActionListPodsForDeployment {
+	if actionType == models.ActionGetPodLogs || actionType == models.ActionDescribePod || actionType == models.ActionDescribeNode || actionType == models.ActionListPodsForDeployment || actionType == models.ActionExecInPod {
 		c.Respond()
 	} else {
 		alertText := result.Message
@@ -776,28 +1767,24 @@ func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.
 	case models.ActionGetPodLogs:
 		if len(result.ResultData.Items) > 0 {
 			logs := result.ResultData.Items[0].Status
-			if len(logs) > 4096 {
-				doc := &telebot.Document{File: telebot.FromReader(strings.NewReader(logs)), FileName: "logs.txt"}
-				b.bot.Send(c.Chat(), doc)
+			markup := b.logTailKeyboard(incidentID, req)
+			if req.Parameters["previous"] == "true" {
+				b.sendCodeOutput(c, incidentID, logs, b.t(c, "resource.previous_logs"), "logs-previous.txt", markup)
 			} else {
-				formattedMessage := fmt.Sprintf("```\n%s\n```", logs)
-				sendOpts, err := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), incidentID)
-				if err != nil {
-					log.Printf("Could not get send options for incident %d: %v", incidentID, err)
-					b.bot.Send(c.Chat(), formattedMessage, telebot.ModeMarkdown)
-					return nil
-				}
-				sendOpts.ParseMode = telebot.ModeMarkdown
-				b.bot.Send(c.Chat(), formattedMessage, sendOpts)
+				b.sendCodeOutput(c, incidentID, logs, "", "logs.txt", markup)
 			}
 		}
-	case models.ActionDescribePod, models.ActionDescribeDeployment:
-		if len(result.ResultData.Items) > 0 {
+	case models.ActionExecInPod:
+		if result.ResultData != nil {
+			b.sendCodeOutput(c, incidentID, formatExecOutput(result), "$ "+req.Parameters["command"], "exec.txt", nil)
+		}
+	case models.ActionDescribePod, models.ActionDescribeDeployment, models.ActionDescribeNode:
+		if result.ResultData != nil && len(result.ResultData.Items) > 0 {
 			description := result.ResultData.Items[0].Status
 			doc := &telebot.Document{File: telebot.FromReader(strings.NewReader(description)), FileName: "description.yaml"}
-			sendOpts, err := b.getSendOptionsForIncident(c.Get("ctx").(context.Context), incidentID)
+			sendOpts, err := b.getSendOptionsForIncident(requestContext(c), incidentID)
 			if err != nil {
-				log.Printf("Could not get send options for incident %d: %v", incidentID, err)
+				b.logger.Warn("Could not get send options", "incident_id", incidentID, "error", err)
 				b.bot.Send(c.Chat(), doc)
 				return nil
 			}
@@ -808,9 +1795,9 @@ func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.
 		b.ignoreNextUpdateFor[incidentID] = true
 		b.ignoreMu.Unlock()
 
-		incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), incidentID)
+		incident, err := b.service.GetIncidentByID(requestContext(c), incidentID)
 		if err != nil {
-			return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 		}
 		listPodsReq := models.ActionRequest{
 			Action:     string(models.ActionListPodsForDeployment),
@@ -821,12 +1808,12 @@ func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.
 				"namespace":  incident.AffectedResources["namespace"],
 			},
 		}
-		listPodsResult, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), listPodsReq)
+		listPodsResult, err := b.executeAction(c, listPodsReq)
 		if err != nil {
 			b.ignoreMu.Lock()
 			delete(b.ignoreNextUpdateFor, incidentID)
 			b.ignoreMu.Unlock()
-			return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 		}
 		return b.showDynamicResourceList(c, incidentID, listPodsResult)
 	case models.ActionListPodsForDeployment:
@@ -842,12 +1829,15 @@ func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.
 		callbackData = c.Callback().Data
 	}
 
+	// Re-render the deployment view so the pause/resume button reflects the new state.
+	if strings.HasPrefix(callbackData, pauseRolloutPrefix) || strings.HasPrefix(callbackData, resumeRolloutPrefix) {
+		return b.renderResourceActionsView(c, incidentID, "deployment", req.Parameters["deployment"], nil, nil)
+	}
 	if strings.HasPrefix(callbackData, performResourceActionPrefix) {
-		incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), incidentID)
-		if err != nil {
-			return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		parts := strings.Split(callbackData, ":")
+		if len(parts) >= 4 {
+			return b.renderResourceActionsView(c, incidentID, parts[2], parts[3], nil, nil)
 		}
-		return b.renderResourceActionsView(c, incidentID, "deployment", incident.AffectedResources["deployment"], nil, nil)
 	}
 
 	return b.showActionsView(c, incidentID, false)
@@ -855,50 +1845,98 @@ func (b *Bot) handleActionResult(c telebot.Context, incidentID uint, req models.
 
 func (b *Bot) showPodInfo(c telebot.Context, incidentID uint, result models.ActionResult) error {
 	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("*Pod Information: %s*\n\n", escapeMarkdown(result.ResultData.Items[0].Name)))
-	builder.WriteString(fmt.Sprintf("∙ *Status:* `%s`\n", escapeMarkdown(result.ResultData.Items[0].Status)))
+	builder.WriteString(b.t(c, "resource.pod_info", escapeMarkdown(result.ResultData.Items[0].Name)))
+	builder.WriteString(b.t(c, "resource.status", escapeMarkdown(result.ResultData.Items[0].Status)))
 
 	keyboard := [][]telebot.InlineButton{
 		{
-			{Text: "⬅️ Назад", Data: showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10)},
-			{Text: "🏠 К инциденту", Data: viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10)},
+			{Text: b.t(c, "common.back"), Data: b.callbackData(showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10))},
+			{Text: b.t(c, "common.to_incident"), Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10))},
 		},
 	}
 
-	return c.Edit(builder.String(), &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	return b.editMarkdown(c, builder.String(), &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 }
 
 func (b *Bot) showDynamicResourceList(c telebot.Context, incidentID uint, result models.ActionResult) error {
-	log.Printf("showDynamicResourceList called for incident %d", incidentID)
+	b.logger.Debug("Showing dynamic resource list", "incident_id", incidentID)
 	var keyboard [][]telebot.InlineButton
 	if len(result.ResultData.Items) == 0 {
-		result.Message = "No pods found for this deployment."
+		result.Message = b.t(c, "resource.no_pods")
 	}
 	for _, item := range result.ResultData.Items {
 		statusIcon := "🟢"
 		if item.Status != "Running" {
 			statusIcon = "🔴"
 		}
-		callbackData := fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, result.ResultData.ItemType, item.Name)
+		callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, result.ResultData.ItemType, item.Name))
 		btn := telebot.InlineButton{Text: fmt.Sprintf("%s %s (%s)", statusIcon, item.Name, item.Status), Data: callbackData}
 		keyboard = append(keyboard, []telebot.InlineButton{btn})
 	}
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), incidentID)
+	incident, err := b.service.GetIncidentByID(requestContext(c), incidentID)
 	if err != nil {
-		return c.EditOrSend("Не удалось найти инцидент.")
+		return c.EditOrSend(b.t(c, "common.incident_not_found"))
 	}
 
 	keyboard = append(keyboard, []telebot.InlineButton{
-		{Text: "⬅️ Назад", Data: fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, "deployment", incident.AffectedResources["deployment"])},
-		{Text: "🏠 К инциденту", Data: viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10)},
+		{Text: b.t(c, "common.back"), Data: b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, "deployment", incident.AffectedResources["deployment"]))},
+		{Text: b.t(c, "common.to_incident"), Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10))},
 	})
 
 	if incident.Status == models.StatusActive {
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "✅ Закрыть инцидент", Data: closeIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10)}})
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.t(c, "common.close_incident"), Data: b.callbackData(closeIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10))}})
+	}
+
+	return b.editMarkdown(c, escapeMarkdown(result.Message), &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+}
+
+// logTailKeyboard offers to fetch the same logs again with a different number of lines.
+func (b *Bot) logTailKeyboard(incidentID uint, req models.ActionRequest) *telebot.ReplyMarkup {
+	mode := "cur"
+	if req.Parameters["previous"] == "true" {
+		mode = "prev"
+	}
+	var row []telebot.InlineButton
+	for _, tail := range logTailOptions {
+		text := strconv.Itoa(tail)
+		if strconv.Itoa(tail) == req.Parameters["tail"] {
+			text = "• " + text
+		}
+		data := fmt.Sprintf("%s%d:%s:%s:%s:%d", getPodLogsPrefix, incidentID, req.Parameters["pod_name"], req.Parameters["container"], mode, tail)
+		row = append(row, telebot.InlineButton{Text: text, Data: b.callbackData(data)})
 	}
+	return &telebot.ReplyMarkup{InlineKeyboard: [][]telebot.InlineButton{row}}
+}
+
+// codeBlockMessage renders output as a MarkdownV2 code block under an optional
+// title. It reports false when the escaped message exceeds Telegram's limit, in
+// which case the output should be sent as a document instead.
+func codeBlockMessage(output, title string) (string, bool) {
+	formattedMessage := fmt.Sprintf("```\n%s\n```", escapeMarkdownCodeBlock(output))
+	if title != "" {
+		formattedMessage = fmt.Sprintf("*%s:*\n", escapeMarkdown(title)) + formattedMessage
+	}
+	return formattedMessage, utf8.RuneCountInString(formattedMessage) <= maxMessageLength
+}
 
-	return c.Edit(escapeMarkdown(result.Message), &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+// sendCodeOutput posts multi-line output (logs, exec results) to the incident's
+// thread as a code block, or as a document when it does not fit into one message.
+func (b *Bot) sendCodeOutput(c telebot.Context, incidentID uint, output, title, fileName string, markup *telebot.ReplyMarkup) {
+	formattedMessage, fits := codeBlockMessage(output, title)
+	sendOpts, err := b.getSendOptionsForIncident(requestContext(c), incidentID)
+	if err != nil {
+		b.logger.Warn("Could not get send options", "incident_id", incidentID, "error", err)
+		sendOpts = &telebot.SendOptions{}
+	}
+	sendOpts.ReplyMarkup = markup
+	if !fits {
+		doc := &telebot.Document{File: telebot.FromReader(strings.NewReader(output)), FileName: fileName, Caption: title}
+		b.bot.Send(c.Chat(), doc, sendOpts)
+		return
+	}
+	sendOpts.ParseMode = telebot.ModeMarkdownV2
+	b.safeSend(c.Chat(), formattedMessage, sendOpts)
 }
 
 func (b *Bot) getSendOptionsForIncident(ctx context.Context, incidentID uint) (*telebot.SendOptions, error) {
@@ -919,20 +1957,30 @@ func (b *Bot) buildIncidentViewKeyboard(incident *models.Incident, historyVisibl
 	var keyboard [][]telebot.InlineButton
 
 	if incident.Status == models.StatusActive {
+		if incident.AcknowledgedBy == nil {
+			keyboard = append(keyboard, []telebot.InlineButton{
+				{Text: b.tr.T(b.channelLanguage, "incident.acknowledge"), Data: b.callbackData(acknowledgePrefix + strconv.FormatUint(uint64(incident.ID), 10))},
+			})
+		}
+		keyboard = append(keyboard, []telebot.InlineButton{
+			{Text: b.tr.T(b.channelLanguage, "common.close_incident"), Data: b.callbackData(closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10))},
+			{Text: b.tr.T(b.channelLanguage, "incident.actions"), Data: b.callbackData(showActionsPrefix + strconv.FormatUint(uint64(incident.ID), 10))},
+		})
+		keyboard = append(keyboard, []telebot.InlineButton{
+			{Text: b.tr.T(b.channelLanguage, "incident.snooze"), Data: b.callbackData(snoozePrefix + strconv.FormatUint(uint64(incident.ID), 10))},
+			{Text: b.tr.T(b.channelLanguage, "incident.comment"), Data: b.callbackData(commentPrefix + strconv.FormatUint(uint64(incident.ID), 10))},
+		})
 		keyboard = append(keyboard, []telebot.InlineButton{
-			{Text: "✅ Закрыть инцидент", Data: closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10)},
-			{Text: "▶️ Выполнить действия", Data: showActionsPrefix + strconv.FormatUint(uint64(incident.ID), 10)},
+			{Text: b.tr.T(b.channelLanguage, "incident.severity"), Data: b.callbackData(showSeverityOptionsPrefix + strconv.FormatUint(uint64(incident.ID), 10))},
 		})
 	}
 
+	if links := b.incidentLinkRow(incident); links != nil {
+		keyboard = append(keyboard, links)
+	}
+
 	if len(incident.AuditLog) > 0 {
-		historyButtonText := "📖 Показать историю"
-		if historyVisible {
-			historyButtonText = "📖 Скрыть историю"
-		}
-		keyboard = append(keyboard, []telebot.InlineButton{
-			{Text: historyButtonText, Data: fmt.Sprintf("%s%d:%t:main", toggleHistoryPrefix, incident.ID, !historyVisible)},
-		})
+		keyboard = append(keyboard, b.historyRow(incident, historyVisible, "main"))
 	}
 
 	return keyboard
@@ -942,120 +1990,164 @@ func (b *Bot) buildSummaryViewKeyboard(incident *models.Incident, historyVisible
 	var keyboard [][]telebot.InlineButton
 
 	if len(incident.AuditLog) > 0 {
-		historyButtonText := "📖 Показать историю"
-		if historyVisible {
-			historyButtonText = "📖 Скрыть историю"
-		}
-		keyboard = append(keyboard, []telebot.InlineButton{
-			{Text: historyButtonText, Data: fmt.Sprintf("%s%d:%t:summary", toggleHistoryPrefix, incident.ID, !historyVisible)},
-		})
+		keyboard = append(keyboard, b.historyRow(incident, historyVisible, "summary"))
+	}
+
+	if links := b.incidentLinkRow(incident); links != nil {
+		keyboard = append(keyboard, links)
 	}
 
 	if incident.TelegramTopicID.Valid {
-		channelIDForLink := strings.TrimPrefix(strconv.FormatInt(b.alertChannelID, 10), "-100")
-		topicURL := fmt.Sprintf("https://t.me/c/%s/%d", channelIDForLink, incident.TelegramTopicID.Int64)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "Перейти к обсуждению", URL: topicURL}})
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "notify.go_to_topic"), URL: topicURL(b.alertChatID(incident), incident.TelegramTopicID.Int64)}})
 	}
 
 	return keyboard
 }
 
-func (b *Bot) buildActionsViewKeyboard(incident *models.Incident, actions []models.SuggestedAction, historyVisible bool) [][]telebot.InlineButton {
+func (b *Bot) buildActionsViewKeyboard(incident *models.Incident, actions []models.SuggestedAction, historyVisible, executorDown bool) [][]telebot.InlineButton {
 	var keyboard [][]telebot.InlineButton
 	var actionRow []telebot.InlineButton
 	for i, action := range actions {
-		callbackData := fmt.Sprintf("%s%d:%d", performActionPrefix, incident.ID, i)
+		// Skipped buttons keep the indexes of the rest, which refer to the full list.
+		if hiddenWhileExecutorDown(action, executorDown) {
+			continue
+		}
+		callbackData := b.callbackData(fmt.Sprintf("%s%d:%d", performActionPrefix, incident.ID, i))
 		actionRow = append(actionRow, telebot.InlineButton{Text: action.HumanReadable, Data: callbackData})
 	}
 	if len(actionRow) > 0 {
 		keyboard = append(keyboard, actionRow)
 	}
+	if hasKubectlCommand(actions) {
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "kubectl.show"), Data: b.callbackData(showKubectlPrefix + strconv.FormatUint(uint64(incident.ID), 10))}})
+	}
 
 	if len(incident.AffectedResources) > 0 {
 		if deployment, ok := incident.AffectedResources["deployment"]; ok {
-			callbackData := fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incident.ID, "deployment", deployment)
-			keyboard = append(keyboard, []telebot.InlineButton{{Text: "🗂️ Действия с Deployment", Data: callbackData}})
+			callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incident.ID, "deployment", deployment))
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "resource.deployment_actions"), Data: callbackData}})
 		}
+		if node, ok := incident.AffectedResources["node"]; ok {
+			callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incident.ID, "node", node))
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "resource.node_actions"), Data: callbackData}})
+		}
+	}
+
+	if links := b.incidentLinkRow(incident); links != nil {
+		keyboard = append(keyboard, links)
 	}
 
-	keyboard = append(keyboard, []telebot.InlineButton{{Text: "⬅️ Назад", Data: viewIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10)}})
+	keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "common.back"), Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10))}})
 
 	if incident.Status == models.StatusActive {
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "✅ Закрыть инцидент", Data: closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10)}})
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "common.close_incident"), Data: b.callbackData(closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10))}})
 	}
 
 	if len(incident.AuditLog) > 0 {
-		historyButtonText := "📖 Показать историю"
-		if historyVisible {
-			historyButtonText = "📖 Скрыть историю"
-		}
-		keyboard = append(keyboard, []telebot.InlineButton{
-			{Text: historyButtonText, Data: fmt.Sprintf("%s%d:%t:actions", toggleHistoryPrefix, incident.ID, !historyVisible)},
-		})
+		keyboard = append(keyboard, b.historyRow(incident, historyVisible, "actions"))
 	}
 
 	return keyboard
 }
 
-func (b *Bot) buildResourceActionsKeyboard(incident *models.Incident, resourceType, resourceName string, actions []models.SuggestedAction) [][]telebot.InlineButton {
+// buildResourceActionsKeyboard builds the resource view buttons. details may be
+// nil if they could not be loaded.
+func (b *Bot) buildResourceActionsKeyboard(lang string, incident *models.Incident, resourceType, resourceName string, actions []models.SuggestedAction, details *models.ResourceDetails, executorDown bool) [][]telebot.InlineButton {
 	var keyboard [][]telebot.InlineButton
 	incidentID := incident.ID
+	// The desired count is read once with the details and carried in the restore
+	// button, so the deployment is scaled to exactly the number the user saw.
+	desiredReplicas := 0
+	rolloutPaused := false
+	if details != nil {
+		desiredReplicas = details.DesiredReplicas
+		rolloutPaused = details.Paused
+	}
 	for i, action := range actions {
-		callbackData := fmt.Sprintf("%s%d:%s:%s:%d", performResourceActionPrefix, incidentID, resourceType, resourceName, i)
+		if hiddenWhileExecutorDown(action, executorDown) {
+			continue
+		}
+		callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s:%d", performResourceActionPrefix, incidentID, resourceType, resourceName, i))
 		keyboard = append(keyboard, []telebot.InlineButton{{Text: action.HumanReadable, Data: callbackData}})
 	}
+	if hasKubectlCommand(actions) {
+		kubectlCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", showKubectlPrefix, incidentID, resourceType, resourceName))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "kubectl.show"), Data: kubectlCallbackData}})
+	}
 
 	if resourceType == "deployment" {
 		namespace := incident.Labels["namespace"]
-		callbackData := fmt.Sprintf("%s%d:%s:%s:%s", scaleDeploymentPrefix, incidentID, resourceType, resourceName, namespace)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "↔️ Масштабировать", Data: callbackData}})
-		describeCallbackData := fmt.Sprintf("%s%d:%s", describeDeploymentPrefix, incidentID, resourceName)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "📖 Описать", Data: describeCallbackData}})
-		rollbackCallbackData := fmt.Sprintf("%s%d:%s", rollbackDeploymentPrefix, incidentID, resourceName)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "⏪ Откатить", Data: rollbackCallbackData}})
+		if !executorDown {
+			callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s:%s:%d", scaleDeploymentPrefix, incidentID, resourceType, resourceName, namespace, desiredReplicas))
+			if desiredReplicas > 0 && incident.Labels["alertname"] == replicasMismatchAlert {
+				restoreCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s:%d", restoreReplicasPrefix, incidentID, resourceName, namespace, desiredReplicas))
+				keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.restore_replicas", desiredReplicas), Data: restoreCallbackData}})
+			}
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.scale"), Data: callbackData}})
+		}
+		describeCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", describeDeploymentPrefix, incidentID, resourceName))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.describe"), Data: describeCallbackData}})
+		if !executorDown {
+			rollbackCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", rollbackDeploymentPrefix, incidentID, resourceName))
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.rollback"), Data: rollbackCallbackData}})
+			if rolloutPaused {
+				resumeCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", resumeRolloutPrefix, incidentID, resourceName))
+				keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.resume_rollout"), Data: resumeCallbackData}})
+			} else {
+				pauseCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", pauseRolloutPrefix, incidentID, resourceName))
+				keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.pause_rollout"), Data: pauseCallbackData}})
+			}
+		}
 	}
 
 	if resourceType == "pod" {
-		callbackData := fmt.Sprintf("%s%d:%s:%s", allocateHardwarePrefix, incidentID, resourceType, resourceName)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "⚙️ Выделить ресурсы", Data: callbackData}})
-		containersCallbackData := fmt.Sprintf("%s%d:%s", listContainersForPodPrefix, incidentID, resourceName)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "Контейнеры", Data: containersCallbackData}})
-		describeCallbackData := fmt.Sprintf("%s%d:%s", describePodPrefix, incidentID, resourceName)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "📖 Описать", Data: describeCallbackData}})
+		if !executorDown {
+			callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", allocateHardwarePrefix, incidentID, resourceType, resourceName))
+			keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.allocate"), Data: callbackData}})
+		}
+		containersCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", listContainersForPodPrefix, incidentID, resourceName))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.containers"), Data: containersCallbackData}})
+		describeCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", describePodPrefix, incidentID, resourceName))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.describe"), Data: describeCallbackData}})
+	}
+
+	if resourceType == "node" {
+		describeCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", describeNodePrefix, incidentID, resourceName))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "resource.describe"), Data: describeCallbackData}})
 	}
 
 	var backCallbackData string
 	if resourceType == "pod" {
 		deploymentName, ok := incident.AffectedResources["deployment"]
 		if !ok {
-			backCallbackData = showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10)
+			backCallbackData = b.callbackData(showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10))
 		} else {
-			backCallbackData = fmt.Sprintf("%s%d:%s", listPodsForDeploymentPrefix, incidentID, deploymentName)
+			backCallbackData = b.callbackData(fmt.Sprintf("%s%d:%s", listPodsForDeploymentPrefix, incidentID, deploymentName))
 		}
 	} else {
-		backCallbackData = showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10)
+		backCallbackData = b.callbackData(showActionsPrefix + strconv.FormatUint(uint64(incidentID), 10))
 	}
 
 	keyboard = append(keyboard, []telebot.InlineButton{
-		{Text: "⬅️ Назад", Data: backCallbackData},
-		{Text: "🏠 К инциденту", Data: viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10)},
+		{Text: b.tr.T(lang, "common.back"), Data: backCallbackData},
+		{Text: b.tr.T(lang, "common.to_incident"), Data: b.callbackData(viewIncidentPrefix + strconv.FormatUint(uint64(incidentID), 10))},
 	})
 
 	if incident.Status == models.StatusActive {
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: "✅ Закрыть инцидент", Data: closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10)}})
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(lang, "common.close_incident"), Data: b.callbackData(closeIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10))}})
 	}
 
 	return keyboard
 }
 
-func (b *Bot) buildCloseOptionsKeyboard(incidentID uint) [][]telebot.InlineButton {
+func (b *Bot) buildCloseOptionsKeyboard(lang string, incidentID uint) [][]telebot.InlineButton {
 	idStr := strconv.FormatUint(uint64(incidentID), 10)
 	return [][]telebot.InlineButton{
 		{
-			{Text: "Решен", Data: setStatusPrefix + idStr + ":" + string(models.StatusResolved)},
-			{Text: "Отклонен", Data: setStatusPrefix + idStr + ":" + string(models.StatusRejected)},
+			{Text: b.tr.T(lang, "status.resolved_button"), Data: b.callbackData(setStatusPrefix + idStr + ":" + string(models.StatusResolved))},
+			{Text: b.tr.T(lang, "status.rejected_button"), Data: b.callbackData(setStatusPrefix + idStr + ":" + string(models.StatusRejected))},
 		},
-		{{Text: "⬅️ Назад", Data: viewIncidentPrefix + idStr}},
+		{{Text: b.tr.T(lang, "common.back"), Data: b.callbackData(viewIncidentPrefix + idStr)}},
 	}
 }
 
@@ -1067,10 +2159,12 @@ func (b *Bot) authMiddleware() telebot.MiddlewareFunc {
 			}
 			user, err := b.userRepo.FindOrCreateByTelegramID(context.Background(), c.Sender().ID, c.Sender().Username, c.Sender().FirstName, c.Sender().LastName)
 			if err != nil {
-				log.Printf("Auth middleware error: %v", err)
-				return c.Send("Произошла ошибка аутентификации.")
+				b.logger.Error("Failed to authenticate Telegram user", "telegram_id", c.Sender().ID, "error", err)
+				return c.Send(b.t(c, "common.auth_failed"))
 			}
-			ctx := context.WithValue(context.Background(), "user", user)
+			ctx := logging.WithRequestID(context.Background(), logging.NewRequestID())
+			b.syncAdminFlag(ctx, user)
+			ctx = context.WithValue(ctx, "user", user)
 			c.Set("ctx", ctx)
 			return next(c)
 		}
@@ -1082,12 +2176,12 @@ func (b *Bot) handleListPodsForDeployment(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	deploymentName := parts[2]
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	listPodsReq := models.ActionRequest{
 		Action:     string(models.ActionListPodsForDeployment),
 		IncidentID: uint(incidentID),
@@ -1097,9 +2191,9 @@ func (b *Bot) handleListPodsForDeployment(c telebot.Context) error {
 			"namespace":  incident.Labels["namespace"],
 		},
 	}
-	listPodsResult, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), listPodsReq)
+	listPodsResult, err := b.executeAction(c, listPodsReq)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 	return b.showDynamicResourceList(c, uint(incidentID), listPodsResult)
 }
@@ -1109,9 +2203,9 @@ func (b *Bot) handleListContainersForPod(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	podName := parts[2]
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
 	detailsReq := models.ResourceDetailsRequest{
@@ -1120,21 +2214,35 @@ func (b *Bot) handleListContainersForPod(c telebot.Context) error {
 		ResourceName: podName,
 		Labels:       incident.Labels,
 	}
-	details, err := b.service.GetResourceDetails(c.Get("ctx").(context.Context), detailsReq)
+	details, err := b.service.GetResourceDetails(requestContext(c), detailsReq)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Could not get pod details"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "resource.pod_details_failed")})
 	}
 
 	var keyboard [][]telebot.InlineButton
 	for _, container := range details.Resources {
-		callbackData := fmt.Sprintf("%s%d:%s:%s", getPodLogsPrefix, incidentID, podName, container.Name)
-		keyboard = append(keyboard, []telebot.InlineButton{{Text: fmt.Sprintf("📄 %s", container.Name), Data: callbackData}})
+		callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", getPodLogsPrefix, incidentID, podName, container.Name))
+		previousCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s:prev", getPodLogsPrefix, incidentID, podName, container.Name))
+		keyboard = append(keyboard, []telebot.InlineButton{
+			{Text: fmt.Sprintf("📄 %s", container.Name), Data: callbackData},
+			{Text: b.t(c, "resource.previous_container", container.Name), Data: previousCallbackData},
+		})
+		followCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", followPodLogsPrefix, incidentID, podName, container.Name))
+		keyboard = append(keyboard, []telebot.InlineButton{
+			{Text: b.t(c, "logfollow.button", container.Name), Data: followCallbackData},
+		})
+		if len(b.execCommands) > 0 {
+			execCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", showExecCommandsPrefix, incidentID, podName, container.Name))
+			keyboard = append(keyboard, []telebot.InlineButton{
+				{Text: fmt.Sprintf("🖥 %s — Exec", container.Name), Data: execCallbackData},
+			})
+		}
 	}
 
-	backCallbackData := fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, "pod", podName)
-	keyboard = append(keyboard, []telebot.InlineButton{{Text: "⬅️ Назад", Data: backCallbackData}})
+	backCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s", viewResourcePrefix, incidentID, "pod", podName))
+	keyboard = append(keyboard, []telebot.InlineButton{{Text: b.t(c, "common.back"), Data: backCallbackData}})
 
-	return c.Edit("Выберите контейнер для просмотра логов:", &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+	return c.Edit(b.t(c, "resource.choose_container"), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
 }
 
 func (b *Bot) handleGetPodLogs(c telebot.Context) error {
@@ -1142,13 +2250,22 @@ func (b *Bot) handleGetPodLogs(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	podName := parts[2]
 	containerName := parts[3]
+	previous := len(parts) > 4 && parts[4] == "prev"
+	tail := b.logTailLines
+	if len(parts) > 5 {
+		parsed, err := strconv.Atoi(parts[5])
+		if err != nil || parsed <= 0 {
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "resource.invalid_log_size")})
+		}
+		tail = parsed
+	}
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	req := models.ActionRequest{
 		Action:     string(models.ActionGetPodLogs),
 		IncidentID: uint(incidentID),
@@ -1157,13 +2274,16 @@ func (b *Bot) handleGetPodLogs(c telebot.Context) error {
 			"pod_name":  podName,
 			"namespace": incident.Labels["namespace"],
 			"container": containerName,
-			"tail":      "100",
+			"tail":      strconv.Itoa(tail),
 		},
 	}
+	if previous {
+		req.Parameters["previous"] = "true"
+	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
@@ -1174,12 +2294,12 @@ func (b *Bot) handleDescribePod(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	podName := parts[2]
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	req := models.ActionRequest{
 		Action:     string(models.ActionDescribePod),
 		IncidentID: uint(incidentID),
@@ -1190,9 +2310,9 @@ func (b *Bot) handleDescribePod(c telebot.Context) error {
 		},
 	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
@@ -1203,12 +2323,12 @@ func (b *Bot) handleDescribeDeployment(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	deploymentName := parts[2]
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 	req := models.ActionRequest{
 		Action:     string(models.ActionDescribeDeployment),
 		IncidentID: uint(incidentID),
@@ -1219,117 +2339,192 @@ func (b *Bot) handleDescribeDeployment(c telebot.Context) error {
 		},
 	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
 }
 
-func (b *Bot) handleRollbackDeployment(c telebot.Context) error {
+func (b *Bot) handleDescribeNode(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	nodeName := parts[2]
+
+	if _, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID)); err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
+	}
+
+	user := requestUser(c)
+	req := models.ActionRequest{
+		Action:     string(models.ActionDescribeNode),
+		IncidentID: uint(incidentID),
+		UserID:     user.ID,
+		Parameters: map[string]string{
+			"node": nodeName,
+		},
+	}
+
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+
+	return b.handleActionResult(c, uint(incidentID), req, result)
+}
+
+// handleRolloutOperation pauses or resumes the rollout of a deployment from its
+// resource view.
+func (b *Bot) handleRolloutOperation(c telebot.Context, action models.ActionType) error {
 	parts := strings.Split(c.Data(), ":")
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	deploymentName := parts[2]
 
-	incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: "Incident not found"})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
 	req := models.ActionRequest{
-		Action:     string(models.ActionRollbackDeployment),
+		Action:     string(action),
 		IncidentID: uint(incidentID),
-		UserID:     user.ID,
+		UserID:     requestUser(c).ID,
 		Parameters: map[string]string{
 			"deployment": deploymentName,
 			"namespace":  incident.Labels["namespace"],
 		},
 	}
 
-	result, err := b.service.ExecuteAction(c.Get("ctx").(context.Context), req)
+	if b.requiresConfirmation(req) {
+		return b.promptConfirmation(c, req)
+	}
+
+	result, err := b.executeAction(c, req)
 	if err != nil {
-		return c.Respond(&telebot.CallbackResponse{Text: fmt.Sprintf("Ошибка: %v", err)})
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
 
 	return b.handleActionResult(c, uint(incidentID), req, result)
 }
 
-func (b *Bot) formatIncidentMessage(incident *models.Incident, historyVisible bool) string {
-	var builder strings.Builder
-
-	alertName, _ := incident.Labels["alertname"]
-	builder.WriteString(fmt.Sprintf("🚨 *%s: %s* 🚨\n", escapeMarkdown(alertName), escapeMarkdown(incident.Summary)))
+func (b *Bot) handleRollbackDeployment(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	deploymentName := parts[2]
 
-	severity := "N/A"
-	if s, ok := incident.Labels["severity"]; ok {
-		severity = s
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
 	}
-	builder.WriteString(fmt.Sprintf("*Статус:* `%s` \\| *Серьезность:* `%s`\n", incident.Status, severity))
-	builder.WriteString("━━━━━━━━━━━━━━━\n")
 
-	builder.WriteString("*📋 Детали:*\n")
-	builder.WriteString(fmt.Sprintf("∙ *Описание:* %s\n", escapeMarkdown(incident.Description)))
-	if namespace, ok := incident.Labels["namespace"]; ok {
-		builder.WriteString(fmt.Sprintf("∙ *Namespace:* `%s`\n", escapeMarkdown(namespace)))
+	user := requestUser(c)
+	req := models.ActionRequest{
+		Action:     string(models.ActionRollbackDeployment),
+		IncidentID: uint(incidentID),
+		UserID:     user.ID,
+		Parameters: map[string]string{
+			"deployment": deploymentName,
+			"namespace":  incident.Labels["namespace"],
+		},
 	}
-	builder.WriteString(fmt.Sprintf("∙ *Начало:* `%s`\n", incident.StartsAt.Format(time.RFC1123)))
-	builder.WriteString("━━━━━━━━━━━━━━━\n")
 
-	builder.WriteString("*🛠 Ресурсы:*\n")
-	if deployment, ok := incident.AffectedResources["deployment"]; ok {
-		builder.WriteString(fmt.Sprintf("∙ *Deployment:* `%s`\n", escapeMarkdown(deployment)))
+	if b.requiresConfirmation(req) {
+		return b.promptConfirmation(c, req)
 	}
-	if pod, ok := incident.AffectedResources["pod"]; ok {
-		builder.WriteString(fmt.Sprintf("∙ *Pod:* `%s`\n", escapeMarkdown(pod)))
+
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
 	}
-	builder.WriteString("━━━━━━━━━━━━━━━\n")
 
-	builder.WriteString("*📖 История действий:*\n")
+	return b.handleActionResult(c, uint(incidentID), req, result)
+}
+
+func (b *Bot) formatIncidentMessage(incident *models.Incident, historyVisible bool) string {
+	var builder strings.Builder
+	builder.WriteString(b.renderIncidentTemplate(incident))
+
+	builder.WriteString(b.tr.T(b.channelLanguage, "history.header"))
 	if len(incident.AuditLog) > 0 {
 		if historyVisible {
-			for _, entry := range incident.AuditLog {
-				builder.WriteString(fmt.Sprintf(
-					"`%s` \\- *%s* by *%s* \\- *%s*\n",
-					entry.Timestamp.Format("15:04:05"),
-					escapeMarkdown(entry.Action),
-					escapeMarkdown(entry.User.Username),
-					escapeMarkdown(entry.Result),
-				))
-				if entry.Action == "update_status" {
-					if reason, ok := entry.Parameters["reason"]; ok && reason != "" {
-						builder.WriteString(fmt.Sprintf("  *Причина:* %s\n", escapeMarkdown(reason)))
-					}
-				}
-				if entry.Action == string(models.ActionScaleDeployment) {
-					if replicas, ok := entry.Parameters["replicas"]; ok {
-						builder.WriteString(fmt.Sprintf("  *Реплики:* `%s`\n", escapeMarkdown(replicas)))
-					}
-				}
-				if entry.Action == string(models.ActionAllocateHardware) {
-					if resources, ok := entry.Parameters["resources"]; ok {
-						builder.WriteString(fmt.Sprintf("  *Ресурсы:* `%s`\n", escapeMarkdown(resources)))
-					}
-				}
-			}
+			history, _ := b.incidentHistory(incident, utf8.RuneCountInString(builder.String()))
+			builder.WriteString(history)
 		} else {
-			builder.WriteString(fmt.Sprintf("_История действий скрыта \\(%d записей\\)\\. Нажмите кнопку ниже, чтобы показать\\._\n", len(incident.AuditLog)))
+			builder.WriteString(b.tr.T(b.channelLanguage, "history.hidden", len(incident.AuditLog)))
 		}
 	} else {
-		builder.WriteString("_Нет записей в истории\\._\n")
+		builder.WriteString(b.tr.T(b.channelLanguage, "history.no_entries"))
 	}
 
 	return builder.String()
 }
 
+// formatAuditEntry renders one history line (and its detail lines) in MarkdownV2.
+func (b *Bot) formatAuditEntry(entry models.AuditRecord) string {
+	t := func(key string, args ...interface{}) string { return b.tr.T(b.channelLanguage, key, args...) }
+	var builder strings.Builder
+	if entry.Action == commentAction {
+		builder.WriteString(fmt.Sprintf(
+			"`%s` 💬 *%s:* _%s_\n",
+			entry.Timestamp.Format("15:04:05"),
+			escapeMarkdown(entry.User.Username),
+			escapeMarkdown(entry.Result),
+		))
+		return builder.String()
+	}
+	builder.WriteString(fmt.Sprintf(
+		"`%s` \\- *%s* by *%s* \\- *%s*\n",
+		entry.Timestamp.Format("15:04:05"),
+		escapeMarkdown(entry.Action),
+		escapeMarkdown(entry.User.Username),
+		escapeMarkdown(entry.Result),
+	))
+	if entry.Action == "update_status" {
+		if reason, ok := entry.Parameters["reason"]; ok && reason != "" {
+			builder.WriteString(t("audit.reason", escapeMarkdown(reason)))
+		}
+	}
+	if entry.Action == string(models.ActionExecInPod) {
+		builder.WriteString(t("audit.command", escapeMarkdown(entry.Parameters["command"]), escapeMarkdown(entry.Parameters["pod_name"]), escapeMarkdown(entry.Parameters["container"])))
+	}
+	if entry.Action == string(models.ActionRestartDeployment) {
+		builder.WriteString(t("audit.operation", "rollout restart "+escapeMarkdown(entry.Parameters["deployment"])))
+	}
+	if entry.Action == string(models.ActionPauseRollout) {
+		builder.WriteString(t("audit.operation", "rollout pause "+escapeMarkdown(entry.Parameters["deployment"])))
+	}
+	if entry.Action == string(models.ActionResumeRollout) {
+		builder.WriteString(t("audit.operation", "rollout resume "+escapeMarkdown(entry.Parameters["deployment"])))
+	}
+	if entry.Action == string(models.ActionScaleDeployment) {
+		if replicas, ok := entry.Parameters["replicas"]; ok {
+			builder.WriteString(t("audit.replicas", escapeMarkdown(replicas)))
+		}
+	}
+	if entry.Action == string(models.ActionAllocateHardware) {
+		if profile, ok := entry.Parameters["profile"]; ok {
+			builder.WriteString(t("audit.profile", escapeMarkdown(profile)))
+		} else if cpu, ok := entry.Parameters["cpu"]; ok {
+			builder.WriteString(t("audit.resources", fmt.Sprintf("cpu\\=%s, memory\\=%s", escapeMarkdown(cpu), escapeMarkdown(entry.Parameters["memory"]))))
+		} else if resources, ok := entry.Parameters["resources"]; ok {
+			builder.WriteString(t("audit.resources", escapeMarkdown(resources)))
+		}
+	}
+	return builder.String()
+}
+
 func (b *Bot) handleScaleDeployment(c telebot.Context) error {
 	parts := strings.Split(c.Data(), ":")
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	resourceName := parts[3]
 	namespace := parts[4]
+	currentReplicas := 0
+	if len(parts) > 5 {
+		currentReplicas, _ = strconv.Atoi(parts[5])
+	}
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 
 	req := &models.ActionRequest{
 		Action:     string(models.ActionScaleDeployment),
@@ -1341,7 +2536,54 @@ func (b *Bot) handleScaleDeployment(c telebot.Context) error {
 		},
 	}
 
-	err := c.Edit("Введите желаемое количество реплик:")
+	return b.promptReplicaCount(c, req, currentReplicas)
+}
+
+// replicasMismatchAlert is the alert for which a one-tap restore to the desired
+// replica count is offered.
+const replicasMismatchAlert = "KubeDeploymentReplicasMismatch"
+
+// handleRestoreReplicas scales a deployment back to the desired replica count shown
+// in the button, without asking for a number.
+func (b *Bot) handleRestoreReplicas(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) < 5 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	replicas, err := strconv.Atoi(parts[4])
+	if err != nil || replicas <= 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "replicas.invalid_count")})
+	}
+
+	req := models.ActionRequest{
+		Action:     string(models.ActionScaleDeployment),
+		IncidentID: uint(incidentID),
+		UserID:     requestUser(c).ID,
+		Parameters: map[string]string{
+			"deployment":   parts[2],
+			"namespace":    parts[3],
+			"replicas":     strconv.Itoa(replicas),
+			"auto_restore": "true",
+		},
+	}
+	if b.requiresConfirmation(req) {
+		return b.promptConfirmation(c, req)
+	}
+
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+	return b.handleActionResult(c, uint(incidentID), req, result)
+}
+
+func (b *Bot) promptReplicaCount(c telebot.Context, req *models.ActionRequest, currentReplicas int) error {
+	prompt := b.t(c, "replicas.prompt", b.maxReplicas)
+	if currentReplicas > 0 {
+		prompt = b.t(c, "replicas.prompt_current", currentReplicas, b.maxReplicas)
+	}
+	err := c.Edit(prompt)
 	if err != nil {
 		return err
 	}
@@ -1351,9 +2593,10 @@ func (b *Bot) handleScaleDeployment(c telebot.Context) error {
 		b.userStates[c.Sender().ID] = &userState{}
 	}
 	b.userStates[c.Sender().ID].AwaitingReplicaCountFor = &awaitingInputState{
-		Request:   req,
-		MessageID: c.Message().ID,
-		ChatID:    c.Chat().ID,
+		Request:         req,
+		MessageID:       c.Message().ID,
+		ChatID:          c.Chat().ID,
+		CurrentReplicas: currentReplicas,
 	}
 	b.mu.Unlock()
 
@@ -1365,7 +2608,7 @@ func (b *Bot) handleAllocateHardware(c telebot.Context) error {
 	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
 	resourceName := parts[3]
 
-	user := c.Get("ctx").(context.Context).Value("user").(*models.User)
+	user := requestUser(c)
 
 	req := &models.ActionRequest{
 		Action:     string(models.ActionAllocateHardware),
@@ -1376,7 +2619,62 @@ func (b *Bot) handleAllocateHardware(c telebot.Context) error {
 		},
 	}
 
-	err := c.Edit("Введите запрашиваемые ресурсы в формате `cpu=1.5, memory=512Mi`:")
+	if len(parts) < 5 {
+		return b.showResourceProfiles(c, req)
+	}
+	if parts[4] == customProfile {
+		return b.promptHardwareRequest(c, req)
+	}
+
+	req.Parameters["profile"] = parts[4]
+	if b.requiresConfirmation(*req) {
+		return b.promptConfirmation(c, *req)
+	}
+
+	result, err := b.executeAction(c, *req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+	return b.handleActionResult(c, uint(incidentID), *req, result)
+}
+
+const customProfile = "custom"
+
+// showResourceProfiles offers the executor's resource profiles as buttons, with a
+// "custom" option that falls back to free-text cpu/memory entry.
+func (b *Bot) showResourceProfiles(c telebot.Context, req *models.ActionRequest) error {
+	available, err := b.service.GetAvailableResources(requestContext(c))
+	if err != nil || available == nil || len(available.Profiles) == 0 {
+		b.logger.WarnContext(requestContext(c), "Failed to fetch resource profiles, falling back to text input", "incident_id", req.IncidentID, "error", err)
+		return b.promptHardwareRequest(c, req)
+	}
+
+	pod := req.Parameters["pod"]
+	var keyboard [][]telebot.InlineButton
+	for _, profile := range available.Profiles {
+		text := fmt.Sprintf("%s (%s)", profile.Name, profile.Description)
+		if profile.IsDefault {
+			text = "⭐️ " + text
+		}
+		keyboard = append(keyboard, []telebot.InlineButton{{
+			Text: text,
+			Data: b.callbackData(fmt.Sprintf("%s%d:pod:%s:%s", allocateHardwarePrefix, req.IncidentID, pod, profile.Name)),
+		}})
+	}
+	keyboard = append(keyboard, []telebot.InlineButton{{
+		Text: b.t(c, "hardware.custom"),
+		Data: b.callbackData(fmt.Sprintf("%s%d:pod:%s:%s", allocateHardwarePrefix, req.IncidentID, pod, customProfile)),
+	}})
+	keyboard = append(keyboard, []telebot.InlineButton{{
+		Text: b.t(c, "common.back"),
+		Data: b.callbackData(fmt.Sprintf("%s%d:pod:%s", viewResourcePrefix, req.IncidentID, pod)),
+	}})
+
+	return c.Edit(b.t(c, "hardware.choose_profile"), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) promptHardwareRequest(c telebot.Context, req *models.ActionRequest) error {
+	err := c.Edit(b.t(c, "hardware.prompt"))
 	if err != nil {
 		return err
 	}
@@ -1395,9 +2693,12 @@ func (b *Bot) handleAllocateHardware(c telebot.Context) error {
 	return nil
 }
 
+// maxMessageLength is Telegram's limit for a single text message.
+const maxMessageLength = 4096
+
 func escapeMarkdown(s string) string {
 	replacer := strings.NewReplacer(
-		"_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(",
+		"\\", "\\\\", "_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(",
 		"\\(", ")", "\\)", "~", "\\~", "`", "\\`", ">", "\\>",
 		"#", "\\#", "+", "\\+", "-", "\\-", "=", "\\=", "|",
 		"\\|", "{", "\\{", "}", "\\}", ".", "\\.", "!", "\\!",
@@ -1405,61 +2706,127 @@ func escapeMarkdown(s string) string {
 	return replacer.Replace(s)
 }
 
-func (b *Bot) addIncidentView(incidentID uint, editable telebot.Editable) {
+// escapeMarkdownCodeBlock escapes text for use inside a MarkdownV2 pre/code block,
+// where only backticks and backslashes are special.
+func escapeMarkdownCodeBlock(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(s)
+}
+
+// addIncidentView registers msg as a view of the incident and persists it so it can
+// still be updated after a restart.
+func (b *Bot) addIncidentView(incidentID uint, msg *telebot.Message, kind string) {
+	if msg == nil || msg.Chat == nil {
+		return
+	}
 	b.registryMu.Lock()
-	defer b.registryMu.Unlock()
 	if _, ok := b.viewRegistry[incidentID]; !ok {
 		b.viewRegistry[incidentID] = make(map[string]telebot.Editable)
 	}
+	key := getViewRegistryKey(msg)
+	b.viewRegistry[incidentID][key] = msg
+	b.logger.Debug("Added incident view", "incident_id", incidentID, "views", len(b.viewRegistry[incidentID]))
+	b.registryMu.Unlock()
+
+	stored := &models.TelegramMessage{
+		IncidentID: incidentID,
+		ChatID:     msg.Chat.ID,
+		MessageID:  int64(msg.ID),
+		ThreadID:   int64(msg.ThreadID),
+		Kind:       kind,
+	}
+	if err := b.service.AddTelegramMessage(context.Background(), stored); err != nil {
+		b.logger.Error("Failed to persist incident view", "incident_id", incidentID, "view", key, "error", err)
+	}
+}
+
+// incidentViews returns every known message showing the incident: the in-memory
+// registry merged with the persisted messages, so views sent before a restart are
+// still found. The returned map is a copy.
+func (b *Bot) incidentViews(incidentID uint) map[string]telebot.Editable {
+	stored, err := b.service.ListTelegramMessages(context.Background(), incidentID)
+	if err != nil {
+		b.logger.Error("Failed to load persisted incident views", "incident_id", incidentID, "error", err)
+	}
+
+	b.registryMu.Lock()
+	defer b.registryMu.Unlock()
+	views, ok := b.viewRegistry[incidentID]
+	if !ok {
+		views = make(map[string]telebot.Editable)
+	}
+	for _, message := range stored {
+		editable := &telebot.StoredMessage{MessageID: strconv.FormatInt(message.MessageID, 10), ChatID: message.ChatID}
+		key := getViewRegistryKey(editable)
+		if _, ok := views[key]; !ok {
+			views[key] = editable
+		}
+	}
+	if len(views) > 0 {
+		b.viewRegistry[incidentID] = views
+	}
+
+	result := make(map[string]telebot.Editable, len(views))
+	for key, editable := range views {
+		result[key] = editable
+	}
+	return result
+}
+
+// forgetIncidentView drops a view that was deleted or can no longer be edited.
+func (b *Bot) forgetIncidentView(incidentID uint, editable telebot.Editable) {
 	key := getViewRegistryKey(editable)
-	b.viewRegistry[incidentID][key] = editable
-	log.Printf("Added view for incident %d. Total views for this incident: %d", incidentID, len(b.viewRegistry[incidentID]))
+	b.registryMu.Lock()
+	delete(b.viewRegistry[incidentID], key)
+	b.registryMu.Unlock()
+
+	msgSig, chatID := editable.MessageSig()
+	messageID, err := strconv.ParseInt(msgSig, 10, 64)
+	if err != nil {
+		return
+	}
+	if err := b.service.DeleteTelegramMessage(context.Background(), chatID, messageID); err != nil {
+		b.logger.Error("Failed to delete persisted incident view", "incident_id", incidentID, "view", key, "error", err)
+	}
 }
 
+// removeIncidentView drops the incident's views from the in-memory cache only. The
+// persisted messages are kept, so incidentViews still finds them later.
 func (b *Bot) removeIncidentView(incidentID uint) {
 	b.registryMu.Lock()
 	defer b.registryMu.Unlock()
 	delete(b.viewRegistry, incidentID)
-	log.Printf("Removed all views for incident %d", incidentID)
+	b.logger.Debug("Removed all incident views", "incident_id", incidentID)
 }
 
 func (b *Bot) updateIncidentView(incident *models.Incident) {
-	b.registryMu.RLock()
-	views, ok := b.viewRegistry[incident.ID]
-	b.registryMu.RUnlock()
-
-	if !ok {
-		log.Printf("No views registered for incident %d, cannot update.", incident.ID)
+	views := b.incidentViews(incident.ID)
+	if len(views) == 0 {
+		b.logger.Debug("No views registered, cannot update", "incident_id", incident.ID)
 		return
 	}
 
 	historyVisible := false
 	message := b.formatIncidentMessage(incident, historyVisible)
 
-	log.Printf("Attempting to update %d views for incident %d", len(views), incident.ID)
-	for key, editable := range views {
+	b.logger.Debug("Updating incident views", "incident_id", incident.ID, "views", len(views))
+	for _, editable := range views {
 		var keyboard [][]telebot.InlineButton
 		msgSig, _ := editable.MessageSig()
 
 		if incident.TelegramMessageID.Valid && msgSig == strconv.FormatInt(incident.TelegramMessageID.Int64, 10) {
 			keyboard = b.buildIncidentViewKeyboard(incident, historyVisible)
-		} else if isHighSeverity(incident) {
+		} else if b.severityPolicy.usesTopic(incident) {
 			keyboard = b.buildSummaryViewKeyboard(incident, historyVisible)
 		} else {
 			keyboard = b.buildIncidentViewKeyboard(incident, historyVisible)
 		}
 
-		_, err := b.bot.Edit(editable, message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
-		if err != nil {
-			if strings.Contains(err.Error(), "message is not modified") {
-			} else if strings.Contains(err.Error(), "message to edit not found") {
-				log.Printf("View %s for incident %d not found, cannot update.", key, incident.ID)
-			} else {
-				log.Printf("Failed to update view %s for incident %d: %v", key, incident.ID, err)
-			}
-		} else {
-			log.Printf("Successfully updated view %s for incident %d", key, incident.ID)
-		}
+		b.editIncidentView(&pendingEdit{
+			incidentID: incident.ID,
+			editable:   editable,
+			text:       message,
+			markup:     &telebot.ReplyMarkup{InlineKeyboard: keyboard},
+		})
 	}
 }
 
@@ -1478,13 +2845,13 @@ func (b *Bot) handleToggleHistory(c telebot.Context) error {
 		return b.showActionsView(c, uint(incidentID), historyVisible)
 	}
 	if viewType == "summary" {
-		incident, err := b.service.GetIncidentByID(c.Get("ctx").(context.Context), uint(incidentID))
+		incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
 		if err != nil {
-			return c.EditOrSend("Не удалось найти инцидент.")
+			return c.EditOrSend(b.t(c, "common.incident_not_found"))
 		}
 		message := b.formatIncidentMessage(incident, historyVisible)
 		keyboard := b.buildSummaryViewKeyboard(incident, historyVisible)
-		return c.Edit(message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+		return b.editMarkdown(c, message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 	}
 	return b.showIncidentView(c, uint(incidentID), historyVisible)
 }
@@ -1492,17 +2859,15 @@ func (b *Bot) handleToggleHistory(c telebot.Context) error {
 func (b *Bot) buildClosedIncidentViewKeyboard(incident *models.Incident, historyVisible bool) [][]telebot.InlineButton {
 	var keyboard [][]telebot.InlineButton
 
-	historyButtonText := "📖 Показать историю"
-	if historyVisible {
-		historyButtonText = "📖 Скрыть историю"
-	}
-	if isHighSeverity(incident) {
+	if b.severityPolicy.usesTopic(incident) {
 		keyboard = b.buildSummaryViewKeyboard(incident, historyVisible)
 	} else {
-		keyboard = append(keyboard, []telebot.InlineButton{
-			{Text: historyButtonText, Data: fmt.Sprintf("%s%d:%t:closed", toggleHistoryPrefix, incident.ID, !historyVisible)},
-		})
+		keyboard = append(keyboard, b.historyRow(incident, historyVisible, "closed"))
+		if links := b.incidentLinkRow(incident); links != nil {
+			keyboard = append(keyboard, links)
+		}
 	}
+	keyboard = append(keyboard, []telebot.InlineButton{{Text: b.tr.T(b.channelLanguage, "incident.reopen"), Data: b.callbackData(reopenIncidentPrefix + strconv.FormatUint(uint64(incident.ID), 10))}})
 
 	return keyboard
 }
@@ -1511,5 +2876,5 @@ func (b *Bot) showClosedIncidentView(c telebot.Context, incident *models.Inciden
 	message := b.formatIncidentMessage(incident, historyVisible)
 	keyboard := b.buildClosedIncidentViewKeyboard(incident, historyVisible)
 
-	return c.Edit(message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	return b.editMarkdown(c, message, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
 }
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"strings"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	confirmResolveAllPrefix = "rsa:"
+	cancelResolveAllPrefix  = "rsx:"
+)
+
+// parseActiveFilter turns "key=value" arguments into a label filter for bulk
+// commands. Unlike /find, status is not accepted: they only touch active incidents.
+func parseActiveFilter(args []string) (map[string]string, string, bool) {
+	labels := make(map[string]string)
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" || value == "" || !labelKeyPattern.MatchString(key) {
+			return nil, arg, false
+		}
+		labels[key] = value
+	}
+	return labels, "", true
+}
+
+// handleResolveAll asks an admin to confirm resolving every active incident that
+// matches the filter, showing how many would be affected.
+func (b *Bot) handleResolveAll(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	args := c.Args()
+	if len(args) == 0 {
+		return c.Send(b.t(c, "resolve_all.usage"))
+	}
+	labels, invalid, ok := parseActiveFilter(args)
+	if !ok {
+		return c.Send(b.t(c, "find.invalid_filter", invalid))
+	}
+
+	incidents, err := b.service.FindIncidentsByLabels(requestContext(c), labels, models.StatusActive)
+	if err != nil {
+		b.logger.ErrorContext(requestContext(c), "Failed to find incidents for bulk resolve", "labels", labels, "error", err)
+		return c.Send(b.t(c, "find.failed"))
+	}
+	if len(incidents) == 0 {
+		return c.Send(b.t(c, "resolve_all.none"))
+	}
+
+	filter := strings.Join(args, " ")
+	keyboard := [][]telebot.InlineButton{{
+		{Text: b.t(c, "resolve_all.confirm"), Data: b.callbackData(confirmResolveAllPrefix + "0:" + filter)},
+		{Text: b.t(c, "resolve_all.cancel"), Data: b.callbackData(cancelResolveAllPrefix + "0")},
+	}}
+	return c.Send(b.t(c, "resolve_all.prompt", len(incidents), filter), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) handleConfirmResolveAll(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "flags.admin_only"), ShowAlert: true})
+	}
+	parts := strings.SplitN(c.Data(), ":", 3)
+	if len(parts) < 3 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	labels, _, ok := parseActiveFilter(strings.Fields(parts[2]))
+	if !ok || len(labels) == 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+
+	ctx := requestContext(c)
+	resolved, err := b.service.BulkResolve(ctx, requestUser(c).ID, labels)
+	c.Respond()
+	if err != nil {
+		b.logger.ErrorContext(ctx, "Bulk resolve finished with errors", "labels", labels, "resolved", resolved, "error", err)
+		return c.Edit(b.t(c, "resolve_all.partial", resolved))
+	}
+	b.logger.InfoContext(ctx, "Bulk resolved incidents", "labels", labels, "resolved", resolved)
+	return c.Edit(b.t(c, "resolve_all.done", resolved))
+}
+
+func (b *Bot) handleCancelResolveAll(c telebot.Context) error {
+	c.Respond()
+	return c.Edit(b.t(c, "resolve_all.cancelled"))
+}
+
+// handleAckAll acknowledges every active, not yet taken incident on behalf of the
+// caller, optionally limited by a label filter.
+func (b *Bot) handleAckAll(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	labels, invalid, ok := parseActiveFilter(c.Args())
+	if !ok {
+		return c.Send(b.t(c, "find.invalid_filter", invalid))
+	}
+
+	ctx := requestContext(c)
+	acknowledged, err := b.service.AcknowledgeAll(ctx, requestUser(c).ID, labels)
+	if err != nil {
+		b.logger.ErrorContext(ctx, "Bulk acknowledge finished with errors", "labels", labels, "acknowledged", acknowledged, "error", err)
+		return c.Send(b.t(c, "ack_all.partial", acknowledged))
+	}
+	if acknowledged == 0 {
+		return c.Send(b.t(c, "ack_all.none"))
+	}
+	b.logger.InfoContext(ctx, "Bulk acknowledged incidents", "labels", labels, "acknowledged", acknowledged)
+	return c.Send(b.t(c, "ack_all.done", acknowledged))
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	callbackTokenPrefix = "tk:"
+	// Telegram rejects or truncates callback data longer than 64 bytes.
+	maxCallbackDataLength = 64
+
+	defaultCallbackTokenTTL      = 24 * time.Hour
+	defaultCallbackTokenCapacity = 10000
+)
+
+type callbackTokenEntry struct {
+	token     string
+	payload   string
+	expiresAt time.Time
+}
+
+// callbackTokenStore keeps callback payloads that do not fit into a button, keyed
+// by a short random token. Entries expire after the TTL; when the store is full the
+// least recently used one is evicted.
+type callbackTokenStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newCallbackTokenStore(ttl time.Duration, capacity int) *callbackTokenStore {
+	return &callbackTokenStore{ttl: ttl, capacity: capacity, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (s *callbackTokenStore) Put(payload string) (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.order.Len() >= s.capacity {
+		s.remove(s.order.Back())
+	}
+	s.entries[token] = s.order.PushFront(&callbackTokenEntry{token: token, payload: payload, expiresAt: time.Now().Add(s.ttl)})
+	return token, nil
+}
+
+// Resolve returns the payload stored under token and marks it as recently used.
+func (s *callbackTokenStore) Resolve(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.entries[token]
+	if !ok {
+		return "", false
+	}
+	entry := elem.Value.(*callbackTokenEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.remove(elem)
+		return "", false
+	}
+	s.order.MoveToFront(elem)
+	return entry.payload, true
+}
+
+func (s *callbackTokenStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*callbackTokenEntry).token)
+}
+
+// callbackData encodes data for an inline button: it adds the version marker and
+// replaces data that would not fit into the button with a token referring to it.
+// Every button handled by handleCallback must be built with it.
+func (b *Bot) callbackData(data string) string {
+	encoded := encodeCallbackVersion(data)
+	if len(encoded) <= maxCallbackDataLength {
+		return encoded
+	}
+	token, err := b.callbackTokens.Put(data)
+	if err != nil {
+		b.logger.Error("Failed to store long callback data", "data", data, "error", err)
+		return encoded
+	}
+	return encodeCallbackVersion(callbackTokenPrefix + token)
+}
+
+// resolveCallbackData replaces a token in the callback payload with the data it
+// refers to, so handlers can parse c.Data() as usual. It reports false for unknown
+// or expired tokens.
+func (b *Bot) resolveCallbackData(payload string) (string, bool) {
+	token, ok := strings.CutPrefix(payload, callbackTokenPrefix)
+	if !ok {
+		return payload, true
+	}
+	return b.callbackTokens.Resolve(token)
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/testutil"
+)
+
+// TestCallbackDataRoundTrip checks that data too long for a button survives the
+// trip through a token and that short data is sent as is.
+func TestCallbackDataRoundTrip(t *testing.T) {
+	b := &Bot{callbackTokens: newCallbackTokenStore(time.Hour, 10), logger: testutil.DiscardLogger()}
+	long := getPodLogsPrefix + "12:payments-api-7d9f8b6c5d-x2k4q:payments-api-sidecar-container:payments-prod"
+	short := acknowledgePrefix + "12"
+
+	tests := []struct {
+		name      string
+		data      string
+		wantToken bool
+	}{
+		{"long pod name", long, true},
+		{"short data", short, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := b.callbackData(tt.data)
+			if len(encoded) > maxCallbackDataLength {
+				t.Fatalf("callback data is %d bytes, want at most %d", len(encoded), maxCallbackDataLength)
+			}
+			version, payload := decodeCallbackVersion(encoded)
+			if version != callbackDataVersion {
+				t.Errorf("version = %d, want %d", version, callbackDataVersion)
+			}
+			if got := strings.HasPrefix(payload, callbackTokenPrefix); got != tt.wantToken {
+				t.Errorf("payload %q uses a token = %v, want %v", payload, got, tt.wantToken)
+			}
+			got, ok := b.resolveCallbackData(payload)
+			if !ok || got != tt.data {
+				t.Errorf("resolveCallbackData = %q, %v, want %q", got, ok, tt.data)
+			}
+		})
+	}
+
+	if _, ok := b.resolveCallbackData(callbackTokenPrefix + "000000000000"); ok {
+		t.Error("an unknown token was resolved")
+	}
+}
+
+func TestCallbackTokenExpiry(t *testing.T) {
+	store := newCallbackTokenStore(10*time.Millisecond, 10)
+	token, err := store.Put("payload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Resolve(token); !ok {
+		t.Fatal("a fresh token was not resolved")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got, ok := store.Resolve(token); ok {
+		t.Errorf("expired token resolved to %q", got)
+	}
+	if n := store.order.Len(); n != 0 {
+		t.Errorf("store holds %d entries after expiry, want 0", n)
+	}
+}
+
+// TestCallbackTokenEviction checks that a full store evicts the least recently
+// used token, where resolving a token counts as a use.
+func TestCallbackTokenEviction(t *testing.T) {
+	store := newCallbackTokenStore(time.Hour, 3)
+	tokens := make([]string, 3)
+	for i := range tokens {
+		token, err := store.Put(fmt.Sprintf("payload-%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens[i] = token
+	}
+	// Using the oldest token makes the second one the least recently used.
+	if _, ok := store.Resolve(tokens[0]); !ok {
+		t.Fatal("token 0 was not resolved")
+	}
+	newest, err := store.Put("payload-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, token := range append(tokens, newest) {
+		_, ok := store.Resolve(token)
+		if want := i != 1; ok != want {
+			t.Errorf("token %d resolved = %v, want %v", i, ok, want)
+		}
+	}
+	if n := store.order.Len(); n != 3 {
+		t.Errorf("store holds %d entries, want 3", n)
+	}
+}
@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"strconv"
+	"strings"
+)
+
+// callbackDataVersion is the layout version written into every button. Bump it
+// when callback data changes incompatibly: buttons of older messages then get a
+// "message is outdated" reply instead of being misparsed.
+const callbackDataVersion = 1
+
+// callbackVersionSeparator ends the version marker, e.g. "v1|vi:42".
+const callbackVersionSeparator = "|"
+
+// encodeCallbackVersion prepends the current version marker to data.
+func encodeCallbackVersion(data string) string {
+	return "v" + strconv.Itoa(callbackDataVersion) + callbackVersionSeparator + data
+}
+
+// decodeCallbackVersion splits callback data into its layout version and payload.
+// Data without a version marker was written before versioning was introduced and
+// is reported as version 0.
+func decodeCallbackVersion(data string) (int, string) {
+	marker, payload, ok := strings.Cut(data, callbackVersionSeparator)
+	if !ok || !strings.HasPrefix(marker, "v") {
+		return 0, data
+	}
+	version, err := strconv.Atoi(marker[1:])
+	if err != nil || version <= 0 {
+		return 0, data
+	}
+	return version, payload
+}
+
+// isCurrentCallback reports whether payload, decoded from data of the given
+// version, can be dispatched. Unversioned (legacy) data is accepted as long as it
+// has the "prefix:incidentID" layout every handler still expects.
+func isCurrentCallback(version int, payload string) bool {
+	if version == callbackDataVersion {
+		return true
+	}
+	if version != 0 {
+		return false
+	}
+	prefix, rest, ok := strings.Cut(payload, ":")
+	if !ok || prefix == "" {
+		return false
+	}
+	id, _, _ := strings.Cut(rest, ":")
+	_, err := strconv.ParseUint(id, 10, 32)
+	return err == nil
+}
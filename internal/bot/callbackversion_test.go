@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestDecodeCallbackVersion(t *testing.T) {
+	tests := []struct {
+		data        string
+		wantVersion int
+		wantPayload string
+	}{
+		{"v1|vi:42", 1, "vi:42"},
+		{"v2|vi:42:extra", 2, "vi:42:extra"},
+		{"vi:42", 0, "vi:42"},
+		{"gpl:12:api-0:app:prod", 0, "gpl:12:api-0:app:prod"},
+		{"vx|vi:42", 0, "vx|vi:42"},
+		{"v0|vi:42", 0, "v0|vi:42"},
+		{"v-1|vi:42", 0, "v-1|vi:42"},
+		{"", 0, ""},
+	}
+	for _, tt := range tests {
+		version, payload := decodeCallbackVersion(tt.data)
+		if version != tt.wantVersion || payload != tt.wantPayload {
+			t.Errorf("decodeCallbackVersion(%q) = %d, %q; want %d, %q", tt.data, version, payload, tt.wantVersion, tt.wantPayload)
+		}
+	}
+
+	if version, payload := decodeCallbackVersion(encodeCallbackVersion("ack:7")); version != callbackDataVersion || payload != "ack:7" {
+		t.Errorf("round trip = %d, %q; want %d, \"ack:7\"", version, payload, callbackDataVersion)
+	}
+}
+
+func TestIsCurrentCallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		payload string
+		want    bool
+	}{
+		{"current", callbackDataVersion, "vi:42", true},
+		{"newer version", callbackDataVersion + 1, "vi:42", false},
+		{"legacy with incident ID", 0, "vi:42", true},
+		{"legacy with extra parts", 0, "gpl:12:api-0:app:prod", true},
+		{"legacy without ID", 0, "vi", false},
+		{"legacy with a non-numeric ID", 0, "vi:abc", false},
+		{"legacy without prefix", 0, ":42", false},
+	}
+	for _, tt := range tests {
+		if got := isCurrentCallback(tt.version, tt.payload); got != tt.want {
+			t.Errorf("%s: isCurrentCallback(%d, %q) = %v, want %v", tt.name, tt.version, tt.payload, got, tt.want)
+		}
+	}
+}
+
+// TestHandleCallbackVersions checks that current and legacy buttons are
+// dispatched, and that buttons the bot cannot parse get the "outdated" alert
+// instead of being ignored.
+func TestHandleCallbackVersions(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "en")
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X"}, nil)
+	view := fmt.Sprintf("%s%d", viewIncidentPrefix, incident.ID)
+	const outdated = "This message is outdated, open the incident again."
+
+	tests := []struct {
+		name         string
+		data         string
+		wantOutdated bool
+	}{
+		{"current format", encodeCallbackVersion(view), false},
+		{"legacy format", view, false},
+		{"newer format", fmt.Sprintf("v%d|%s", callbackDataVersion+1, view), true},
+		{"legacy without ID", "vi:", true},
+		{"legacy garbage", "refresh", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCallbackContext(tt.data, user)
+			if err := tb.handleCallback(c); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.lastResponse() == outdated; got != tt.wantOutdated {
+				t.Errorf("outdated alert = %v, want %v (response %q)", got, tt.wantOutdated, c.lastResponse())
+			}
+			if dispatched := len(c.edits) > 0; dispatched == tt.wantOutdated {
+				t.Errorf("view rendered = %v, want %v", dispatched, !tt.wantOutdated)
+			}
+		})
+	}
+}
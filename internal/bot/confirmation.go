@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	confirmActionPrefix = "cfa:"
+	cancelActionPrefix  = "cxa:"
+
+	pendingActionTTL = 5 * time.Minute
+)
+
+var defaultDestructiveActions = []string{
+	string(models.ActionDeletePod),
+	string(models.ActionRollbackDeployment),
+	string(models.ActionDrainNode),
+}
+
+type pendingAction struct {
+	Request models.ActionRequest
+	// RequesterID is the Telegram ID of the user who asked for the action; only
+	// they can confirm or cancel it.
+	RequesterID int64
+	ExpiresAt   time.Time
+}
+
+// pendingActionStore keeps destructive actions awaiting confirmation, keyed by a short
+// token so the callback data stays under Telegram's 64-byte limit.
+type pendingActionStore struct {
+	mu      sync.Mutex
+	actions map[string]*pendingAction
+}
+
+func newPendingActionStore() *pendingActionStore {
+	return &pendingActionStore{actions: make(map[string]*pendingAction)}
+}
+
+func (s *pendingActionStore) Put(req models.ActionRequest, requesterID int64) (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for key, action := range s.actions {
+		if now.After(action.ExpiresAt) {
+			delete(s.actions, key)
+		}
+	}
+	s.actions[token] = &pendingAction{Request: req, RequesterID: requesterID, ExpiresAt: now.Add(pendingActionTTL)}
+	return token, nil
+}
+
+// Peek returns the pending action without removing it, so a tap by someone who
+// may not confirm it leaves it for the requester.
+func (s *pendingActionStore) Peek(token string) (*pendingAction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action, ok := s.actions[token]
+	if !ok || time.Now().After(action.ExpiresAt) {
+		return nil, false
+	}
+	return action, true
+}
+
+// Take removes and returns the pending action; of two concurrent taps only one
+// gets it.
+func (s *pendingActionStore) Take(token string) (*pendingAction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action, ok := s.actions[token]
+	if !ok {
+		return nil, false
+	}
+	delete(s.actions, token)
+	if time.Now().After(action.ExpiresAt) {
+		return nil, false
+	}
+	return action, true
+}
+
+func (b *Bot) isDestructive(req models.ActionRequest) bool {
+	if models.ActionType(req.Action) == models.ActionScaleDeployment && req.Parameters["replicas"] == "0" {
+		return true
+	}
+	return b.destructiveActions[req.Action]
+}
+
+func (b *Bot) requiresConfirmation(req models.ActionRequest) bool {
+	return b.flags.IsEnabled(models.FlagConfirmationPrompts) && b.isDestructive(req)
+}
+
+func (b *Bot) confirmationPrompt(c telebot.Context, req models.ActionRequest) (string, *telebot.ReplyMarkup, error) {
+	token, err := b.pendingActions.Put(req, c.Sender().ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	text := b.t(c, "confirm.prompt", req.Action, actionTarget(req.Parameters))
+	if replicas, ok := req.Parameters["replicas"]; ok {
+		text += b.t(c, "confirm.replicas", replicas)
+	}
+	if command := models.KubectlCommand(req.Action, req.Parameters); command != "" {
+		text += b.t(c, "confirm.command", command)
+	}
+
+	idStr := strconv.FormatUint(uint64(req.IncidentID), 10)
+	keyboard := [][]telebot.InlineButton{{
+		{Text: b.t(c, "confirm.yes"), Data: b.callbackData(confirmActionPrefix + idStr + ":" + token)},
+		{Text: b.t(c, "confirm.cancel"), Data: b.callbackData(cancelActionPrefix + idStr + ":" + token)},
+	}}
+	return text, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, nil
+}
+
+// actionTarget names the resource an action acts on: a pod, a deployment or a node.
+func actionTarget(params map[string]string) string {
+	for _, key := range []string{"pod_name", "deployment", "node"} {
+		if params[key] != "" {
+			return params[key]
+		}
+	}
+	return "—"
+}
+
+func (b *Bot) promptConfirmation(c telebot.Context, req models.ActionRequest) error {
+	text, markup, err := b.confirmationPrompt(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+	return c.Edit(text, markup)
+}
+
+func (b *Bot) handleConfirmAction(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) < 3 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	pending, ok := b.pendingActions.Peek(parts[2])
+	if !ok {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "confirm.expired"), ShowAlert: true})
+	}
+	if pending.RequesterID != c.Sender().ID {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "confirm.not_requester"), ShowAlert: true})
+	}
+
+	req := pending.Request
+	if !b.isAuthorized(c, req.Action) {
+		return b.denyUnauthorized(c, req.IncidentID, req.Action, req.Parameters)
+	}
+	// Checks passed: take the token now, so a double tap runs the action once.
+	if _, ok := b.pendingActions.Take(parts[2]); !ok {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "confirm.expired"), ShowAlert: true})
+	}
+
+	user := requestUser(c)
+	req.UserID = user.ID
+
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+
+	if models.ActionType(req.Action) == models.ActionScaleDeployment {
+		alertText := result.Message
+		if result.Error != "" {
+			alertText = result.Error
+		}
+		c.Respond(&telebot.CallbackResponse{Text: alertText, ShowAlert: true})
+		return b.renderResourceActionsView(c, req.IncidentID, "deployment", req.Parameters["deployment"], nil, nil)
+	}
+	return b.handleActionResult(c, req.IncidentID, req, result)
+}
+
+func (b *Bot) handleCancelAction(c telebot.Context, incidentID uint) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) >= 3 {
+		if pending, ok := b.pendingActions.Peek(parts[2]); ok && pending.RequesterID != c.Sender().ID {
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "confirm.cancel_not_requester"), ShowAlert: true})
+		}
+		b.pendingActions.Take(parts[2])
+	}
+	c.Respond(&telebot.CallbackResponse{Text: b.t(c, "confirm.cancelled")})
+	return b.showIncidentView(c, incidentID, false)
+}
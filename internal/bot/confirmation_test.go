@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+func TestPendingActionStore(t *testing.T) {
+	store := newPendingActionStore()
+	req := models.ActionRequest{Action: string(models.ActionDeletePod), IncidentID: 1, Parameters: map[string]string{"pod_name": "api-0"}}
+
+	token, err := store.Put(req, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Peeking, e.g. on a tap by another user, leaves the action in place.
+	for i := 0; i < 2; i++ {
+		pending, ok := store.Peek(token)
+		if !ok {
+			t.Fatalf("Peek #%d: action not found", i+1)
+		}
+		if pending.RequesterID != 42 || pending.Request.Parameters["pod_name"] != "api-0" {
+			t.Fatalf("Peek #%d = %+v", i+1, pending)
+		}
+	}
+
+	if _, ok := store.Take(token); !ok {
+		t.Fatal("Take: action not found")
+	}
+	if _, ok := store.Take(token); ok {
+		t.Fatal("second Take returned the action again")
+	}
+	if _, ok := store.Peek(token); ok {
+		t.Fatal("Peek after Take returned the action")
+	}
+}
+
+func TestPendingActionStoreExpiry(t *testing.T) {
+	store := newPendingActionStore()
+	token, err := store.Put(models.ActionRequest{Action: string(models.ActionDrainNode)}, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.actions[token].ExpiresAt = time.Now().Add(-time.Second)
+
+	if _, ok := store.Peek(token); ok {
+		t.Error("Peek returned an expired action")
+	}
+	if _, ok := store.Take(token); ok {
+		t.Error("Take returned an expired action")
+	}
+}
+
+func TestActionTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   string
+	}{
+		{name: "pod", params: map[string]string{"pod_name": "api-0", "deployment": "api", "namespace": "prod"}, want: "api-0"},
+		{name: "deployment", params: map[string]string{"deployment": "api", "namespace": "prod", "replicas": "0"}, want: "api"},
+		{name: "node", params: map[string]string{"node": "worker-1"}, want: "worker-1"},
+		{name: "empty pod name falls through", params: map[string]string{"pod_name": "", "deployment": "api"}, want: "api"},
+		{name: "nothing", params: map[string]string{}, want: "—"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := actionTarget(tt.params); got != tt.want {
+				t.Errorf("actionTarget(%v) = %q, want %q", tt.params, got, tt.want)
+			}
+		})
+	}
+}
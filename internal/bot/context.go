@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// ctxFromContext returns the request context and the authenticated user stored by
+// authMiddleware. ok is false when the middleware did not run to completion, e.g.
+// for channel posts without a sender.
+func ctxFromContext(c telebot.Context) (context.Context, *models.User, bool) {
+	ctx, ok := c.Get("ctx").(context.Context)
+	if !ok || ctx == nil {
+		return context.Background(), nil, false
+	}
+	user, ok := ctx.Value("user").(*models.User)
+	if !ok || user == nil {
+		return ctx, nil, false
+	}
+	return ctx, user, true
+}
+
+// requestContext and requestUser are used by handlers wrapped with requireUser,
+// which guarantees that both values are present.
+func requestContext(c telebot.Context) context.Context {
+	ctx, _, _ := ctxFromContext(c)
+	return ctx
+}
+
+func requestUser(c telebot.Context) *models.User {
+	_, user, ok := ctxFromContext(c)
+	if !ok {
+		return &models.User{}
+	}
+	return user
+}
+
+// requireUser rejects updates that reached a handler without an authenticated user
+// instead of letting the handler panic on a missing context value.
+func (b *Bot) requireUser(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		if _, _, ok := ctxFromContext(c); ok {
+			return next(c)
+		}
+		if c.Sender() == nil {
+			return nil
+		}
+		b.logger.Warn("No authenticated user for update, ignoring", "telegram_id", c.Sender().ID)
+		if c.Callback() != nil {
+			return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.auth_failed"), ShowAlert: true})
+		}
+		return c.Send(b.t(c, "common.auth_failed"))
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/testutil"
+
+	"gopkg.in/telebot.v3"
+)
+
+func TestRequireUser(t *testing.T) {
+	user := &models.User{TelegramID: 42, Username: "alice"}
+	tests := []struct {
+		name         string
+		context      func() *fakeContext
+		wantNext     bool
+		wantResponse string
+		wantAlert    bool
+		wantSent     string
+	}{
+		{
+			name:     "authenticated callback",
+			context:  func() *fakeContext { return newCallbackContext("ack:1", user) },
+			wantNext: true,
+		},
+		{
+			name: "callback without a user",
+			context: func() *fakeContext {
+				c := newCallbackContext("ack:1", user)
+				delete(c.store, "ctx")
+				return c
+			},
+			wantResponse: "Произошла ошибка аутентификации.",
+			wantAlert:    true,
+		},
+		{
+			name: "callback with a context but no user",
+			context: func() *fakeContext {
+				c := newCallbackContext("ack:1", user)
+				c.sender.LanguageCode = "en"
+				c.store["ctx"] = context.Background()
+				return c
+			},
+			wantResponse: "Authentication failed.",
+			wantAlert:    true,
+		},
+		{
+			name: "message without a user",
+			context: func() *fakeContext {
+				return &fakeContext{sender: &telebot.User{ID: 42}, store: make(map[string]interface{})}
+			},
+			wantSent: "Произошла ошибка аутентификации.",
+		},
+		{
+			name: "channel post without a sender",
+			context: func() *fakeContext {
+				return &fakeContext{store: make(map[string]interface{})}
+			},
+		},
+	}
+	b := newLocalizedBot("ru")
+	b.logger = testutil.DiscardLogger()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.context()
+			called := false
+			handler := b.requireUser(func(c telebot.Context) error {
+				called = true
+				if got := requestUser(c); got.TelegramID != user.TelegramID {
+					t.Errorf("requestUser = %+v, want %d", got, user.TelegramID)
+				}
+				return nil
+			})
+			if err := handler(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if called != tt.wantNext {
+				t.Errorf("handler called = %v, want %v", called, tt.wantNext)
+			}
+			if got := c.lastResponse(); got != tt.wantResponse {
+				t.Errorf("callback answer = %q, want %q", got, tt.wantResponse)
+			}
+			if tt.wantResponse != "" && c.responses[0].ShowAlert != tt.wantAlert {
+				t.Errorf("answer shown as alert = %v, want %v", c.responses[0].ShowAlert, tt.wantAlert)
+			}
+			var sent string
+			if len(c.sent) > 0 {
+				sent = c.sent[0]
+			}
+			if sent != tt.wantSent {
+				t.Errorf("sent = %q, want %q", sent, tt.wantSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+)
+
+const callbackDedupeTTL = 5 * time.Second
+
+// callbackDeduper remembers recently handled callbacks so a double-tap or a
+// callback delivered twice by Telegram is only handled once.
+type callbackDeduper struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[string]time.Time
+}
+
+func newCallbackDeduper(ttl time.Duration) *callbackDeduper {
+	return &callbackDeduper{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// Seen records key and reports whether it was already recorded within the TTL.
+func (d *callbackDeduper) Seen(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for k, at := range d.seen {
+		if now.Sub(at) >= d.ttl {
+			delete(d.seen, k)
+		}
+	}
+	if _, ok := d.seen[key]; ok {
+		return true
+	}
+	d.seen[key] = now
+	return false
+}
+
+func callbackDedupeKey(c telebot.Context) string {
+	var chatID int64
+	var messageID int
+	if msg := c.Message(); msg != nil {
+		messageID = msg.ID
+		if msg.Chat != nil {
+			chatID = msg.Chat.ID
+		}
+	}
+	return fmt.Sprintf("%d:%d:%s", chatID, messageID, c.Data())
+}
+
+// actionIdempotencyKey derives a key identifying the update that triggered the
+// action. A callback delivered twice keeps its ID, so the service can reject the replay.
+func actionIdempotencyKey(c telebot.Context, action string) string {
+	if cb := c.Callback(); cb != nil && cb.ID != "" {
+		return fmt.Sprintf("cb:%s:%s", cb.ID, action)
+	}
+	if msg := c.Message(); msg != nil && msg.Chat != nil {
+		return fmt.Sprintf("msg:%d:%d:%s", msg.Chat.ID, msg.ID, action)
+	}
+	return ""
+}
+
+// executeAction runs req on behalf of the update in c, tagging it with an
+// idempotency key. While the dry_run flag is on, actions are only simulated.
+func (b *Bot) executeAction(c telebot.Context, req models.ActionRequest) (models.ActionResult, error) {
+	req.IdempotencyKey = actionIdempotencyKey(c, req.Action)
+	if b.flags.IsEnabled(models.FlagDryRun) {
+		req.DryRun = true
+	}
+	result, err := b.service.ExecuteAction(requestContext(c), req)
+	if errors.Is(err, service.ErrDuplicateAction) {
+		b.logger.Info("Ignoring duplicate action", "incident_id", req.IncidentID, "action", req.Action)
+	}
+	return result, err
+}
@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+func TestCallbackDeduperConcurrentDuplicates(t *testing.T) {
+	d := newCallbackDeduper(time.Minute)
+	var handled atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !d.Seen("-100:42:pa:1:0") {
+				handled.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := handled.Load(); got != 1 {
+		t.Fatalf("callback handled %d times, want 1", got)
+	}
+	if d.Seen("-100:42:pa:1:1") {
+		t.Error("a different callback was treated as a duplicate")
+	}
+}
+
+func TestCallbackDeduperExpiry(t *testing.T) {
+	d := newCallbackDeduper(10 * time.Millisecond)
+	if d.Seen("key") {
+		t.Fatal("first callback reported as duplicate")
+	}
+	if !d.Seen("key") {
+		t.Fatal("immediate repeat not reported as duplicate")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if d.Seen("key") {
+		t.Error("callback after the TTL reported as duplicate")
+	}
+}
+
+// TestExecuteActionDryRunFlag checks that while the dry_run flag is on the
+// executor gets no mutating request, and that it is called again once the flag
+// is turned off.
+func TestExecuteActionDryRunFlag(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 1, "")
+	incident := tb.incident(t, models.JSONBMap{"alertname": "Dry"}, nil)
+	restart := models.ActionRequest{
+		Action:     string(models.ActionRestartDeployment),
+		IncidentID: incident.ID,
+		UserID:     user.ID,
+		Parameters: map[string]string{"deployment": "api", "namespace": "prod"},
+	}
+	if err := tb.flags.Set(context.Background(), models.FlagDryRun, true); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newCallbackContext("pa:1:0", user)
+	c.callback.ID = "cb-1"
+	result, err := tb.executeAction(c, restart)
+	if err != nil {
+		t.Fatalf("executeAction: %v", err)
+	}
+	if calls := tb.executor.Calls(); len(calls) != 0 {
+		t.Fatalf("executor got %v on a dry run", calls)
+	}
+	if result.Error != "" {
+		t.Errorf("dry run result error = %q", result.Error)
+	}
+
+	// Read-only actions are not simulated.
+	c = newCallbackContext("lpfd:1:api", user)
+	c.callback.ID = "cb-2"
+	if _, err := tb.executeAction(c, models.ActionRequest{Action: string(models.ActionListPodsForDeployment), IncidentID: incident.ID, UserID: user.ID, Parameters: restart.Parameters}); err != nil {
+		t.Fatal(err)
+	}
+	if calls := tb.executor.Calls(); len(calls) != 1 || calls[0].Action != string(models.ActionListPodsForDeployment) {
+		t.Fatalf("executor calls = %v, want only the read-only list", calls)
+	}
+
+	if err := tb.flags.Set(context.Background(), models.FlagDryRun, false); err != nil {
+		t.Fatal(err)
+	}
+	c = newCallbackContext("pa:1:0", user)
+	c.callback.ID = "cb-3"
+	if _, err := tb.executeAction(c, restart); err != nil {
+		t.Fatal(err)
+	}
+	last, ok := tb.executor.LastCall()
+	if !ok || last.Action != restart.Action || last.DryRun {
+		t.Errorf("last executor call = %+v, want a real restart", last)
+	}
+
+	got, err := tb.repo.FindByID(context.Background(), incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dryRuns []bool
+	for _, entry := range got.AuditLog {
+		if entry.Action == restart.Action {
+			dryRuns = append(dryRuns, entry.DryRun)
+		}
+	}
+	if len(dryRuns) != 2 || !dryRuns[0] || dryRuns[1] {
+		t.Errorf("restart audit dry-run marks = %v, want [true false]", dryRuns)
+	}
+}
@@ -0,0 +1,29 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// isBenignEditError reports whether an edit failed only because the message
+// already shows the requested content.
+func isBenignEditError(err error) bool {
+	if errors.Is(err, telebot.ErrMessageNotModified) {
+		return true
+	}
+	// Telegram appends details to this description, which defeats telebot's exact
+	// match, so fall back to the message text.
+	return err != nil && strings.Contains(err.Error(), "message is not modified")
+}
+
+// isMessageGoneError reports whether the edited message no longer exists or can
+// no longer be edited, so the view should be dropped instead of retried.
+func isMessageGoneError(err error) bool {
+	if errors.Is(err, telebot.ErrCantEditMessage) {
+		return true
+	}
+	// telebot v3 has no typed error for this response.
+	return err != nil && strings.Contains(err.Error(), "message to edit not found")
+}
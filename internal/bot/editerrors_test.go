@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gopkg.in/telebot.v3"
+)
+
+func TestEditErrorClassification(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantBenign bool
+		wantGone   bool
+	}{
+		{name: "nil", err: nil},
+		{name: "not modified", err: telebot.ErrMessageNotModified, wantBenign: true},
+		{name: "wrapped not modified", err: fmt.Errorf("edit view: %w", telebot.ErrMessageNotModified), wantBenign: true},
+		{
+			name:       "not modified with details",
+			err:        telebot.NewError(400, "Bad Request: message is not modified: specified new message content and reply markup are exactly the same as a current content and reply markup of the message"),
+			wantBenign: true,
+		},
+		{name: "cannot edit", err: telebot.ErrCantEditMessage, wantGone: true},
+		{name: "message to edit not found", err: telebot.NewError(400, "Bad Request: message to edit not found"), wantGone: true},
+		{name: "flood", err: telebot.NewError(429, "Too Many Requests: retry after 5")},
+		{name: "network", err: errors.New("dial tcp: connection refused")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBenignEditError(tt.err); got != tt.wantBenign {
+				t.Errorf("isBenignEditError(%v) = %v, want %v", tt.err, got, tt.wantBenign)
+			}
+			if got := isMessageGoneError(tt.err); got != tt.wantGone {
+				t.Errorf("isMessageGoneError(%v) = %v, want %v", tt.err, got, tt.wantGone)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	showExecCommandsPrefix = "exl:"
+	execInPodPrefix        = "exc:"
+)
+
+// handleShowExecCommands lists the allowlisted commands for a container. Commands are
+// referenced by their index in the allowlist so free text never reaches the executor.
+func (b *Bot) handleShowExecCommands(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) < 4 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	podName := parts[2]
+	containerName := parts[3]
+
+	if len(b.execCommands) == 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "exec.disabled"), ShowAlert: true})
+	}
+
+	var keyboard [][]telebot.InlineButton
+	for i, command := range b.execCommands {
+		callbackData := b.callbackData(fmt.Sprintf("%s%d:%s:%s:%d", execInPodPrefix, incidentID, podName, containerName, i))
+		keyboard = append(keyboard, []telebot.InlineButton{{Text: "$ " + command, Data: callbackData}})
+	}
+	backCallbackData := b.callbackData(fmt.Sprintf("%s%d:%s", listContainersForPodPrefix, incidentID, podName))
+	keyboard = append(keyboard, []telebot.InlineButton{{Text: b.t(c, "common.back"), Data: backCallbackData}})
+
+	text := b.t(c, "exec.prompt", escapeMarkdown(containerName), escapeMarkdown(podName))
+	return b.editMarkdown(c, text, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+}
+
+func (b *Bot) handleExecInPod(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) < 5 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	podName := parts[2]
+	containerName := parts[3]
+	commandIndex, err := strconv.Atoi(parts[4])
+	if err != nil || commandIndex < 0 || commandIndex >= len(b.execCommands) {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "exec.not_allowed")})
+	}
+
+	incident, err := b.service.GetIncidentByID(requestContext(c), uint(incidentID))
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
+	}
+
+	user := requestUser(c)
+	req := models.ActionRequest{
+		Action:     string(models.ActionExecInPod),
+		IncidentID: uint(incidentID),
+		UserID:     user.ID,
+		Parameters: map[string]string{
+			"pod_name":  podName,
+			"namespace": incident.Labels["namespace"],
+			"container": containerName,
+			"command":   b.execCommands[commandIndex],
+		},
+	}
+
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+
+	return b.handleActionResult(c, uint(incidentID), req, result)
+}
+
+// formatExecOutput joins stdout and stderr of an exec result into a single text.
+func formatExecOutput(result models.ActionResult) string {
+	var stdout, stderr string
+	for _, item := range result.ResultData.Items {
+		switch item.Name {
+		case "stdout":
+			stdout = item.Status
+		case "stderr":
+			stderr = item.Status
+		}
+	}
+	if stderr == "" {
+		return stdout
+	}
+	return fmt.Sprintf("%s\n--- stderr ---\n%s", stdout, stderr)
+}
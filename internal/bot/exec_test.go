@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestShowExecCommands(t *testing.T) {
+	user := &models.User{TelegramID: 42, Language: "en"}
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		b := newLocalizedBot("ru")
+		c := newCallbackContext(showExecCommandsPrefix+"7:api-0:app", user)
+		if err := b.handleShowExecCommands(c); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := c.lastResponse(), "Exec is disabled: the command allowlist is empty."; got != want {
+			t.Errorf("response = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("commands", func(t *testing.T) {
+		b := newLocalizedBot("ru")
+		b.execCommands = []string{"env"}
+		c := newCallbackContext(showExecCommandsPrefix+"7:api-0:app", user)
+		if err := b.handleShowExecCommands(c); err != nil {
+			t.Fatal(err)
+		}
+		if len(c.edits) != 1 || !strings.HasPrefix(c.edits[0], "Choose a command for container `app` of pod `api\\-0`") {
+			t.Errorf("edits = %q, want the English command prompt", c.edits)
+		}
+	})
+
+	t.Run("command removed from the allowlist", func(t *testing.T) {
+		b := newLocalizedBot("ru")
+		b.execCommands = []string{"env"}
+		c := newCallbackContext(execInPodPrefix+"7:api-0:app:3", user)
+		if err := b.handleExecInPod(c); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := c.lastResponse(), "This command is no longer allowed."; got != want {
+			t.Errorf("response = %q, want %q", got, want)
+		}
+	})
+}
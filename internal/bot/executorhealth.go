@@ -0,0 +1,25 @@
+package bot
+
+import (
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// executorDown reports whether the executor failed its (cached) health probe.
+// Action views then show a banner and leave out buttons that change the cluster.
+func (b *Bot) executorDown(c telebot.Context) bool {
+	return !b.service.ExecutorAvailable(requestContext(c))
+}
+
+// executorDownBanner is the MarkdownV2 banner shown above action views while the
+// executor is unavailable.
+func (b *Bot) executorDownBanner(c telebot.Context) string {
+	return escapeMarkdown(b.t(c, "executor.unavailable")) + "\n\n"
+}
+
+// hiddenWhileExecutorDown reports whether an action button is left out while the
+// executor is unavailable. Read-only actions stay so their failure is explicit.
+func hiddenWhileExecutorDown(action models.SuggestedAction, executorDown bool) bool {
+	return executorDown && !models.ActionType(action.Action).IsReadOnly()
+}
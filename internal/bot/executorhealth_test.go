@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestResourceViewWhileExecutorDown(t *testing.T) {
+	tests := []struct {
+		name       string
+		down       bool
+		wantBanner bool
+	}{
+		{name: "executor up"},
+		{name: "executor down", down: true, wantBanner: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestBot(t)
+			tb.executor.Down = tt.down
+			user := tb.user(t, 42, "en")
+			tb.executor.Details = &models.ResourceDetails{Status: models.DeploymentDegraded, ReplicasInfo: "1/4 ready", DesiredReplicas: 4, ReadyReplicas: 1}
+			incident := tb.incident(t,
+				models.JSONBMap{"alertname": replicasMismatchAlert, "namespace": "prod"},
+				models.JSONBMap{"deployment": "api"},
+			)
+
+			c := newCallbackContext("", user)
+			if err := tb.renderResourceActionsView(c, incident.ID, "deployment", "api", nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			view := c.edits[len(c.edits)-1]
+			if got := strings.HasPrefix(view, "⚠️ Executor is unavailable"); got != tt.wantBanner {
+				t.Errorf("banner shown = %v, want %v in %q", got, tt.wantBanner, view)
+			}
+			buttons := buttonTexts(c.lastMarkup().InlineKeyboard)
+			for _, mutating := range []string{"🔁", "↔️"} {
+				if _, ok := findButton(c.lastMarkup(), mutating); ok == tt.down {
+					t.Errorf("%s button present = %v with executor down = %v: %q", mutating, ok, tt.down, buttons)
+				}
+			}
+			if _, ok := findButton(c.lastMarkup(), "📖"); !ok {
+				t.Errorf("read-only describe button missing: %q", buttons)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var memoryQuantityPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?)(Ki|Mi|Gi|Ti|Pi|Ei|k|M|G|T|P|E)?$`)
+
+// parseHardwareRequest parses input of the form "cpu=1.5, memory=512Mi". CPU must be
+// a positive number of cores and memory a positive Kubernetes quantity.
+func parseHardwareRequest(input string) (cpu, memory string, err error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n' || r == '\t'
+	})
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return "", "", fmt.Errorf("expected key=value, got %q", field)
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "cpu":
+			cores, err := strconv.ParseFloat(value, 64)
+			if err != nil || !(cores > 0) || math.IsInf(cores, 0) {
+				return "", "", fmt.Errorf("invalid cpu %q: must be a positive number", value)
+			}
+			cpu = value
+		case "memory", "mem":
+			match := memoryQuantityPattern.FindStringSubmatch(value)
+			if match == nil {
+				return "", "", fmt.Errorf("invalid memory %q: expected a quantity like 512Mi or 2Gi", value)
+			}
+			if amount, _ := strconv.ParseFloat(match[1], 64); amount <= 0 {
+				return "", "", fmt.Errorf("invalid memory %q: must be positive", value)
+			}
+			memory = value
+		default:
+			return "", "", fmt.Errorf("unknown key %q", key)
+		}
+	}
+	if cpu == "" || memory == "" {
+		return "", "", errors.New("both cpu and memory are required")
+	}
+	return cpu, memory, nil
+}
+
+// formatUsageVsLimit renders usage against its limit, e.g. "0.45 / 1.00 cores (45%)",
+// so throttling and OOM risk stand out. A zero limit means the container is
+// unlimited and is shown with the localized unlimited label instead.
+func formatUsageVsLimit(usage, limit float64, numberFormat, unit, unlimited string) string {
+	if limit <= 0 {
+		return fmt.Sprintf(numberFormat+" %s (%s)", usage, unit, unlimited)
+	}
+	return fmt.Sprintf(numberFormat+" / "+numberFormat+" %s (%.0f%%)", usage, limit, unit, usage/limit*100)
+}
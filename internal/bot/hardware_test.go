@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestParseHardwareRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantCPU    string
+		wantMemory string
+		wantErr    string
+	}{
+		{name: "documented format", input: "cpu=1.5, memory=512Mi", wantCPU: "1.5", wantMemory: "512Mi"},
+		{name: "semicolon and reversed order", input: "memory=2Gi;cpu=2", wantCPU: "2", wantMemory: "2Gi"},
+		{name: "newlines and mem alias", input: "CPU=0.5\nmem=1G", wantCPU: "0.5", wantMemory: "1G"},
+		{name: "plain bytes", input: "cpu=1 memory=1048576", wantCPU: "1", wantMemory: "1048576"},
+		{name: "missing memory", input: "cpu=1", wantErr: "both cpu and memory are required"},
+		{name: "missing cpu", input: "memory=512Mi", wantErr: "both cpu and memory are required"},
+		{name: "empty", input: "", wantErr: "both cpu and memory are required"},
+		{name: "negative cpu", input: "cpu=-1, memory=512Mi", wantErr: "invalid cpu"},
+		{name: "zero cpu", input: "cpu=0, memory=512Mi", wantErr: "invalid cpu"},
+		{name: "cpu NaN", input: "cpu=NaN, memory=512Mi", wantErr: "invalid cpu"},
+		{name: "cpu Inf", input: "cpu=Inf, memory=512Mi", wantErr: "invalid cpu"},
+		{name: "cpu not a number", input: "cpu=two, memory=512Mi", wantErr: "invalid cpu"},
+		{name: "negative memory", input: "cpu=1, memory=-512Mi", wantErr: "invalid memory"},
+		{name: "zero memory", input: "cpu=1, memory=0Gi", wantErr: "invalid memory"},
+		{name: "unknown unit", input: "cpu=1, memory=512MB", wantErr: "invalid memory"},
+		{name: "unknown key", input: "cpu=1, memory=512Mi, gpu=1", wantErr: "unknown key"},
+		{name: "no equals sign", input: "cpu 1, memory=512Mi", wantErr: "expected key=value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory, err := parseHardwareRequest(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseHardwareRequest(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHardwareRequest(%q) error = %v", tt.input, err)
+			}
+			if cpu != tt.wantCPU || memory != tt.wantMemory {
+				t.Errorf("parseHardwareRequest(%q) = %q, %q, want %q, %q", tt.input, cpu, memory, tt.wantCPU, tt.wantMemory)
+			}
+		})
+	}
+}
+
+func TestFormatUsageVsLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		usage, limit float64
+		numberFormat string
+		unit         string
+		want         string
+	}{
+		{name: "cpu under limit", usage: 0.45, limit: 1, numberFormat: "%.2f", unit: "cores", want: "0.45 / 1.00 cores (45%)"},
+		{name: "memory over limit", usage: 600, limit: 512, numberFormat: "%.0f", unit: "MiB", want: "600 / 512 MiB (117%)"},
+		{name: "percentage rounds", usage: 1, limit: 3, numberFormat: "%.1f", unit: "GiB", want: "1.0 / 3.0 GiB (33%)"},
+		{name: "zero limit is unlimited", usage: 0.2, limit: 0, numberFormat: "%.2f", unit: "cores", want: "0.20 cores (no limit)"},
+		{name: "negative limit is unlimited", usage: 128, limit: -1, numberFormat: "%.0f", unit: "MiB", want: "128 MiB (no limit)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUsageVsLimit(tt.usage, tt.limit, tt.numberFormat, tt.unit, "no limit"); got != tt.want {
+				t.Errorf("formatUsageVsLimit = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResourceUsageUnlimitedLabel checks that an unlimited container is labelled
+// in the viewer's language.
+func TestResourceUsageUnlimitedLabel(t *testing.T) {
+	details := &models.ResourceDetails{
+		Status:    "Running",
+		Resources: []models.ContainerResources{{Name: "app", CpuUsage: 250, MemoryUsage: 64 << 20}},
+	}
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"ru", "0.25 cores (без лимита)"},
+		{"en", "0.25 cores (no limit)"},
+	}
+	for _, tt := range tests {
+		var sb strings.Builder
+		newLocalizedBot("ru").writeResourceDetails(&sb, tt.lang, "pod", details)
+		if !strings.Contains(sb.String(), tt.want) {
+			t.Errorf("%s: details = %q, want them to contain %q", tt.lang, sb.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/i18n"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+
+	"gopkg.in/telebot.v3"
+)
+
+// testBot is a Bot backed by a real service on sqlite and the mock executor. It
+// has no Telegram client, so only handlers that reply through the context work.
+type testBot struct {
+	*Bot
+	clock    *service.FakeClock
+	repo     service.IncidentRepository
+	users    service.UserRepository
+	executor *mock.ExecutorClientMock
+	flags    *service.FeatureFlagService
+}
+
+func newTestBot(t *testing.T) *testBot {
+	t.Helper()
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flagRepo, err := gormrepo.NewGormFeatureFlagRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags, err := service.NewFeatureFlagService(context.Background(), flagRepo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := mock.NewExecutorClientMock()
+	clock := service.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	updates := make(chan *models.Incident, 100)
+	svc := service.NewIncidentService(repo, users, executor, nil, nil, updates, nil, nil, nil, nil, clock, testutil.DiscardLogger())
+	svc.SetFeatureFlags(flags)
+
+	b := &Bot{
+		service:             svc,
+		userRepo:            users,
+		suggester:           service.NewActionSuggester(nil),
+		flags:               flags,
+		clock:               clock,
+		userStates:          make(map[int64]*userState),
+		viewRegistry:        make(map[uint]map[string]telebot.Editable),
+		destructiveActions:  make(map[string]bool),
+		maxReplicas:         defaultMaxReplicas,
+		pendingActions:      newPendingActionStore(),
+		callbackDedupe:      newCallbackDeduper(callbackDedupeTTL),
+		editRetries:         newEditRetryQueue(maxPendingEditRetries),
+		adminIDs:            make(map[int64]bool),
+		ignoreNextUpdateFor: make(map[uint]bool),
+		tr:                  i18n.NewTranslator(),
+		channelLanguage:     i18n.DefaultLanguage,
+		callbackTokens:      newCallbackTokenStore(time.Hour, 100),
+		logger:              testutil.DiscardLogger(),
+	}
+	return &testBot{Bot: b, clock: clock, repo: repo, users: users, executor: executor, flags: flags}
+}
+
+// user registers a Telegram user with the given language.
+func (tb *testBot) user(t *testing.T, telegramID int64, lang string) *models.User {
+	t.Helper()
+	ctx := context.Background()
+	user, err := tb.users.FindOrCreateByTelegramID(ctx, telegramID, fmt.Sprintf("user%d", telegramID), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang != "" {
+		if err := tb.users.SetLanguage(ctx, user.ID, lang); err != nil {
+			t.Fatal(err)
+		}
+		user.Language = lang
+	}
+	return user
+}
+
+// incident stores an active incident with the given labels and resources.
+func (tb *testBot) incident(t *testing.T, labels, resources models.JSONBMap) *models.Incident {
+	t.Helper()
+	incident := &models.Incident{
+		Fingerprint:       fmt.Sprintf("fp-%d", time.Now().UnixNano()),
+		Status:            models.StatusActive,
+		Labels:            labels,
+		AffectedResources: resources,
+		StartsAt:          time.Now(),
+	}
+	if err := tb.repo.Create(context.Background(), incident); err != nil {
+		t.Fatal(err)
+	}
+	return incident
+}
+
+// payload returns the handler payload of a button: the data without the version
+// marker, with a long-data token resolved.
+func (tb *testBot) payload(t *testing.T, button telebot.InlineButton) string {
+	t.Helper()
+	_, payload := decodeCallbackVersion(button.Data)
+	data, ok := tb.resolveCallbackData(payload)
+	if !ok {
+		t.Fatalf("button %q refers to an unknown token", button.Text)
+	}
+	return data
+}
+
+// fakeContext is a telebot.Context that records replies instead of calling the
+// Telegram API. Methods a test does not expect panic through the nil embedded
+// Context.
+type fakeContext struct {
+	telebot.Context
+
+	data      string
+	args      []string
+	sender    *telebot.User
+	chat      *telebot.Chat
+	message   *telebot.Message
+	callback  *telebot.Callback
+	store     map[string]interface{}
+	responses []*telebot.CallbackResponse
+	edits     []string
+	markups   []*telebot.ReplyMarkup
+	sent      []string
+}
+
+// newCallbackContext returns a callback update with data from user, as if
+// authMiddleware had authenticated it.
+func newCallbackContext(data string, user *models.User) *fakeContext {
+	c := &fakeContext{
+		data:     data,
+		sender:   &telebot.User{ID: user.TelegramID},
+		chat:     &telebot.Chat{ID: -100},
+		message:  &telebot.Message{ID: 1, Chat: &telebot.Chat{ID: -100}},
+		callback: &telebot.Callback{Data: data},
+		store:    make(map[string]interface{}),
+	}
+	c.store["ctx"] = context.WithValue(context.Background(), "user", user)
+	return c
+}
+
+func (c *fakeContext) Data() string                  { return c.data }
+func (c *fakeContext) Args() []string                { return c.args }
+func (c *fakeContext) Sender() *telebot.User         { return c.sender }
+func (c *fakeContext) Chat() *telebot.Chat           { return c.chat }
+func (c *fakeContext) Message() *telebot.Message     { return c.message }
+func (c *fakeContext) Callback() *telebot.Callback   { return c.callback }
+func (c *fakeContext) Get(key string) interface{}    { return c.store[key] }
+func (c *fakeContext) Set(key string, v interface{}) { c.store[key] = v }
+
+func (c *fakeContext) Respond(resp ...*telebot.CallbackResponse) error {
+	if len(resp) == 0 {
+		resp = []*telebot.CallbackResponse{{}}
+	}
+	c.responses = append(c.responses, resp[0])
+	return nil
+}
+
+func (c *fakeContext) Edit(what interface{}, opts ...interface{}) error {
+	c.edits = append(c.edits, fmt.Sprint(what))
+	var markup *telebot.ReplyMarkup
+	for _, opt := range opts {
+		if m, ok := opt.(*telebot.ReplyMarkup); ok {
+			markup = m
+		}
+	}
+	c.markups = append(c.markups, markup)
+	return nil
+}
+
+func (c *fakeContext) EditOrSend(what interface{}, opts ...interface{}) error {
+	return c.Edit(what, opts...)
+}
+
+func (c *fakeContext) Send(what interface{}, opts ...interface{}) error {
+	c.sent = append(c.sent, fmt.Sprint(what))
+	return nil
+}
+
+func (c *fakeContext) Delete() error { return nil }
+
+// lastMarkup returns the keyboard of the last edit, or nil if none.
+func (c *fakeContext) lastMarkup() *telebot.ReplyMarkup {
+	if len(c.markups) == 0 {
+		return nil
+	}
+	return c.markups[len(c.markups)-1]
+}
+
+// lastResponse returns the text of the last callback answer, or "" if none.
+func (c *fakeContext) lastResponse() string {
+	if len(c.responses) == 0 {
+		return ""
+	}
+	return c.responses[len(c.responses)-1].Text
+}
+
+// fakeTelegram is a Bot API server that records the calls made to it. Queued
+// replies are returned first; after that every call succeeds with a message.
+type fakeTelegram struct {
+	mu      sync.Mutex
+	calls   []telegramCall
+	replies []string
+}
+
+type telegramCall struct {
+	method string
+	params map[string]interface{}
+}
+
+const okMessageReply = `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":-100}}}`
+
+// withTelegram points the bot at a fake Bot API server.
+func (tb *testBot) withTelegram(t *testing.T) *fakeTelegram {
+	t.Helper()
+	api := &fakeTelegram{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&params)
+		api.mu.Lock()
+		api.calls = append(api.calls, telegramCall{method: r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], params: params})
+		reply := okMessageReply
+		if len(api.replies) > 0 {
+			reply, api.replies = api.replies[0], api.replies[1:]
+		}
+		api.mu.Unlock()
+		fmt.Fprint(w, reply)
+	}))
+	t.Cleanup(srv.Close)
+
+	bot, err := telebot.NewBot(telebot.Settings{URL: srv.URL, Token: "test", Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb.bot = bot
+	tb.limiter = newOutboundLimiter(1000)
+	return api
+}
+
+// reply queues a raw Bot API response for the next call.
+func (api *fakeTelegram) reply(body string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.replies = append(api.replies, body)
+}
+
+// count returns the number of calls to method.
+func (api *fakeTelegram) count(method string) int {
+	return len(api.texts(method))
+}
+
+// texts returns the text of every call to method, in order.
+func (api *fakeTelegram) texts(method string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	var texts []string
+	for _, call := range api.calls {
+		if call.method == method {
+			text, _ := call.params["text"].(string)
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
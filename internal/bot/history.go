@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	fullHistoryPrefix = "fh:"
+	// maxVisibleHistoryEntries caps the audit entries rendered in the incident
+	// message; the full log is available as a document.
+	maxVisibleHistoryEntries = 20
+)
+
+// incidentHistory renders the most recent audit entries that fit into a message
+// whose other content is headerLen runes long. It reports whether older entries
+// were left out.
+func (b *Bot) incidentHistory(incident *models.Incident, headerLen int) (string, bool) {
+	total := len(incident.AuditLog)
+	note := func(shown int) string {
+		return b.tr.T(b.channelLanguage, "history.truncated", shown, total)
+	}
+	// Reserve room for the truncation note so adding it never overflows.
+	budget := maxMessageLength - headerLen - utf8.RuneCountInString(note(total))
+
+	var entries []string
+	used := 0
+	for i := total - 1; i >= 0 && len(entries) < maxVisibleHistoryEntries; i-- {
+		entry := b.formatAuditEntry(incident.AuditLog[i])
+		length := utf8.RuneCountInString(entry)
+		if used+length > budget {
+			break
+		}
+		entries = append(entries, entry)
+		used += length
+	}
+
+	var builder strings.Builder
+	truncated := len(entries) < total
+	if truncated {
+		builder.WriteString(note(len(entries)))
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		builder.WriteString(entries[i])
+	}
+	return builder.String(), truncated
+}
+
+// historyTruncated reports whether the visible history of the incident message
+// omits older entries.
+func (b *Bot) historyTruncated(incident *models.Incident) bool {
+	header := b.renderIncidentTemplate(incident) + b.tr.T(b.channelLanguage, "history.header")
+	_, truncated := b.incidentHistory(incident, utf8.RuneCountInString(header))
+	return truncated
+}
+
+// historyRow returns the history toggle for the given view, followed by a button
+// sending the full log when the visible history is truncated.
+func (b *Bot) historyRow(incident *models.Incident, historyVisible bool, view string) []telebot.InlineButton {
+	text := b.tr.T(b.channelLanguage, "history.show")
+	if historyVisible {
+		text = b.tr.T(b.channelLanguage, "history.hide")
+	}
+	row := []telebot.InlineButton{
+		{Text: text, Data: b.callbackData(fmt.Sprintf("%s%d:%t:%s", toggleHistoryPrefix, incident.ID, !historyVisible, view))},
+	}
+	if historyVisible && b.historyTruncated(incident) {
+		row = append(row, telebot.InlineButton{Text: b.tr.T(b.channelLanguage, "history.show_all"), Data: b.callbackData(fmt.Sprintf("%s%d", fullHistoryPrefix, incident.ID))})
+	}
+	return row
+}
+
+// handleFullHistory sends the incident's complete audit log as a text document.
+func (b *Bot) handleFullHistory(c telebot.Context, incidentID uint) error {
+	ctx := requestContext(c)
+	incident, err := b.service.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
+	}
+
+	records := append([]models.AuditRecord(nil), incident.AuditLog...)
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+	var builder strings.Builder
+	for _, entry := range records {
+		builder.WriteString(plainAuditEntry(entry))
+	}
+
+	sendOpts, err := b.getSendOptionsForIncident(ctx, incidentID)
+	if err != nil {
+		b.logger.Warn("Could not get send options", "incident_id", incidentID, "error", err)
+		sendOpts = &telebot.SendOptions{}
+	}
+	doc := &telebot.Document{
+		File:     telebot.FromReader(strings.NewReader(builder.String())),
+		FileName: fmt.Sprintf("audit-%d.txt", incident.ID),
+		Caption:  b.t(c, "history.document_caption", incident.ID, len(records)),
+	}
+	if _, err := b.bot.Send(c.Chat(), doc, sendOpts); err != nil {
+		b.logger.ErrorContext(ctx, "Failed to send audit log document", "incident_id", incidentID, "error", err)
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "history.send_failed")})
+	}
+	return c.Respond()
+}
+
+// plainAuditEntry renders one audit record without markup for the history document.
+func plainAuditEntry(entry models.AuditRecord) string {
+	line := fmt.Sprintf("%s  %s by %s - %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Action, entry.User.Username, entry.Result)
+	if entry.Action == commentAction {
+		line = fmt.Sprintf("%s  %s: %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"), entry.User.Username, entry.Result)
+	}
+	keys := make([]string, 0, len(entry.Parameters))
+	for key := range entry.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		line += fmt.Sprintf("    %s: %s\n", key, entry.Parameters[key])
+	}
+	return line
+}
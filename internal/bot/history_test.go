@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"chatops-bot/internal/models"
+)
+
+func syntheticAuditLog(n int) []models.AuditRecord {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	records := make([]models.AuditRecord, n)
+	for i := range records {
+		records[i] = models.AuditRecord{
+			Action:    string(models.ActionScaleDeployment),
+			Result:    fmt.Sprintf("entry-%03d %s", i, strings.Repeat("r", 250)),
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+			User:      models.User{Username: "oncall"},
+			Parameters: models.JSONBMap{
+				"replicas": "3",
+			},
+		}
+	}
+	return records
+}
+
+// TestIncidentMessageWithLongHistory checks that a long audit log is cut to the
+// newest entries that fit, so the incident message stays within Telegram's limit.
+func TestIncidentMessageWithLongHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   int
+		truncated bool
+	}{
+		{name: "short log", entries: 3},
+		{name: "more entries than shown", entries: maxVisibleHistoryEntries + 5, truncated: true},
+		{name: "long log", entries: 500, truncated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newLocalizedBot("en")
+			incident := &models.Incident{ID: 7, Status: models.StatusActive, AuditLog: syntheticAuditLog(tt.entries)}
+
+			message := b.formatIncidentMessage(incident, true)
+			if n := utf8.RuneCountInString(message); n > maxMessageLength {
+				t.Fatalf("message is %d runes, want at most %d", n, maxMessageLength)
+			}
+			newest := fmt.Sprintf("entry\\-%03d", tt.entries-1)
+			if !strings.Contains(message, newest) {
+				t.Errorf("message does not contain the newest entry %s", newest)
+			}
+			if got := b.historyTruncated(incident); got != tt.truncated {
+				t.Errorf("historyTruncated = %t, want %t", got, tt.truncated)
+			}
+			note := fmt.Sprintf("of %d entries_", tt.entries)
+			if got := strings.Contains(message, note); got != tt.truncated {
+				t.Errorf("truncation note present = %t, want %t", got, tt.truncated)
+			}
+			if tt.truncated && strings.Contains(message, "entry\\-000") {
+				t.Error("message contains the oldest entry of a truncated log")
+			}
+		})
+	}
+}
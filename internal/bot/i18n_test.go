@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"chatops-bot/internal/i18n"
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+func newLocalizedBot(channelLanguage string) *Bot {
+	return &Bot{
+		tr:              i18n.NewTranslator(),
+		channelLanguage: channelLanguage,
+		callbackTokens:  newCallbackTokenStore(time.Hour, 10),
+	}
+}
+
+func buttonTexts(keyboard [][]telebot.InlineButton) []string {
+	var texts []string
+	for _, row := range keyboard {
+		for _, button := range row {
+			texts = append(texts, button.Text)
+		}
+	}
+	return texts
+}
+
+// TestIncidentKeyboardsFollowLanguage checks that the incident buttons come from the
+// catalog, so an English channel gets no Russian button.
+func TestIncidentKeyboardsFollowLanguage(t *testing.T) {
+	incident := &models.Incident{
+		ID:       7,
+		Status:   models.StatusActive,
+		AuditLog: []models.AuditRecord{{Action: "create"}},
+	}
+
+	tests := []struct {
+		name     string
+		keyboard func(b *Bot) [][]telebot.InlineButton
+		want     []string
+	}{
+		{
+			name:     "incident view",
+			keyboard: func(b *Bot) [][]telebot.InlineButton { return b.buildIncidentViewKeyboard(incident, false) },
+			want:     []string{"🙋 Take it", "✅ Close incident", "▶️ Run actions", "🔕 Snooze", "💬 Add a comment", "⚠️ Change severity", "📖 Show history"},
+		},
+		{
+			name:     "actions view",
+			keyboard: func(b *Bot) [][]telebot.InlineButton { return b.buildActionsViewKeyboard(incident, nil, true, false) },
+			want:     []string{"⬅️ Back", "✅ Close incident", "📖 Hide history"},
+		},
+		{
+			name:     "close options",
+			keyboard: func(b *Bot) [][]telebot.InlineButton { return b.buildCloseOptionsKeyboard("en", incident.ID) },
+			want:     []string{"Resolved", "Rejected", "⬅️ Back"},
+		},
+		{
+			name: "pod resource view",
+			keyboard: func(b *Bot) [][]telebot.InlineButton {
+				return b.buildResourceActionsKeyboard("en", incident, "pod", "api-0", nil, nil, false)
+			},
+			want: []string{"⚙️ Allocate resources", "Containers", "📖 Describe", "⬅️ Back", "🏠 To the incident", "✅ Close incident"},
+		},
+		{
+			name: "paused deployment view",
+			keyboard: func(b *Bot) [][]telebot.InlineButton {
+				return b.buildResourceActionsKeyboard("en", incident, "deployment", "api", nil, &models.ResourceDetails{Paused: true}, false)
+			},
+			want: []string{"↔️ Scale", "📖 Describe", "⏪ Roll back", "▶️ Resume rollout", "⬅️ Back", "🏠 To the incident", "✅ Close incident"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buttonTexts(tt.keyboard(newLocalizedBot("en")))
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("buttons = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAuditEntryFollowsChannelLanguage(t *testing.T) {
+	entry := models.AuditRecord{
+		Action:     string(models.ActionScaleDeployment),
+		Result:     "ok",
+		Timestamp:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Parameters: models.JSONBMap{"replicas": "3"},
+	}
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"ru", "  *Реплики:* `3`\n"},
+		{"en", "  *Replicas:* `3`\n"},
+	}
+	for _, tt := range tests {
+		if got := newLocalizedBot(tt.lang).formatAuditEntry(entry); !strings.HasSuffix(got, tt.want) {
+			t.Errorf("%s: formatAuditEntry = %q, want suffix %q", tt.lang, got, tt.want)
+		}
+	}
+}
+
+// TestShowCommandButton checks that the incident and resource views label the
+// kubectl button with the same catalog message.
+func TestShowCommandButton(t *testing.T) {
+	incident := &models.Incident{ID: 7, Status: models.StatusResolved}
+	actions := []models.SuggestedAction{{
+		Action:        string(models.ActionRestartDeployment),
+		HumanReadable: "restart",
+		Parameters:    map[string]string{"deployment": "api", "namespace": "prod"},
+	}}
+	b := newLocalizedBot("en")
+
+	keyboards := map[string][][]telebot.InlineButton{
+		"actions view":  b.buildActionsViewKeyboard(incident, actions, false, false),
+		"resource view": b.buildResourceActionsKeyboard("en", incident, "node", "worker-1", actions, nil, false),
+	}
+	for name, keyboard := range keyboards {
+		found := false
+		for _, text := range buttonTexts(keyboard) {
+			found = found || text == "📋 Show command"
+		}
+		if !found {
+			t.Errorf("%s: buttons = %q, want %q", name, buttonTexts(keyboard), "📋 Show command")
+		}
+	}
+}
+
+// TestNoHardcodedRussianStrings guards the catalog: user-facing text belongs in
+// internal/i18n, so no string literal in the bot sources may contain Cyrillic.
+func TestNoHardcodedRussianStrings(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				lit, ok := n.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				if strings.ContainsFunc(lit.Value, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) {
+					t.Errorf("%s: hardcoded Russian string %s, move it to the i18n catalog", fset.Position(lit.Pos()), lit.Value)
+				}
+				return true
+			})
+		}
+	}
+}
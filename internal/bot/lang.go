@@ -0,0 +1,47 @@
+package bot
+
+import (
+	"strings"
+
+	"chatops-bot/internal/i18n"
+
+	"gopkg.in/telebot.v3"
+)
+
+// lang returns the language for replies to the current user: the saved preference
+// if any, otherwise the language reported by the Telegram client.
+func (b *Bot) lang(c telebot.Context) string {
+	if _, user, ok := ctxFromContext(c); ok && user.Language != "" {
+		return user.Language
+	}
+	if sender := c.Sender(); sender != nil && b.tr.Supports(sender.LanguageCode) {
+		return i18n.Normalize(sender.LanguageCode)
+	}
+	return i18n.DefaultLanguage
+}
+
+func (b *Bot) t(c telebot.Context, key string, args ...interface{}) string {
+	return b.tr.T(b.lang(c), key, args...)
+}
+
+func (b *Bot) handleLang(c telebot.Context) error {
+	available := strings.Join(b.tr.Languages(), ", ")
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send(b.t(c, "lang.current", b.lang(c), available))
+	}
+
+	lang := i18n.Normalize(args[0])
+	if !b.tr.Supports(lang) {
+		return c.Send(b.t(c, "lang.unsupported", args[0], available))
+	}
+
+	ctx := requestContext(c)
+	user := requestUser(c)
+	if err := b.userRepo.SetLanguage(ctx, user.ID, lang); err != nil {
+		b.logger.ErrorContext(ctx, "Failed to save language", "telegram_id", user.TelegramID, "error", err)
+		return c.Send(b.t(c, "lang.failed"))
+	}
+	user.Language = lang
+	return c.Send(b.t(c, "lang.set"))
+}
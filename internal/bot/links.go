@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"net/url"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// incidentLinks are the alert annotations shown as URL buttons on incident
+// messages, with the catalog keys of their button texts.
+var incidentLinks = []struct {
+	annotation string
+	textKey    string
+}{
+	{"runbook_url", "links.runbook"},
+	{"dashboard_url", "links.dashboard"},
+}
+
+// incidentLinkRow returns URL buttons for the alert's Prometheus expression and
+// the incident's runbook and dashboard annotations, or nil if it has none. Links
+// that are not absolute http(s) URLs are skipped: Telegram rejects the whole
+// keyboard because of one bad button.
+func (b *Bot) incidentLinkRow(incident *models.Incident) []telebot.InlineButton {
+	var row []telebot.InlineButton
+	if isButtonURL(incident.GeneratorURL) {
+		row = append(row, telebot.InlineButton{Text: b.tr.T(b.channelLanguage, "links.prometheus"), URL: incident.GeneratorURL})
+	}
+	for _, link := range incidentLinks {
+		if raw := incident.Annotations[link.annotation]; isButtonURL(raw) {
+			row = append(row, telebot.InlineButton{Text: b.tr.T(b.channelLanguage, link.textKey), URL: raw})
+		}
+	}
+	return row
+}
+
+func isButtonURL(raw string) bool {
+	if raw == "" {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// linkButtons returns the URL buttons of a keyboard by their text.
+func linkButtons(keyboard [][]telebot.InlineButton) map[string]string {
+	links := make(map[string]string)
+	for _, row := range keyboard {
+		for _, button := range row {
+			if button.URL != "" {
+				links[button.Text] = button.URL
+			}
+		}
+	}
+	return links
+}
+
+// firedIncident creates an incident from an alert and reloads it from the
+// database, as the bot sees it after a restart.
+func (tb *testBot) firedIncident(t *testing.T, alert models.Alert) *models.Incident {
+	t.Helper()
+	ctx := context.Background()
+	alert.Status = "firing"
+	if alert.Fingerprint == "" {
+		alert.Fingerprint = "fp-links"
+	}
+	alert.Labels = map[string]string{"alertname": "HighCPU"}
+	created, err := tb.service.CreateIncidentFromAlert(ctx, alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	incident, err := tb.repo.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return incident
+}
+
+func TestRunbookButtons(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations models.Annotations
+		want        map[string]string
+	}{
+		{name: "no annotations", want: map[string]string{}},
+		{
+			name:        "runbook",
+			annotations: models.Annotations{"runbook_url": "https://wiki.example.com/runbooks/cpu"},
+			want:        map[string]string{"📘 Runbook": "https://wiki.example.com/runbooks/cpu"},
+		},
+		{
+			name: "runbook and dashboard",
+			annotations: models.Annotations{
+				"runbook_url":   "https://wiki.example.com/runbooks/cpu",
+				"dashboard_url": "http://grafana.local/d/abc",
+			},
+			want: map[string]string{
+				"📘 Runbook": "https://wiki.example.com/runbooks/cpu",
+				"📊 Дашборд": "http://grafana.local/d/abc",
+			},
+		},
+		{
+			name:        "malformed links are skipped",
+			annotations: models.Annotations{"runbook_url": "wiki/runbooks/cpu", "dashboard_url": "javascript:alert(1)"},
+			want:        map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestBot(t)
+			tb.severityPolicy = newSeverityPolicy(config.TelegramConfig{})
+			incident := tb.firedIncident(t, models.Alert{Annotations: tt.annotations})
+
+			got := linkButtons(tb.buildIncidentViewKeyboard(incident, false))
+			if len(got) != len(tt.want) {
+				t.Fatalf("link buttons = %v, want %v", got, tt.want)
+			}
+			for text, url := range tt.want {
+				if got[text] != url {
+					t.Errorf("button %q = %q, want %q", text, got[text], url)
+				}
+			}
+		})
+	}
+}
+
+func TestIsButtonURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"https://example.com/a?b=c", true},
+		{"http://grafana:3000/d/x", true},
+		{"", false},
+		{"example.com/runbook", false},
+		{"/relative/path", false},
+		{"ftp://example.com/file", false},
+		{"https://", false},
+		{"http://bad host/", false},
+	}
+	for _, tt := range tests {
+		if got := isButtonURL(tt.raw); got != tt.want {
+			t.Errorf("isButtonURL(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestPrometheusButton(t *testing.T) {
+	const generatorURL = "http://prometheus:9090/graph?g0.expr=up+%3D%3D+0"
+	tests := []struct {
+		name         string
+		generatorURL string
+		want         bool
+	}{
+		{name: "with generator URL", generatorURL: generatorURL, want: true},
+		{name: "without generator URL"},
+		{name: "invalid generator URL", generatorURL: "prometheus:9090/graph"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestBot(t)
+			tb.severityPolicy = newSeverityPolicy(config.TelegramConfig{})
+			incident := tb.firedIncident(t, models.Alert{GeneratorURL: tt.generatorURL})
+			if incident.GeneratorURL != tt.generatorURL {
+				t.Fatalf("stored generator URL = %q, want %q", incident.GeneratorURL, tt.generatorURL)
+			}
+
+			active := linkButtons(tb.buildIncidentViewKeyboard(incident, false))
+			if url, ok := active["🔗 Открыть в Prometheus"]; ok != tt.want || (ok && url != tt.generatorURL) {
+				t.Errorf("active view links = %v, want Prometheus button: %v", active, tt.want)
+			}
+
+			user := tb.user(t, 1, "")
+			if _, err := tb.service.BulkResolve(context.Background(), user.ID, map[string]string{"alertname": "HighCPU"}); err != nil {
+				t.Fatal(err)
+			}
+			closed, err := tb.repo.FindByID(context.Background(), incident.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if closed.Status == models.StatusActive {
+				t.Fatal("incident was not closed")
+			}
+			links := linkButtons(tb.buildClosedIncidentViewKeyboard(closed, false))
+			if url, ok := links["🔗 Открыть в Prometheus"]; ok != tt.want || (ok && url != tt.generatorURL) {
+				t.Errorf("closed view links = %v, want Prometheus button: %v", links, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+// TestEscalationBannerCountsFromReopen checks that the escalation banner reports
+// how long the incident has been active since it was last reopened, by the
+// service clock.
+func TestEscalationBannerCountsFromReopen(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	tb.escalationChannelID = -300
+	now := tb.clock.Now()
+
+	incident := &models.Incident{
+		Status:          models.StatusActive,
+		Summary:         "disk full",
+		EscalationLevel: 1,
+		ActiveSince:     now.Add(-30 * time.Minute),
+	}
+	incident.ID = 7
+	incident.CreatedAt = now.Add(-48 * time.Hour)
+
+	escalations := make(chan *models.Incident, 1)
+	escalations <- incident
+	close(escalations)
+	tb.startEscalationListener(escalations)
+
+	sent := api.texts("sendMessage")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	if want := "НЕ ПРИНЯТ за 30 минут"; !strings.Contains(sent[0], want) {
+		t.Errorf("escalation = %q, want it to contain %q", sent[0], want)
+	}
+}
+
+// TestReminderCountsFromReopen checks that a reminder about a reopened incident
+// reports the time since the reopen, not since the incident was first created.
+func TestReminderCountsFromReopen(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	ctx := context.Background()
+	now := tb.clock.Now()
+
+	incident := &models.Incident{
+		Fingerprint: "fp-reminder",
+		Status:      models.StatusActive,
+		StartsAt:    now.Add(-48 * time.Hour),
+		ActiveSince: now.Add(-45 * time.Minute),
+	}
+	if err := tb.repo.Create(ctx, incident); err != nil {
+		t.Fatal(err)
+	}
+	if err := tb.service.SetTelegramMessageID(ctx, incident.ID, -100, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	reminders := make(chan *models.Incident, 1)
+	reminders <- incident
+	close(reminders)
+	tb.startReminderListener(reminders)
+
+	sent := api.texts("sendMessage")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	if want := "(45 мин.)"; !strings.Contains(sent[0], want) {
+		t.Errorf("reminder = %q, want it to contain %q", sent[0], want)
+	}
+}
@@ -0,0 +1,257 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	followPodLogsPrefix = "fpl:"
+	stopLogFollowPrefix = "sfl:"
+
+	logFollowInterval    = 5 * time.Second
+	logFollowDuration    = 2 * time.Minute
+	logFollowTail        = "30"
+	maxLogFollowSessions = 10
+)
+
+type logFollowSession struct {
+	IncidentID uint
+	cancel     context.CancelFunc
+}
+
+// logFollowStore tracks live log sessions keyed by the chat/message they edit, so a
+// session can be stopped from its own "Стоп" button or when the incident closes.
+type logFollowStore struct {
+	mu       sync.Mutex
+	sessions map[string]*logFollowSession
+}
+
+func newLogFollowStore() *logFollowStore {
+	return &logFollowStore{sessions: make(map[string]*logFollowSession)}
+}
+
+func logFollowKey(chatID int64, messageID int) string {
+	return fmt.Sprintf("%d:%d", chatID, messageID)
+}
+
+func (s *logFollowStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+func (s *logFollowStore) Add(key string, session *logFollowSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[key] = session
+}
+
+func (s *logFollowStore) Remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+}
+
+// Stop cancels the session for key and reports whether one was running.
+func (s *logFollowStore) Stop(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[key]
+	if !ok {
+		return false
+	}
+	session.cancel()
+	delete(s.sessions, key)
+	return true
+}
+
+func (s *logFollowStore) StopIncident(incidentID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, session := range s.sessions {
+		if session.IncidentID == incidentID {
+			session.cancel()
+			delete(s.sessions, key)
+		}
+	}
+}
+
+func (b *Bot) handleFollowPodLogs(c telebot.Context) error {
+	parts := strings.Split(c.Data(), ":")
+	if len(parts) < 4 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+	incidentID, _ := strconv.ParseUint(parts[1], 10, 32)
+	podName := parts[2]
+	containerName := parts[3]
+
+	if b.logFollows.Len() >= maxLogFollowSessions {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "logfollow.too_many"), ShowAlert: true})
+	}
+
+	ctx := requestContext(c)
+	incident, err := b.service.GetIncidentByID(ctx, uint(incidentID))
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_not_found")})
+	}
+	if incident.Status != models.StatusActive {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_closed")})
+	}
+
+	user := requestUser(c)
+	req := models.ActionRequest{
+		Action:     string(models.ActionGetPodLogs),
+		IncidentID: uint(incidentID),
+		UserID:     user.ID,
+		Parameters: map[string]string{
+			"pod_name":  podName,
+			"namespace": incident.Labels["namespace"],
+			"container": containerName,
+			"tail":      logFollowTail,
+		},
+	}
+
+	// Only the first fetch goes to the audit log; the polling below does not.
+	result, err := b.executeAction(c, req)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.error", err)})
+	}
+	if result.Error != "" {
+		return c.Respond(&telebot.CallbackResponse{Text: result.Error, ShowAlert: true})
+	}
+	c.Respond()
+
+	sendOpts, err := b.getSendOptionsForIncident(ctx, uint(incidentID))
+	if err != nil {
+		b.logger.Warn("Could not get send options", "incident_id", incidentID, "error", err)
+		sendOpts = &telebot.SendOptions{}
+	}
+	sendOpts.ParseMode = telebot.ModeMarkdownV2
+	// The message keeps the requester's language while it is updated in the background.
+	lang := b.lang(c)
+	sendOpts.ReplyMarkup = b.stopLogFollowMarkup(lang, uint(incidentID))
+
+	logs := logsFromResult(result)
+	msg, err := b.safeSend(c.Chat(), b.formatLogFollowMessage(lang, podName, containerName, logs, true), sendOpts)
+	if err != nil {
+		b.logger.Error("Failed to send live logs message", "incident_id", incidentID, "error", err)
+		return nil
+	}
+
+	followCtx, cancel := context.WithTimeout(context.Background(), logFollowDuration)
+	key := logFollowKey(msg.Chat.ID, msg.ID)
+	b.logFollows.Add(key, &logFollowSession{IncidentID: uint(incidentID), cancel: cancel})
+	go b.runLogFollow(followCtx, lang, key, msg, req, logs)
+	return nil
+}
+
+func (b *Bot) handleStopLogFollow(c telebot.Context) error {
+	msg := c.Message()
+	if msg == nil {
+		return c.Respond()
+	}
+	if !b.logFollows.Stop(logFollowKey(msg.Chat.ID, msg.ID)) {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "logfollow.already_stopped")})
+	}
+	return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "logfollow.stopped")})
+}
+
+// runLogFollow polls the pod logs until ctx is done and edits msg whenever they
+// change. The poll interval keeps edits well below Telegram's rate limits.
+func (b *Bot) runLogFollow(ctx context.Context, lang, key string, msg *telebot.Message, req models.ActionRequest, last string) {
+	defer b.logFollows.Remove(key)
+	podName := req.Parameters["pod_name"]
+	containerName := req.Parameters["container"]
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			b.editLogFollowMessage(req.IncidentID, msg, b.formatLogFollowMessage(lang, podName, containerName, last, false), &telebot.ReplyMarkup{})
+			return
+		case <-ticker.C:
+		}
+
+		result, err := b.service.ExecuteReadOnlyAction(ctx, req)
+		if ctx.Err() != nil {
+			continue
+		}
+		if err != nil || result.Error != "" {
+			b.logger.Warn("Live logs poll failed", "incident_id", req.IncidentID, "error", err, "result_error", result.Error)
+			continue
+		}
+		logs := logsFromResult(result)
+		if logs == last {
+			continue
+		}
+		last = logs
+		b.editLogFollowMessage(req.IncidentID, msg, b.formatLogFollowMessage(lang, podName, containerName, logs, true), b.stopLogFollowMarkup(lang, req.IncidentID))
+	}
+}
+
+func (b *Bot) editLogFollowMessage(incidentID uint, msg *telebot.Message, text string, markup *telebot.ReplyMarkup) {
+	_, err := b.safeEdit(msg, text, markup, telebot.ModeMarkdownV2)
+	if err != nil && !isBenignEditError(err) {
+		b.logger.Error("Failed to edit live logs message", "incident_id", incidentID, "message_id", msg.ID, "error", err)
+	}
+}
+
+func (b *Bot) stopLogFollowMarkup(lang string, incidentID uint) *telebot.ReplyMarkup {
+	return &telebot.ReplyMarkup{InlineKeyboard: [][]telebot.InlineButton{
+		{{Text: b.tr.T(lang, "logfollow.stop"), Data: b.callbackData(stopLogFollowPrefix + strconv.FormatUint(uint64(incidentID), 10))}},
+	}}
+}
+
+func logsFromResult(result models.ActionResult) string {
+	if result.ResultData == nil || len(result.ResultData.Items) == 0 {
+		return ""
+	}
+	return result.ResultData.Items[0].Status
+}
+
+// formatLogFollowMessage renders the latest log lines, dropping the oldest ones
+// until the message fits into a single Telegram message.
+func (b *Bot) formatLogFollowMessage(lang, podName, containerName, logs string, active bool) string {
+	header := b.tr.T(lang, "logfollow.header", escapeMarkdown(podName), escapeMarkdown(containerName))
+	footer := b.tr.T(lang, "logfollow.finished")
+	if active {
+		footer = b.tr.T(lang, "logfollow.refreshing", int(logFollowInterval.Seconds()))
+	}
+
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	for {
+		body := strings.Join(lines, "\n")
+		if body == "" {
+			body = b.tr.T(lang, "logfollow.no_logs")
+		}
+		message := fmt.Sprintf("%s```\n%s\n```\n%s", header, escapeMarkdownCodeBlock(body), footer)
+		overflow := utf8.RuneCountInString(message) - maxMessageLength
+		if overflow <= 0 {
+			return message
+		}
+		if len(lines) > 1 {
+			lines = lines[1:]
+			continue
+		}
+		runes := []rune(lines[0])
+		if overflow >= len(runes) {
+			lines[0] = ""
+		} else {
+			lines[0] = string(runes[overflow:])
+		}
+		if lines[0] == "" {
+			return fmt.Sprintf("%s```\n\n```\n%s", header, footer)
+		}
+	}
+}
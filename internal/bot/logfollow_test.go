@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatLogFollowMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   string
+		logs   string
+		active bool
+		want   []string
+	}{
+		{name: "active in Russian", lang: "ru", logs: "line", active: true, want: []string{"*📄 Логи \\(live\\):* `api\\-0/app`", "line", "_Обновляется каждые 5 сек\\._"}},
+		{name: "finished in English", lang: "en", logs: "line", want: []string{"*📄 Logs \\(live\\):* `api\\-0/app`", "_The stream has finished\\._"}},
+		{name: "no logs in English", lang: "en", active: true, want: []string{"(no logs)", "_Refreshed every 5 s\\._"}},
+	}
+	b := newLocalizedBot("ru")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := b.formatLogFollowMessage(tt.lang, "api-0", "app", tt.logs, tt.active)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("message = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatLogFollowMessageDropsOldestLines(t *testing.T) {
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, strings.Repeat("x", 20))
+	}
+	lines = append(lines, "newest")
+
+	got := newLocalizedBot("en").formatLogFollowMessage("en", "api-0", "app", strings.Join(lines, "\n"), true)
+	if n := utf8.RuneCountInString(got); n > maxMessageLength {
+		t.Errorf("message is %d runes, want at most %d", n, maxMessageLength)
+	}
+	if !strings.Contains(got, "newest") {
+		t.Error("the newest line was dropped")
+	}
+}
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestLogTailSelection(t *testing.T) {
+	tb := newTestBot(t)
+	tb.withTelegram(t)
+	tb.logTailLines = 200
+	user := tb.user(t, 42, "en")
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X", "namespace": "prod"}, models.JSONBMap{"pod": "api-0"})
+	logs := fmt.Sprintf("%s%d:api-0:app", getPodLogsPrefix, incident.ID)
+
+	tests := []struct {
+		name         string
+		data         string
+		wantTail     string
+		wantPrevious bool
+	}{
+		{"configured default", logs, "200", false},
+		{"selected size", logs + ":cur:500", "500", false},
+		{"previous container", logs + ":prev:1000", "1000", true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCallbackContext(tt.data, user)
+			// Every press is a separate callback, not a replay of the previous one.
+			c.callback.ID = fmt.Sprint(i)
+			if err := tb.handleGetPodLogs(c); err != nil {
+				t.Fatal(err)
+			}
+			call, ok := tb.executor.LastCall()
+			if !ok || call.Action != string(models.ActionGetPodLogs) {
+				t.Fatalf("last executor call = %+v, %v, want get_pod_logs", call, ok)
+			}
+			if call.Parameters["tail"] != tt.wantTail || call.Parameters["container"] != "app" || call.Parameters["namespace"] != "prod" {
+				t.Errorf("parameters = %v, want tail %s for app in prod", call.Parameters, tt.wantTail)
+			}
+			if previous := call.Parameters["previous"] == "true"; previous != tt.wantPrevious {
+				t.Errorf("previous = %v, want %v", previous, tt.wantPrevious)
+			}
+		})
+	}
+
+	for _, size := range []string{"0", "-5", "abc"} {
+		calls := len(tb.executor.Calls())
+		c := newCallbackContext(logs+":cur:"+size, user)
+		if err := tb.handleGetPodLogs(c); err != nil {
+			t.Fatal(err)
+		}
+		if got := c.lastResponse(); got != "Invalid log size." {
+			t.Errorf("size %q: response = %q, want the invalid size message", size, got)
+		}
+		if len(tb.executor.Calls()) != calls {
+			t.Errorf("size %q reached the executor", size)
+		}
+	}
+}
+
+func TestLogTailKeyboard(t *testing.T) {
+	tb := newTestBot(t)
+	req := models.ActionRequest{Parameters: map[string]string{"pod_name": "api-0", "container": "app", "tail": "500", "previous": "true"}}
+
+	markup := tb.logTailKeyboard(12, req)
+	if got, want := buttonTexts(markup.InlineKeyboard), []string{"50", "100", "• 500", "1000"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("buttons = %q, want %q", got, want)
+	}
+	button, _ := findButton(markup, "1000")
+	if got, want := tb.payload(t, button), getPodLogsPrefix+"12:api-0:app:prev:1000"; got != want {
+		t.Errorf("payload = %q, want %q", got, want)
+	}
+}
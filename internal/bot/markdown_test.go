@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain text", want: "plain text"},
+		{in: "api-gateway.prod", want: `api\-gateway\.prod`},
+		{in: "_*[]()~`>#+-=|{}.!", want: `\_\*\[\]\(\)\~\` + "`" + `\>\#\+\-\=\|\{\}\.\!`},
+		{in: `C:\path`, want: `C:\\path`},
+		{in: "кириллица (тест)", want: `кириллица \(тест\)`},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdown(tt.in); got != tt.want {
+			t.Errorf("escapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeMarkdownCodeBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "ERROR connection refused", want: "ERROR connection refused"},
+		{name: "backticks", in: "run `make`", want: "run \\`make\\`"},
+		{name: "fence", in: "```", want: "\\`\\`\\`"},
+		{name: "backslashes", in: `a\nb\\c`, want: `a\\nb\\\\c`},
+		{name: "backslash before backtick", in: "\\`", want: "\\\\\\`"},
+		{name: "other special characters stay", in: "a_b*c[d].e!", want: "a_b*c[d].e!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeMarkdownCodeBlock(tt.in); got != tt.want {
+				t.Errorf("escapeMarkdownCodeBlock(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEscapeRoundTrip checks that stripping the markup of escaped text gives the
+// original back, i.e. every escape is a valid MarkdownV2 escape.
+func TestEscapeRoundTrip(t *testing.T) {
+	for _, in := range []string{"_*[]()~`>#+-=|{}.!", `back\slash`, "обычный текст"} {
+		if got := stripMarkdownV2(escapeMarkdown(in)); got != in {
+			t.Errorf("stripMarkdownV2(escapeMarkdown(%q)) = %q", in, got)
+		}
+	}
+}
+
+func TestCodeBlockMessage(t *testing.T) {
+	msg, fits := codeBlockMessage("line `1`", "Логи (tail)")
+	if !fits {
+		t.Fatal("short output does not fit")
+	}
+	want := "*Логи \\(tail\\):*\n```\nline \\`1\\`\n```"
+	if msg != want {
+		t.Errorf("message = %q, want %q", msg, want)
+	}
+
+	// Fits before escaping, overflows after: every backtick doubles in length.
+	output := strings.Repeat("`", maxMessageLength-20)
+	if _, fits := codeBlockMessage(output, ""); fits {
+		t.Error("output longer than the limit after escaping reported as fitting")
+	}
+}
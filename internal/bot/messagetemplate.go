@@ -0,0 +1,167 @@
+package bot
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+// defaultIncidentTemplate renders the incident header, details and resources. The
+// action history below them is not part of the template.
+//
+//go:embed incident_message.tmpl
+var defaultIncidentTemplate string
+
+// markdownV2 is template output that is already valid MarkdownV2 and must not be
+// escaped again.
+type markdownV2 string
+
+var incidentTemplateFuncs = template.FuncMap{
+	"escape": escapeTemplateValue,
+	"raw":    func(s string) markdownV2 { return markdownV2(s) },
+	"date":   formatTemplateDate,
+}
+
+// incidentTemplateData is what message templates see: the incident itself plus a
+// few values that need the bot's config to compute.
+type incidentTemplateData struct {
+	*models.Incident
+	Severity           string
+	Snoozed            bool
+	AcknowledgedByName string
+	AssignedToName     string
+}
+
+// parseIncidentTemplate parses a message template and makes every {{ }} output
+// MarkdownV2-escaped, so label values and summaries cannot break the message.
+// Text outside actions is the template's own markup and is sent as is; {{raw .X}}
+// opts a value out of escaping.
+func parseIncidentTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(incidentTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			escapeTemplateActions(t.Tree.Root)
+		}
+	}
+	return tmpl, nil
+}
+
+// loadIncidentTemplate reads the template from path, or returns the built-in one
+// when path is empty.
+func loadIncidentTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return builtinIncidentTemplate, nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read message template: %w", err)
+	}
+	tmpl, err := parseIncidentTemplate(path, string(text))
+	if err != nil {
+		return nil, fmt.Errorf("parse message template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// escapeTemplateActions appends the escape func to the pipeline of every action
+// that prints a value.
+func escapeTemplateActions(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeTemplateActions(child)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		escape := parse.NewIdentifier("escape").SetTree(nil).SetPos(n.Pos)
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{escape}})
+	case *parse.IfNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	case *parse.RangeNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	case *parse.WithNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	}
+}
+
+func escapeTemplateValue(value any) markdownV2 {
+	if s, ok := value.(markdownV2); ok {
+		return s
+	}
+	if value == nil {
+		return ""
+	}
+	return markdownV2(escapeMarkdown(fmt.Sprint(value)))
+}
+
+func formatTemplateDate(layout string, value any) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	default:
+		return ""
+	}
+}
+
+func (b *Bot) incidentTemplateData(incident *models.Incident) incidentTemplateData {
+	data := incidentTemplateData{
+		Incident: incident,
+		Severity: "N/A",
+		Snoozed:  incident.IsSnoozed(time.Now()),
+	}
+	if s, ok := b.severityPolicy.severity(incident); ok {
+		data.Severity = s
+	}
+	if incident.AcknowledgedBy != nil && incident.AcknowledgedAt != nil {
+		data.AcknowledgedByName = userDisplayName(&incident.AcknowledgedByUser)
+	}
+	if incident.AssignedTo != nil {
+		data.AssignedToName = userDisplayName(&incident.AssignedToUser)
+	}
+	return data
+}
+
+// renderIncidentTemplate renders the configured template, falling back to the
+// built-in one if it fails (e.g. a field that does not exist).
+func (b *Bot) renderIncidentTemplate(incident *models.Incident) string {
+	data := b.incidentTemplateData(incident)
+	tmpl := b.messageTemplate
+	if tmpl == nil {
+		tmpl = builtinIncidentTemplate
+	}
+	var builder strings.Builder
+	err := tmpl.Execute(&builder, data)
+	if err == nil {
+		return builder.String()
+	}
+	b.logger.Error("Failed to render message template, using the built-in one", "incident_id", incident.ID, "error", err)
+	builder.Reset()
+	if err := builtinIncidentTemplate.Execute(&builder, data); err != nil {
+		b.logger.Error("Failed to render built-in message template", "incident_id", incident.ID, "error", err)
+	}
+	return builder.String()
+}
+
+var builtinIncidentTemplate = template.Must(parseIncidentTemplate("incident", defaultIncidentTemplate))
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+func templateIncident() *models.Incident {
+	incident := &models.Incident{
+		Status:            models.StatusActive,
+		Summary:           "CPU > 90% (node-1)",
+		Description:       "load_avg is high",
+		Labels:            models.JSONBMap{"alertname": "HighCPU", "severity": "critical", "namespace": "prod-eu"},
+		AffectedResources: models.JSONBMap{"deployment": "api.v2", "pod": "api-7f_x"},
+		StartsAt:          time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		OccurrenceCount:   3,
+	}
+	incident.ID = 12
+	return incident
+}
+
+func templateBot(t *testing.T, text string) *testBot {
+	t.Helper()
+	tb := newTestBot(t)
+	tb.severityPolicy = newSeverityPolicy(config.TelegramConfig{})
+	if text != "" {
+		tmpl, err := parseIncidentTemplate("test", text)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		tb.messageTemplate = tmpl
+	}
+	return tb
+}
+
+func TestRenderIncidentTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "values are escaped, markup is not",
+			text: `*{{.Summary}}* \| {{.Severity}}`,
+			want: `*CPU \> 90% \(node\-1\)* \| critical`,
+		},
+		{
+			name: "label lookup",
+			text: "ns: `{{index .Labels \"namespace\"}}`",
+			want: "ns: `prod\\-eu`",
+		},
+		{
+			name: "range and with bodies are escaped",
+			text: `{{with index .AffectedResources "deployment"}}{{.}}{{end}};{{range $k, $v := .AffectedResources}} {{$k}}={{$v}}{{end}}`,
+			want: `api\.v2; deployment=api\.v2 pod=api\-7f\_x`,
+		},
+		{
+			name: "raw opts out of escaping",
+			text: `{{raw "*bold*"}} {{"*bold*"}}`,
+			want: `*bold* \*bold\*`,
+		},
+		{
+			name: "date helper",
+			text: `{{date "02.01 15:04" .StartsAt}}`,
+			want: `01\.05 10:30`,
+		},
+		{
+			name: "missing label is empty",
+			text: `[{{index .Labels "team"}}]`,
+			want: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := templateBot(t, tt.text)
+			if got := tb.renderIncidentTemplate(templateIncident()); got != tt.want {
+				t.Errorf("render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderIncidentTemplateFallsBackToBuiltin(t *testing.T) {
+	builtin := templateBot(t, "").renderIncidentTemplate(templateIncident())
+
+	tb := templateBot(t, `{{.NoSuchField}}`)
+	if got := tb.renderIncidentTemplate(templateIncident()); got != builtin {
+		t.Errorf("render = %q, want the built-in output %q", got, builtin)
+	}
+}
+
+func TestBuiltinIncidentTemplate(t *testing.T) {
+	got := templateBot(t, "").renderIncidentTemplate(templateIncident())
+	for _, want := range []string{
+		`🚨 *HighCPU: CPU \> 90% \(node\-1\)* 🚨`,
+		"*Серьезность:* `critical`",
+		"∙ *Namespace:* `prod\\-eu`",
+		"∙ *Повторений:* `3`",
+		"∙ *Deployment:* `api\\.v2`",
+		"∙ *Pod:* `api\\-7f\\_x`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("built-in output is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Node:") {
+		t.Errorf("built-in output lists a node the incident does not have:\n%s", got)
+	}
+}
+
+func TestLoadIncidentTemplate(t *testing.T) {
+	if tmpl, err := loadIncidentTemplate(""); err != nil || tmpl != builtinIncidentTemplate {
+		t.Fatalf("empty path = %v, %v; want the built-in template", tmpl, err)
+	}
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.tmpl")
+	if err := os.WriteFile(valid, []byte(`{{.Summary}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadIncidentTemplate(valid); err != nil {
+		t.Errorf("valid template: %v", err)
+	}
+
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte(`{{.Summary`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadIncidentTemplate(broken); err == nil || !strings.Contains(err.Error(), "parse message template") {
+		t.Errorf("broken template error = %v", err)
+	}
+	if _, err := loadIncidentTemplate(filepath.Join(dir, "missing.tmpl")); err == nil || !strings.Contains(err.Error(), "read message template") {
+		t.Errorf("missing template error = %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+// handleNotify shows the user's direct-message preferences, or changes one with
+// "/notify <kind> on|off".
+func (b *Bot) handleNotify(c telebot.Context) error {
+	user := requestUser(c)
+	args := c.Args()
+	if len(args) == 2 {
+		flag, ok := notificationKind(args[0])
+		if !ok {
+			return c.Send(b.t(c, "prefs.unknown_kind", args[0]))
+		}
+		var enabled bool
+		switch strings.ToLower(args[1]) {
+		case "on", "true", "1":
+			enabled = true
+		case "off", "false", "0":
+			enabled = false
+		default:
+			return c.Send(b.t(c, "flags.invalid_value"))
+		}
+
+		ctx := requestContext(c)
+		prefs := user.NotifyPrefs.With(flag, enabled)
+		if err := b.userRepo.SetNotificationPrefs(ctx, user.ID, prefs); err != nil {
+			b.logger.ErrorContext(ctx, "Failed to save notification preferences", "telegram_id", user.TelegramID, "error", err)
+			return c.Send(b.t(c, "prefs.failed"))
+		}
+		user.NotifyPrefs = prefs
+	} else if len(args) != 0 {
+		return c.Send(b.t(c, "prefs.usage"))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(b.t(c, "prefs.header") + "\n")
+	for _, kind := range models.NotificationKinds {
+		icon := "⚪️"
+		if user.NotifyPrefs.Has(kind.Flag) {
+			icon = "🟢"
+		}
+		builder.WriteString(fmt.Sprintf("%s %s — %s\n", icon, kind.Name, b.t(c, "prefs.kind."+kind.Name)))
+	}
+	builder.WriteString("\n" + b.t(c, "prefs.usage"))
+	return c.Send(builder.String())
+}
+
+func notificationKind(name string) (models.NotificationPrefs, bool) {
+	for _, kind := range models.NotificationKinds {
+		if strings.EqualFold(kind.Name, name) {
+			return kind.Flag, true
+		}
+	}
+	return 0, false
+}
+
+// notifyUser sends a direct message to user if they opted in to the given kind.
+// The text key is translated to the user's language.
+func (b *Bot) notifyUser(user *models.User, flag models.NotificationPrefs, key string, args ...interface{}) {
+	if user == nil || user.TelegramID == 0 || !user.NotifyPrefs.Has(flag) {
+		return
+	}
+	lang := user.Language
+	if lang == "" {
+		lang = b.channelLanguage
+	}
+	if _, err := b.send(&telebot.Chat{ID: user.TelegramID}, b.tr.T(lang, key, args...)); err != nil {
+		// Telegram refuses messages to users who have not started a chat with the bot.
+		b.logger.Warn("Failed to send direct notification", "telegram_id", user.TelegramID, "error", err)
+	}
+}
+
+// notifyAssignee sends a direct message to the incident's assignee, if there is
+// one and they opted in to the given kind.
+func (b *Bot) notifyAssignee(incident *models.Incident, flag models.NotificationPrefs, key string, args ...interface{}) {
+	if incident.AssignedTo == nil {
+		return
+	}
+	assignee := &incident.AssignedToUser
+	if assignee.ID != *incident.AssignedTo {
+		var err error
+		assignee, err = b.userRepo.FindByID(context.Background(), *incident.AssignedTo)
+		if err != nil {
+			b.logger.Error("Failed to load incident assignee", "incident_id", incident.ID, "user_id", *incident.AssignedTo, "error", err)
+			return
+		}
+	}
+	b.notifyUser(assignee, flag, key, args...)
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestNotifyCommandPersistsPreferences(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "en")
+
+	steps := []struct {
+		args      []string
+		want      models.NotificationPrefs
+		wantReply string
+	}{
+		{args: []string{"assigned", "on"}, want: models.NotifyAssigned, wantReply: "🟢 assigned"},
+		{args: []string{"REMINDER", "true"}, want: models.NotifyAssigned | models.NotifyReminder, wantReply: "🟢 reminder"},
+		{args: []string{"assigned", "off"}, want: models.NotifyReminder, wantReply: "⚪️ assigned"},
+		{args: []string{"pager", "on"}, want: models.NotifyReminder, wantReply: "Unknown notification kind: pager."},
+		{args: []string{"assigned", "maybe"}, want: models.NotifyReminder},
+		{args: []string{"assigned"}, want: models.NotifyReminder, wantReply: "Usage: /notify <kind> on|off"},
+	}
+	for _, step := range steps {
+		c := newCommandContext(user, step.args...)
+		if err := tb.handleNotify(c); err != nil {
+			t.Fatalf("%v: %v", step.args, err)
+		}
+		if step.wantReply != "" && !strings.Contains(c.sent[len(c.sent)-1], step.wantReply) {
+			t.Errorf("%v: reply = %q, want it to contain %q", step.args, c.sent[len(c.sent)-1], step.wantReply)
+		}
+
+		stored, err := tb.users.FindByID(context.Background(), user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.NotifyPrefs != step.want {
+			t.Errorf("%v: stored prefs = %b, want %b", step.args, stored.NotifyPrefs, step.want)
+		}
+		user = stored
+	}
+}
+
+func TestNotifyAssigneeRespectsPreferences(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	ctx := context.Background()
+	lead := tb.user(t, 1, "en")
+	assignee := tb.user(t, 2, "en")
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X"}, nil)
+	incident, err := tb.service.Assign(ctx, lead.ID, incident.ID, assignee.TelegramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tb.notifyAssignee(incident, models.NotifyEscalation, "prefs.dm_escalation", incident.ID, 2, "disk full")
+	if n := api.count("sendMessage"); n != 0 {
+		t.Fatalf("sent %d messages to an assignee who opted out, want 0", n)
+	}
+
+	if err := tb.users.SetNotificationPrefs(ctx, assignee.ID, models.NotifyEscalation); err != nil {
+		t.Fatal(err)
+	}
+	incident, err = tb.service.GetIncidentByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb.notifyAssignee(incident, models.NotifyReminder, "prefs.dm_reminder", incident.ID, 30, "disk full")
+	tb.notifyAssignee(incident, models.NotifyEscalation, "prefs.dm_escalation", incident.ID, 2, "disk full")
+
+	sent := api.texts("sendMessage")
+	want := fmt.Sprintf("⚠️ Incident #%d assigned to you escalated to level 2: disk full", incident.ID)
+	if len(sent) != 1 || sent[0] != want {
+		t.Fatalf("sent %q, want only %q", sent, want)
+	}
+	if chatID := fmt.Sprint(api.calls[0].params["chat_id"]); chatID != "2" {
+		t.Errorf("chat_id = %s, want the assignee's Telegram ID 2", chatID)
+	}
+}
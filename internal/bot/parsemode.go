@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"strings"
+
+	"gopkg.in/telebot.v3"
+)
+
+// isParseError reports whether Telegram rejected a message because its
+// MarkdownV2 markup is malformed, e.g. a character was left unescaped.
+func isParseError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "can't parse entities")
+}
+
+// stripMarkdownV2 turns MarkdownV2 text into plain text: escaped characters are
+// kept as is, formatting characters are dropped and links become "text (url)".
+func stripMarkdownV2(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				builder.WriteRune(runes[i])
+			}
+		case '*', '_', '~', '`', '|', '[':
+		case ']':
+			if i+1 < len(runes) && runes[i+1] == '(' {
+				builder.WriteString(" (")
+				i++
+			}
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// plainTextOptions returns opts without a parse mode.
+func plainTextOptions(opts []interface{}) []interface{} {
+	plain := make([]interface{}, 0, len(opts))
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case telebot.ParseMode:
+			continue
+		case *telebot.SendOptions:
+			if o != nil {
+				withoutMode := *o
+				withoutMode.ParseMode = telebot.ModeDefault
+				opt = &withoutMode
+			}
+		}
+		plain = append(plain, opt)
+	}
+	return plain
+}
+
+// withPlainTextFallback delivers text with opts and, if Telegram cannot parse its
+// markup, once more as plain text, so the content still reaches the chat.
+func (b *Bot) withPlainTextFallback(text string, opts []interface{}, deliver func(text string, opts ...interface{}) error) error {
+	err := deliver(text, opts...)
+	if !isParseError(err) {
+		return err
+	}
+	b.logger.Warn("Telegram could not parse message markup, falling back to plain text", "error", err, "text", text)
+	return deliver(stripMarkdownV2(text), plainTextOptions(opts)...)
+}
+
+// safeSend sends a MarkdownV2 message, falling back to plain text on parse errors.
+func (b *Bot) safeSend(to telebot.Recipient, text string, opts ...interface{}) (*telebot.Message, error) {
+	var msg *telebot.Message
+	err := b.withPlainTextFallback(text, opts, func(text string, opts ...interface{}) error {
+		var err error
+		msg, err = b.bot.Send(to, text, opts...)
+		return err
+	})
+	return msg, err
+}
+
+// safeEdit edits a message to MarkdownV2 text, falling back to plain text on
+// parse errors.
+func (b *Bot) safeEdit(editable telebot.Editable, text string, opts ...interface{}) (*telebot.Message, error) {
+	var msg *telebot.Message
+	err := b.withPlainTextFallback(text, opts, func(text string, opts ...interface{}) error {
+		var err error
+		msg, err = b.bot.Edit(editable, text, opts...)
+		return err
+	})
+	return msg, err
+}
+
+// sendMarkdown is c.Send for MarkdownV2 text with the plain-text fallback.
+func (b *Bot) sendMarkdown(c telebot.Context, text string, opts ...interface{}) error {
+	return b.withPlainTextFallback(text, opts, func(text string, opts ...interface{}) error {
+		return c.Send(text, opts...)
+	})
+}
+
+// editMarkdown is c.Edit for MarkdownV2 text with the plain-text fallback.
+func (b *Bot) editMarkdown(c telebot.Context, text string, opts ...interface{}) error {
+	return b.withPlainTextFallback(text, opts, func(text string, opts ...interface{}) error {
+		return c.Edit(text, opts...)
+	})
+}
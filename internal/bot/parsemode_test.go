@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/telebot.v3"
+)
+
+const parseErrorReply = `{"ok":false,"error_code":400,"description":"Bad Request: can't parse entities: Character '-' is reserved and must be escaped with the preceding '\\'"}`
+
+func TestStripMarkdownV2(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`*bold* _italic_ ~strike~`, "bold italic strike"},
+		{"`code` \\- \\*x\\*", "code - *x*"},
+		{`[runbook](https://wiki/cpu)`, "runbook (https://wiki/cpu)"},
+		{`node-1 (unescaped)`, "node-1 (unescaped)"},
+		{`trailing \`, "trailing "},
+	}
+	for _, tt := range tests {
+		if got := stripMarkdownV2(tt.in); got != tt.want {
+			t.Errorf("stripMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSafeSendFallsBackToPlainText(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	api.reply(parseErrorReply)
+
+	msg, err := tb.safeSend(&telebot.Chat{ID: -100}, "*CPU* on node-1", telebot.ModeMarkdownV2)
+	if err != nil {
+		t.Fatalf("safeSend: %v", err)
+	}
+	if msg == nil || msg.ID != 1 {
+		t.Errorf("message = %+v, want the message from the plain-text send", msg)
+	}
+	if texts := api.texts("sendMessage"); len(texts) != 2 || texts[1] != "CPU on node-1" {
+		t.Fatalf("sent texts = %q, want the markup and then %q", texts, "CPU on node-1")
+	}
+	if mode := api.calls[1].params["parse_mode"]; mode != nil && mode != "" {
+		t.Errorf("fallback parse_mode = %v, want none", mode)
+	}
+}
+
+func TestSafeEditFallsBackToPlainText(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	api.reply(parseErrorReply)
+
+	msg := &telebot.Message{ID: 7, Chat: &telebot.Chat{ID: -100}}
+	opts := &telebot.SendOptions{ParseMode: telebot.ModeMarkdownV2}
+	if _, err := tb.safeEdit(msg, "_Resolved_ node-1", opts); err != nil {
+		t.Fatalf("safeEdit: %v", err)
+	}
+	if texts := api.texts("editMessageText"); len(texts) != 2 || texts[1] != "Resolved node-1" {
+		t.Fatalf("edit texts = %q, want the markup and then %q", texts, "Resolved node-1")
+	}
+	if opts.ParseMode != telebot.ModeMarkdownV2 {
+		t.Errorf("caller options changed to parse mode %q", opts.ParseMode)
+	}
+}
+
+func TestSafeSendKeepsOtherErrors(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	api.reply(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`)
+
+	if _, err := tb.safeSend(&telebot.Chat{ID: -100}, "*CPU*", telebot.ModeMarkdownV2); err == nil {
+		t.Fatal("safeSend succeeded, want the Forbidden error")
+	}
+	if n := api.count("sendMessage"); n != 1 {
+		t.Errorf("sendMessage calls = %d, want 1", n)
+	}
+}
+
+func TestPlainTextFallbackFailsOnce(t *testing.T) {
+	tb := newTestBot(t)
+	parseErr := errors.New("telegram: Bad Request: can't parse entities (400)")
+	var texts []string
+	err := tb.withPlainTextFallback("*x*", nil, func(text string, opts ...interface{}) error {
+		texts = append(texts, text)
+		return parseErr
+	})
+	if !errors.Is(err, parseErr) {
+		t.Errorf("error = %v, want the parse error", err)
+	}
+	if len(texts) != 2 {
+		t.Errorf("deliveries = %q, want one retry", texts)
+	}
+}
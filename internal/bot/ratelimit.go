@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	// Telegram allows about 30 messages per second per bot across all chats.
+	telegramMessagesPerSecond = 30
+	maxPendingEditRetries     = 100
+	maxEditRetryAttempts      = 3
+	maxSendRetryAttempts      = 3
+)
+
+// outboundLimiter spaces out calls to the Telegram API so that bursts (e.g. an
+// alert storm updating many views) stay under the global rate limit.
+type outboundLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newOutboundLimiter(perSecond int) *outboundLimiter {
+	return &outboundLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the caller may issue the next request.
+func (l *outboundLimiter) Wait() {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	time.Sleep(time.Until(slot))
+}
+
+// floodRetryAfter reports how long Telegram asked us to wait if err is a 429 response.
+func floodRetryAfter(err error) (time.Duration, bool) {
+	var flood telebot.FloodError
+	if errors.As(err, &flood) {
+		return time.Duration(flood.RetryAfter) * time.Second, true
+	}
+	return 0, false
+}
+
+type pendingEdit struct {
+	incidentID uint
+	editable   telebot.Editable
+	text       string
+	markup     *telebot.ReplyMarkup
+	attempt    int
+}
+
+// editRetryQueue holds view edits rejected with 429 until Telegram's retry_after
+// has passed. Only the latest edit per view is kept, so a retry never overwrites
+// newer content, and the number of pending views is bounded.
+type editRetryQueue struct {
+	mu      sync.Mutex
+	limit   int
+	pending map[string]*pendingEdit
+}
+
+func newEditRetryQueue(limit int) *editRetryQueue {
+	return &editRetryQueue{limit: limit, pending: make(map[string]*pendingEdit)}
+}
+
+// Schedule runs edit after delay, replacing any edit already pending for key. It
+// reports false if the queue is full.
+func (q *editRetryQueue) Schedule(key string, edit *pendingEdit, delay time.Duration, run func(*pendingEdit)) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, scheduled := q.pending[key]
+	if !scheduled && len(q.pending) >= q.limit {
+		return false
+	}
+	q.pending[key] = edit
+	if !scheduled {
+		time.AfterFunc(delay, func() {
+			if next := q.take(key); next != nil {
+				run(next)
+			}
+		})
+	}
+	return true
+}
+
+// Cancel drops the pending edit for key, e.g. after newer content was applied.
+func (q *editRetryQueue) Cancel(key string) {
+	q.take(key)
+}
+
+func (q *editRetryQueue) take(key string) *pendingEdit {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	edit := q.pending[key]
+	delete(q.pending, key)
+	return edit
+}
+
+// editIncidentView applies an edit to a single view, requeueing it when Telegram
+// rate limits the bot.
+func (b *Bot) editIncidentView(edit *pendingEdit) {
+	key := getViewRegistryKey(edit.editable)
+	b.limiter.Wait()
+	_, err := b.safeEdit(edit.editable, edit.text, edit.markup, telebot.ModeMarkdownV2)
+	if err == nil {
+		b.editRetries.Cancel(key)
+		b.logger.Debug("Updated incident view", "incident_id", edit.incidentID, "view", key)
+		return
+	}
+
+	if retryAfter, ok := floodRetryAfter(err); ok {
+		if edit.attempt >= maxEditRetryAttempts {
+			b.logger.Error("Giving up on rate-limited incident view update", "incident_id", edit.incidentID, "view", key, "attempts", edit.attempt+1)
+			return
+		}
+		edit.attempt++
+		if !b.editRetries.Schedule(key, edit, retryAfter, b.editIncidentView) {
+			b.logger.Error("Edit retry queue is full, dropping incident view update", "incident_id", edit.incidentID, "view", key)
+			return
+		}
+		b.logger.Warn("Rate limited by Telegram, retrying incident view update", "incident_id", edit.incidentID, "view", key, "retry_after", retryAfter)
+		return
+	}
+
+	switch {
+	case isBenignEditError(err):
+		b.editRetries.Cancel(key)
+	case isMessageGoneError(err):
+		b.logger.Warn("Incident view not found, forgetting it", "incident_id", edit.incidentID, "view", key)
+		b.forgetIncidentView(edit.incidentID, edit.editable)
+	default:
+		b.logger.Error("Failed to update incident view", "incident_id", edit.incidentID, "view", key, "error", err)
+	}
+}
+
+// send delivers a message through the limiter, waiting out 429 responses. Text
+// messages fall back to plain text when Telegram cannot parse their markup.
+func (b *Bot) send(to telebot.Recipient, what interface{}, opts ...interface{}) (*telebot.Message, error) {
+	text, ok := what.(string)
+	if !ok {
+		return b.sendLimited(to, what, opts...)
+	}
+	var msg *telebot.Message
+	err := b.withPlainTextFallback(text, opts, func(text string, opts ...interface{}) error {
+		var err error
+		msg, err = b.sendLimited(to, text, opts...)
+		return err
+	})
+	return msg, err
+}
+
+func (b *Bot) sendLimited(to telebot.Recipient, what interface{}, opts ...interface{}) (*telebot.Message, error) {
+	for attempt := 0; ; attempt++ {
+		b.limiter.Wait()
+		msg, err := b.bot.Send(to, what, opts...)
+		retryAfter, flooded := floodRetryAfter(err)
+		if !flooded || attempt+1 >= maxSendRetryAttempts {
+			return msg, err
+		}
+		b.logger.Warn("Rate limited by Telegram, retrying send", "retry_after", retryAfter)
+		time.Sleep(retryAfter)
+	}
+}
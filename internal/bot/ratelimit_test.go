@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+const floodReply = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0","parameters":{"retry_after":0}}`
+
+func TestSendRetriesAfterFlood(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	api.reply(floodReply)
+
+	msg, err := tb.sendLimited(&telebot.Chat{ID: -100}, "hello")
+	if err != nil {
+		t.Fatalf("sendLimited: %v", err)
+	}
+	if msg == nil || msg.ID != 1 {
+		t.Errorf("message = %+v, want the message of the retried send", msg)
+	}
+	if got := api.texts("sendMessage"); len(got) != 2 || got[1] != "hello" {
+		t.Errorf("sendMessage calls = %q, want the flooded send and one retry", got)
+	}
+}
+
+func TestSendGivesUpAfterRepeatedFlood(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	for i := 0; i < maxSendRetryAttempts; i++ {
+		api.reply(floodReply)
+	}
+
+	if _, err := tb.sendLimited(&telebot.Chat{ID: -100}, "hello"); err == nil {
+		t.Fatal("sendLimited succeeded, want the flood error")
+	}
+	if got := api.count("sendMessage"); got != maxSendRetryAttempts {
+		t.Errorf("sendMessage calls = %d, want %d", got, maxSendRetryAttempts)
+	}
+}
+
+func TestEditIncidentViewRetriesAfterFlood(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	api.reply(floodReply)
+
+	view := &telebot.Message{ID: 5, Chat: &telebot.Chat{ID: -100}}
+	tb.editIncidentView(&pendingEdit{incidentID: 1, editable: view, text: "updated"})
+
+	deadline := time.Now().Add(time.Second)
+	for api.count("editMessageText") < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := api.texts("editMessageText"); len(got) != 2 || got[1] != "updated" {
+		t.Fatalf("editMessageText calls = %q, want the flooded edit and one retry", got)
+	}
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"errors"
+	"strconv"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+	"gorm.io/gorm"
+)
+
+// handleRefresh handles "/refresh <ID>": it rebuilds the incident's views from
+// the stored message IDs and re-renders them. Admins only; meant for messages
+// left stale by Telegram errors or a restart.
+func (b *Bot) handleRefresh(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send(b.t(c, "refresh.usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+
+	ctx := requestContext(c)
+	incident, err := b.service.GetIncidentByID(ctx, uint(incidentID))
+	if err != nil {
+		if errors.Is(err, service.ErrIncidentNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Send(b.t(c, "common.incident_not_found"))
+		}
+		b.logger.ErrorContext(ctx, "Failed to load incident for refresh", "incident_id", incidentID, "error", err)
+		return c.Send(b.t(c, "refresh.failed"))
+	}
+
+	views := b.rebuildIncidentViews(incident)
+	if views == 0 {
+		return c.Send(b.t(c, "refresh.no_messages", incident.ID))
+	}
+	b.updateIncidentView(incident)
+	return c.Send(b.t(c, "refresh.done", incident.ID, views))
+}
+
+// rebuildIncidentViews drops the in-memory views of the incident and reloads
+// them from the persisted messages, re-persisting the primary message when its
+// record is missing (incidents announced before views were stored). It returns
+// the number of views found.
+func (b *Bot) rebuildIncidentViews(incident *models.Incident) int {
+	b.removeIncidentView(incident.ID)
+	views := b.incidentViews(incident.ID)
+
+	if incident.TelegramChatID.Valid && incident.TelegramMessageID.Valid {
+		primary := &telebot.Message{
+			ID:   int(incident.TelegramMessageID.Int64),
+			Chat: &telebot.Chat{ID: incident.TelegramChatID.Int64},
+		}
+		if incident.TelegramTopicID.Valid {
+			primary.ThreadID = int(incident.TelegramTopicID.Int64)
+		}
+		if _, ok := views[getViewRegistryKey(primary)]; !ok {
+			b.addIncidentView(incident.ID, primary, models.TelegramMessagePrimary)
+			views = b.incidentViews(incident.ID)
+		}
+	}
+	return len(views)
+}
@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+// TestRefreshRebuildsViewsFromStoredMessages checks that /refresh after a restart
+// (empty registry) finds the incident's messages in the database, registers them
+// again and re-renders each one.
+func TestRefreshRebuildsViewsFromStoredMessages(t *testing.T) {
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	tb.severityPolicy = newSeverityPolicy(config.TelegramConfig{})
+	ctx := context.Background()
+	admin := tb.user(t, 1, "en")
+	if err := tb.users.SetAdmin(ctx, admin.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	admin.IsAdmin = true
+
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X", "severity": "warning"}, nil)
+	// The primary message predates stored views: only the incident row has its ID.
+	if err := tb.service.SetTelegramMessageID(ctx, incident.ID, -100, 55); err != nil {
+		t.Fatal(err)
+	}
+	if err := tb.service.AddTelegramMessage(ctx, &models.TelegramMessage{IncidentID: incident.ID, ChatID: 42, MessageID: 7, Kind: models.TelegramMessageView}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newCommandContext(admin, strconv.FormatUint(uint64(incident.ID), 10))
+	if err := tb.handleRefresh(c); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("Refreshed 2 message(s) of incident #%d.", incident.ID); len(c.sent) != 1 || c.sent[0] != want {
+		t.Errorf("reply = %q, want %q", c.sent, want)
+	}
+
+	tb.registryMu.Lock()
+	var keys []string
+	for key := range tb.viewRegistry[incident.ID] {
+		keys = append(keys, key)
+	}
+	tb.registryMu.Unlock()
+	sort.Strings(keys)
+	if want := []string{"-100-55", "42-7"}; fmt.Sprint(keys) != fmt.Sprint(want) {
+		t.Errorf("registry keys = %v, want %v", keys, want)
+	}
+	if n := api.count("editMessageText"); n != 2 {
+		t.Errorf("editMessageText calls = %d, want 2", n)
+	}
+
+	// The primary message is persisted, so the next restart finds it directly.
+	stored, err := tb.service.ListTelegramMessages(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 {
+		t.Errorf("stored messages = %d, want 2", len(stored))
+	}
+}
+
+func TestRefreshErrors(t *testing.T) {
+	tb := newTestBot(t)
+	ctx := context.Background()
+	admin := tb.user(t, 1, "en")
+	if err := tb.users.SetAdmin(ctx, admin.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	admin.IsAdmin = true
+	member := tb.user(t, 2, "en")
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X"}, nil)
+	id := strconv.FormatUint(uint64(incident.ID), 10)
+
+	tests := []struct {
+		name string
+		user *models.User
+		args []string
+		want string
+	}{
+		{"not an admin", member, []string{id}, "This command is available to admins only."},
+		{"no arguments", admin, nil, "Usage: /refresh <ID>"},
+		{"unknown incident", admin, []string{"999"}, tb.tr.T("en", "common.incident_not_found")},
+		{"nothing stored", admin, []string{id}, fmt.Sprintf("Incident #%d has no stored messages, nothing to refresh.", incident.ID)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommandContext(tt.user, tt.args...)
+			if err := tb.handleRefresh(c); err != nil {
+				t.Fatal(err)
+			}
+			if len(c.sent) != 1 || c.sent[0] != tt.want {
+				t.Errorf("reply = %q, want %q", c.sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxReplicas caps the replica count accepted from chat when
+// actions.max_replicas is not set.
+const defaultMaxReplicas = 50
+
+// scaleUpWarnFactor is how many times above the current desired count a scale-up
+// has to go to be confirmed explicitly, even when scaling is not destructive.
+const scaleUpWarnFactor = 5
+
+// Errors returned by parseReplicaCount. replicaCountMessage maps them to
+// catalog messages for the user.
+var (
+	errReplicasMissing    = errors.New("replica count is missing")
+	errReplicasNotInteger = errors.New("replica count is not an integer")
+	errReplicasNegative   = errors.New("replica count is negative")
+	errReplicasTooMany    = errors.New("replica count exceeds the maximum")
+)
+
+// parseReplicaCount validates a replica count typed by the user.
+func parseReplicaCount(text string, maxReplicas int) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, errReplicasMissing
+	}
+	count, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, errReplicasNotInteger
+	}
+	if count < 0 {
+		return 0, errReplicasNegative
+	}
+	if count > maxReplicas {
+		return 0, errReplicasTooMany
+	}
+	return count, nil
+}
+
+// replicaCountMessage explains a parseReplicaCount error for text in lang.
+func (b *Bot) replicaCountMessage(lang string, err error, text string) string {
+	switch {
+	case errors.Is(err, errReplicasMissing):
+		return b.tr.T(lang, "replicas.missing")
+	case errors.Is(err, errReplicasNotInteger):
+		return b.tr.T(lang, "replicas.not_integer", strings.TrimSpace(text))
+	case errors.Is(err, errReplicasNegative):
+		return b.tr.T(lang, "replicas.negative")
+	case errors.Is(err, errReplicasTooMany):
+		return b.tr.T(lang, "replicas.too_many", b.maxReplicas)
+	}
+	return b.tr.T(lang, "replicas.invalid_count")
+}
+
+// isLargeScaleUp reports whether scaling from current to requested replicas
+// exceeds scaleUpWarnFactor. An unknown current count never warns.
+func isLargeScaleUp(current, requested int) bool {
+	return current > 0 && requested > current*scaleUpWarnFactor
+}
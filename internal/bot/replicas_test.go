@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseReplicaCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr error
+	}{
+		{name: "zero", input: "0", want: 0},
+		{name: "in range", input: "3", want: 3},
+		{name: "surrounding spaces", input: "  7\n", want: 7},
+		{name: "upper bound", input: "10", want: 10},
+		{name: "above upper bound", input: "11", wantErr: errReplicasTooMany},
+		{name: "negative", input: "-1", wantErr: errReplicasNegative},
+		{name: "empty", input: "   ", wantErr: errReplicasMissing},
+		{name: "word", input: "three", wantErr: errReplicasNotInteger},
+		{name: "fraction", input: "1.5", wantErr: errReplicasNotInteger},
+		{name: "overflow", input: "99999999999999999999", wantErr: errReplicasNotInteger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReplicaCount(tt.input, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseReplicaCount(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parseReplicaCount(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplicaCountMessage(t *testing.T) {
+	b := newLocalizedBot("ru")
+	b.maxReplicas = 10
+
+	tests := []struct {
+		lang  string
+		input string
+		want  string
+	}{
+		{"ru", "", "Количество реплик не указано."},
+		{"en", "three", "“three” is not a whole number."},
+		{"en", "-2", "The replica count cannot be negative."},
+		{"ru", "11", "Нельзя масштабировать больше чем до 10 реплик."},
+	}
+	for _, tt := range tests {
+		_, err := parseReplicaCount(tt.input, b.maxReplicas)
+		if got := b.replicaCountMessage(tt.lang, err, tt.input); got != tt.want {
+			t.Errorf("%s %q: message = %q, want %q", tt.lang, tt.input, got, tt.want)
+		}
+	}
+}
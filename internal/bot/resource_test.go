@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+func findButton(markup *telebot.ReplyMarkup, prefix string) (telebot.InlineButton, bool) {
+	if markup == nil {
+		return telebot.InlineButton{}, false
+	}
+	for _, row := range markup.InlineKeyboard {
+		for _, button := range row {
+			if strings.HasPrefix(button.Text, prefix) {
+				return button, true
+			}
+		}
+	}
+	return telebot.InlineButton{}, false
+}
+
+// TestRestoreReplicasUsesDesiredCount checks that the restore button offers the
+// desired replica count reported by the executor and scales to exactly that count.
+func TestRestoreReplicasUsesDesiredCount(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "en")
+	tb.executor.Details = &models.ResourceDetails{Status: models.DeploymentDegraded, ReplicasInfo: "1/4 ready", DesiredReplicas: 4, ReadyReplicas: 1}
+	incident := tb.incident(t,
+		models.JSONBMap{"alertname": replicasMismatchAlert, "namespace": "prod"},
+		models.JSONBMap{"deployment": "api"},
+	)
+
+	c := newCallbackContext("", user)
+	if err := tb.renderResourceActionsView(c, incident.ID, "deployment", "api", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	button, ok := findButton(c.lastMarkup(), "🔁")
+	if !ok {
+		t.Fatalf("no restore button in %q", buttonTexts(c.lastMarkup().InlineKeyboard))
+	}
+	if want := "🔁 Restore to 4 replicas"; button.Text != want {
+		t.Errorf("button = %q, want %q", button.Text, want)
+	}
+
+	restore := newCallbackContext(tb.payload(t, button), user)
+	if err := tb.handleRestoreReplicas(restore); err != nil {
+		t.Fatal(err)
+	}
+	call, ok := tb.executor.LastCall()
+	if !ok || call.Action != string(models.ActionScaleDeployment) {
+		t.Fatalf("last executor call = %+v, %v, want scale_deployment", call, ok)
+	}
+	if call.Parameters["replicas"] != "4" || call.Parameters["deployment"] != "api" || call.Parameters["namespace"] != "prod" {
+		t.Errorf("scale parameters = %v, want api in prod scaled to 4", call.Parameters)
+	}
+}
+
+func TestRestoreButtonOnlyForReplicasMismatch(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "")
+	tb.executor.Details = &models.ResourceDetails{DesiredReplicas: 4}
+	incident := tb.incident(t, models.JSONBMap{"alertname": "KubePodCrashLooping", "namespace": "prod"}, models.JSONBMap{"deployment": "api"})
+
+	c := newCallbackContext("", user)
+	if err := tb.renderResourceActionsView(c, incident.ID, "deployment", "api", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if button, ok := findButton(c.lastMarkup(), "🔁"); ok {
+		t.Errorf("unexpected restore button %q for another alert", button.Text)
+	}
+}
+
+func TestWriteResourceDetailsNode(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	tests := []struct {
+		name    string
+		details models.ResourceDetails
+		want    []string
+		absent  []string
+	}{
+		{
+			name: "ready with usage",
+			details: models.ResourceDetails{
+				Status: "Ready",
+				Age:    "12d",
+				Node: &models.NodeResources{
+					CpuAllocatable: 4000, CpuUsage: 1500,
+					MemoryAllocatable: 16 * gib, MemoryUsage: 4 * gib,
+					Pods: 23, PodCapacity: 110,
+				},
+			},
+			want: []string{
+				"∙ *Status:* 🟢 `Ready`\n",
+				"∙ *Age:* `12d`\n",
+				"*Usage / allocatable:*\n",
+				"  ∙ *CPU:* `1.50 / 4.00 cores (38%)`\n",
+				"  ∙ *Memory:* `4.0 / 16.0 GiB (25%)`\n",
+				"  ∙ *Pods:* `23/110`\n",
+			},
+		},
+		{
+			name:    "cordoned",
+			details: models.ResourceDetails{Status: "Ready,SchedulingDisabled", Node: &models.NodeResources{Pods: 5}},
+			want:    []string{"🟡 `Ready,SchedulingDisabled`", "  ∙ *Pods:* `5`\n", "(no limit)"},
+		},
+		{
+			name:    "not ready without metrics",
+			details: models.ResourceDetails{Status: "NotReady", Age: "3h"},
+			want:    []string{"∙ *Status:* 🔴 `NotReady`\n", "∙ *Age:* `3h`\n"},
+			absent:  []string{"allocatable", "Pods", "Replicas"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newLocalizedBot("en")
+			var builder strings.Builder
+			b.writeResourceDetails(&builder, "en", "node", &tt.details)
+			got := builder.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("details = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(got, absent) {
+					t.Errorf("details = %q, want no %q", got, absent)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeResourceViewOffersDescribe(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "en")
+	tb.executor.Details = &models.ResourceDetails{Status: "Ready", Node: &models.NodeResources{Pods: 3}}
+	incident := tb.incident(t, models.JSONBMap{"alertname": "KubeNodeUnreachable"}, models.JSONBMap{"node": "worker-1"})
+
+	c := newCallbackContext("", user)
+	if err := tb.renderResourceActionsView(c, incident.ID, "node", "worker-1", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(c.edits[len(c.edits)-1], "∙ *Pods:* `3`") {
+		t.Errorf("view = %q, want node pod count", c.edits[len(c.edits)-1])
+	}
+	button, ok := findButton(c.lastMarkup(), "📖")
+	if !ok {
+		t.Fatalf("no describe button in %q", buttonTexts(c.lastMarkup().InlineKeyboard))
+	}
+	if payload := tb.payload(t, button); !strings.HasPrefix(payload, describeNodePrefix) || !strings.HasSuffix(payload, ":worker-1") {
+		t.Errorf("describe payload = %q", payload)
+	}
+}
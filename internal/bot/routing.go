@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+// alertRouter picks the channel an incident is posted to from its labels.
+type alertRouter struct {
+	routes          []config.AlertRoute
+	fallbackChannel int64
+}
+
+func newAlertRouter(cfg config.TelegramConfig) alertRouter {
+	return alertRouter{routes: cfg.Routes, fallbackChannel: cfg.AlertChannelID}
+}
+
+// channelFor returns the channel of the first route whose labels all match the
+// incident, or the default alert channel. Zero means no channel is configured.
+func (r alertRouter) channelFor(incident *models.Incident) int64 {
+	for _, route := range r.routes {
+		if routeMatches(route, incident.Labels) {
+			return route.ChannelID
+		}
+	}
+	return r.fallbackChannel
+}
+
+func routeMatches(route config.AlertRoute, labels models.JSONBMap) bool {
+	if len(route.Match) == 0 {
+		return false
+	}
+	for key, value := range route.Match {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// alertChatID returns the channel the incident was posted to, falling back to the
+// routed channel for incidents that have not been posted yet.
+func (b *Bot) alertChatID(incident *models.Incident) int64 {
+	if incident.TelegramChatID.Valid && incident.TelegramChatID.Int64 != 0 {
+		return incident.TelegramChatID.Int64
+	}
+	return b.router.channelFor(incident)
+}
+
+// topicURL links to a forum topic of a supergroup.
+func topicURL(chatID, threadID int64) string {
+	channelIDForLink := strings.TrimPrefix(strconv.FormatInt(chatID, 10), "-100")
+	return fmt.Sprintf("https://t.me/c/%s/%d", channelIDForLink, threadID)
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"database/sql"
+	"testing"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+func TestAlertRouterChannelFor(t *testing.T) {
+	router := newAlertRouter(config.TelegramConfig{
+		AlertChannelID: -100,
+		Routes: []config.AlertRoute{
+			{Match: map[string]string{"team": "payments", "env": "prod"}, ChannelID: -201},
+			{Match: map[string]string{"team": "payments"}, ChannelID: -202},
+			{Match: map[string]string{}, ChannelID: -203},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		labels models.JSONBMap
+		want   int64
+	}{
+		{name: "all labels of the first route", labels: models.JSONBMap{"team": "payments", "env": "prod"}, want: -201},
+		{name: "first matching route wins", labels: models.JSONBMap{"team": "payments", "env": "stage"}, want: -202},
+		{name: "no route matches", labels: models.JSONBMap{"team": "search"}, want: -100},
+		{name: "empty matcher never matches", labels: models.JSONBMap{}, want: -100},
+		{name: "values are case-sensitive", labels: models.JSONBMap{"team": "Payments"}, want: -100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := router.channelFor(&models.Incident{Labels: tt.labels}); got != tt.want {
+				t.Errorf("channelFor(%v) = %d, want %d", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlertChatIDPrefersPostedChat(t *testing.T) {
+	b := &Bot{router: newAlertRouter(config.TelegramConfig{
+		AlertChannelID: -100,
+		Routes:         []config.AlertRoute{{Match: map[string]string{"team": "payments"}, ChannelID: -201}},
+	})}
+
+	// The routes changed after the incident was posted: updates still go to its chat.
+	posted := &models.Incident{Labels: models.JSONBMap{"team": "payments"}, TelegramChatID: sql.NullInt64{Int64: -300, Valid: true}}
+	if got := b.alertChatID(posted); got != -300 {
+		t.Errorf("alertChatID(posted) = %d, want -300", got)
+	}
+	fresh := &models.Incident{Labels: models.JSONBMap{"team": "payments"}}
+	if got := b.alertChatID(fresh); got != -201 {
+		t.Errorf("alertChatID(fresh) = %d, want -201", got)
+	}
+}
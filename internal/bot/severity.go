@@ -0,0 +1,185 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	showSeverityOptionsPrefix = "sev:"
+	setSeverityPrefix         = "ssv:"
+)
+
+const defaultSeverityLabel = "severity"
+
+// lowSeverityChoices are offered next to the high values when an operator
+// re-classifies an incident.
+var lowSeverityChoices = []string{"warning", "info"}
+
+// severityPolicy decides which incidents are high severity and whether those get a
+// dedicated forum topic.
+type severityPolicy struct {
+	label         string
+	highValues    map[string]bool
+	choices       []string
+	topicsEnabled bool
+}
+
+func newSeverityPolicy(cfg config.TelegramConfig) severityPolicy {
+	policy := severityPolicy{
+		label:         cfg.SeverityLabel,
+		highValues:    make(map[string]bool),
+		topicsEnabled: !cfg.DisableTopics,
+	}
+	if policy.label == "" {
+		policy.label = defaultSeverityLabel
+	}
+	values := cfg.HighSeverityValues
+	if len(values) == 0 {
+		values = config.DefaultHighSeverityValues
+	}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		policy.highValues[value] = true
+		policy.choices = append(policy.choices, value)
+	}
+	for _, value := range lowSeverityChoices {
+		if !policy.highValues[value] {
+			policy.choices = append(policy.choices, value)
+		}
+	}
+	return policy
+}
+
+func (p severityPolicy) severity(incident *models.Incident) (string, bool) {
+	severity, ok := incident.Labels[p.label]
+	return severity, ok
+}
+
+func (p severityPolicy) isHigh(incident *models.Incident) bool {
+	severity, ok := p.severity(incident)
+	return ok && p.isHighValue(severity)
+}
+
+func (p severityPolicy) isHighValue(severity string) bool {
+	return p.highValues[strings.ToLower(severity)]
+}
+
+// usesTopic reports whether the incident goes through the topic (high-severity) path.
+func (p severityPolicy) usesTopic(incident *models.Incident) bool {
+	return p.topicsEnabled && p.isHigh(incident)
+}
+
+// usesTopicFor reports whether an incident with the given severity would go
+// through the topic path.
+func (p severityPolicy) usesTopicFor(severity string) bool {
+	return p.topicsEnabled && p.isHighValue(severity)
+}
+
+func (b *Bot) showSeverityOptions(c telebot.Context, incidentID uint) error {
+	incident, err := b.service.GetIncidentByID(requestContext(c), incidentID)
+	if err != nil {
+		return c.EditOrSend(b.t(c, "common.incident_not_found"))
+	}
+	current, _ := b.severityPolicy.severity(incident)
+
+	idStr := strconv.FormatUint(uint64(incidentID), 10)
+	var keyboard [][]telebot.InlineButton
+	var row []telebot.InlineButton
+	for _, choice := range b.severityPolicy.choices {
+		text := choice
+		if strings.EqualFold(choice, current) {
+			text = "• " + choice
+		}
+		row = append(row, telebot.InlineButton{Text: text, Data: b.callbackData(setSeverityPrefix + idStr + ":" + choice)})
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+	keyboard = append(keyboard, []telebot.InlineButton{{Text: b.t(c, "common.back"), Data: b.callbackData(viewIncidentPrefix + idStr)}})
+
+	if current == "" {
+		current = "N/A"
+	}
+	return c.Edit(b.t(c, "severity.prompt", incidentID, current), &telebot.ReplyMarkup{InlineKeyboard: keyboard})
+}
+
+func (b *Bot) handleSetSeverity(c telebot.Context, incidentID uint) error {
+	parts := strings.SplitN(c.Data(), ":", 3)
+	if len(parts) < 3 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_callback")})
+	}
+
+	ctx := requestContext(c)
+	incident, previous, err := b.service.SetSeverity(ctx, requestUser(c).ID, incidentID, parts[2])
+	if errors.Is(err, service.ErrIncidentNotActive) {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.incident_closed")})
+	}
+	if err != nil {
+		b.logger.ErrorContext(ctx, "Failed to set incident severity", "incident_id", incidentID, "error", err)
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "severity.failed")})
+	}
+	c.Respond(&telebot.CallbackResponse{Text: b.t(c, "severity.done", parts[2])})
+
+	if b.needsRerouteAfterSeverityChange(incident, previous) {
+		b.repostIncident(incident)
+		return nil
+	}
+	return b.showIncidentView(c, incidentID, false)
+}
+
+// needsRerouteAfterSeverityChange reports whether a posted incident now belongs on
+// the other side of the topic threshold, or in another channel because routes
+// match on the severity label.
+func (b *Bot) needsRerouteAfterSeverityChange(incident *models.Incident, previous string) bool {
+	if !incident.TelegramMessageID.Valid {
+		return false
+	}
+	if b.severityPolicy.usesTopicFor(previous) != b.severityPolicy.usesTopic(incident) {
+		return true
+	}
+	return b.router.channelFor(incident) != b.alertChatID(incident)
+}
+
+// repostIncident removes the incident's topic and alert channel messages and posts
+// it again, so it lands where its current labels route it.
+func (b *Bot) repostIncident(incident *models.Incident) {
+	b.logger.Info("Reposting incident after severity change", "incident_id", incident.ID)
+	alertChatID := b.alertChatID(incident)
+	if incident.TelegramTopicID.Valid && incident.TelegramTopicID.Int64 != 0 {
+		b.limiter.Wait()
+		topic := &telebot.Topic{ThreadID: int(incident.TelegramTopicID.Int64)}
+		if err := b.bot.DeleteTopic(&telebot.Chat{ID: alertChatID}, topic); err != nil {
+			b.logger.Error("Failed to delete topic", "incident_id", incident.ID, "topic_id", topic.ThreadID, "error", err)
+		}
+		b.service.SetTelegramTopicID(context.Background(), incident.ID, 0)
+		incident.TelegramTopicID = sql.NullInt64{}
+	}
+
+	for key, editable := range b.incidentViews(incident.ID) {
+		if _, chatID := editable.MessageSig(); chatID != alertChatID {
+			continue
+		}
+		if err := b.bot.Delete(editable); err != nil {
+			b.logger.Error("Failed to delete view from alert channel", "incident_id", incident.ID, "view", key, "error", err)
+		}
+		b.forgetIncidentView(incident.ID, editable)
+	}
+	incident.TelegramChatID = sql.NullInt64{}
+	incident.TelegramMessageID = sql.NullInt64{}
+
+	b.notifyTelegram(incident)
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+func TestSeverityPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.TelegramConfig
+		labels    models.JSONBMap
+		wantHigh  bool
+		wantTopic bool
+	}{
+		{name: "default critical", labels: models.JSONBMap{"severity": "critical"}, wantHigh: true, wantTopic: true},
+		{name: "default high", labels: models.JSONBMap{"severity": "high"}, wantHigh: true, wantTopic: true},
+		{name: "default warning", labels: models.JSONBMap{"severity": "warning"}},
+		{name: "no severity label", labels: models.JSONBMap{"alertname": "X"}},
+		{name: "case-insensitive", labels: models.JSONBMap{"severity": "CRITICAL"}, wantHigh: true, wantTopic: true},
+		{
+			name:      "custom values",
+			cfg:       config.TelegramConfig{HighSeverityValues: []string{"P1", " sev1 "}},
+			labels:    models.JSONBMap{"severity": "p1"},
+			wantHigh:  true,
+			wantTopic: true,
+		},
+		{
+			name:      "custom values trimmed",
+			cfg:       config.TelegramConfig{HighSeverityValues: []string{"P1", " sev1 "}},
+			labels:    models.JSONBMap{"severity": "sev1"},
+			wantHigh:  true,
+			wantTopic: true,
+		},
+		{
+			name:   "custom values replace the defaults",
+			cfg:    config.TelegramConfig{HighSeverityValues: []string{"P1"}},
+			labels: models.JSONBMap{"severity": "critical"},
+		},
+		{
+			name:      "custom label",
+			cfg:       config.TelegramConfig{SeverityLabel: "priority", HighSeverityValues: []string{"P1"}},
+			labels:    models.JSONBMap{"priority": "P1", "severity": "warning"},
+			wantHigh:  true,
+			wantTopic: true,
+		},
+		{
+			name:   "custom label ignores severity",
+			cfg:    config.TelegramConfig{SeverityLabel: "priority"},
+			labels: models.JSONBMap{"severity": "critical"},
+		},
+		{
+			name:     "topics disabled",
+			cfg:      config.TelegramConfig{DisableTopics: true},
+			labels:   models.JSONBMap{"severity": "critical"},
+			wantHigh: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newSeverityPolicy(tt.cfg)
+			incident := &models.Incident{Labels: tt.labels}
+			if got := policy.isHigh(incident); got != tt.wantHigh {
+				t.Errorf("isHigh = %v, want %v", got, tt.wantHigh)
+			}
+			if got := policy.usesTopic(incident); got != tt.wantTopic {
+				t.Errorf("usesTopic = %v, want %v", got, tt.wantTopic)
+			}
+		})
+	}
+}
+
+func TestSeverityPolicyChoices(t *testing.T) {
+	policy := newSeverityPolicy(config.TelegramConfig{HighSeverityValues: []string{"P1", "warning"}})
+	want := []string{"p1", "warning", "info"}
+	if len(policy.choices) != len(want) {
+		t.Fatalf("choices = %v, want %v", policy.choices, want)
+	}
+	for i := range want {
+		if policy.choices[i] != want[i] {
+			t.Fatalf("choices = %v, want %v", policy.choices, want)
+		}
+	}
+}
+
+// severityBot is a test bot posting to channel -100 with the default severity
+// policy, and an incident of the given severity already posted there.
+func severityBot(t *testing.T, severity string, topicID int64) (*testBot, *fakeTelegram, *models.Incident, *models.User) {
+	t.Helper()
+	tb := newTestBot(t)
+	api := tb.withTelegram(t)
+	tb.severityPolicy = newSeverityPolicy(config.TelegramConfig{})
+	tb.router = newAlertRouter(config.TelegramConfig{AlertChannelID: -100})
+	ctx := context.Background()
+	incident := tb.incident(t, models.JSONBMap{"alertname": "X", "severity": severity}, nil)
+	if err := tb.service.SetTelegramMessageID(ctx, incident.ID, -100, 1); err != nil {
+		t.Fatal(err)
+	}
+	if topicID != 0 {
+		if err := tb.service.SetTelegramTopicID(ctx, incident.ID, topicID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tb, api, incident, tb.user(t, 1, "")
+}
+
+func setSeverityData(incident *models.Incident, severity string) string {
+	return setSeverityPrefix + strconv.FormatUint(uint64(incident.ID), 10) + ":" + severity
+}
+
+func TestSetSeverityCrossingThresholdOpensTopic(t *testing.T) {
+	tb, api, incident, user := severityBot(t, "warning", 0)
+	api.reply(`{"ok":true,"result":{"message_thread_id":42,"name":"topic"}}`)
+
+	c := newCallbackContext(setSeverityData(incident, "critical"), user)
+	if err := tb.handleSetSeverity(c, incident.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := api.count("createForumTopic"); n != 1 {
+		t.Fatalf("createForumTopic calls = %d, want 1", n)
+	}
+	got, err := tb.repo.FindByID(context.Background(), incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Labels["severity"] != "critical" {
+		t.Errorf("severity = %q, want critical", got.Labels["severity"])
+	}
+	if !got.TelegramTopicID.Valid || got.TelegramTopicID.Int64 != 42 {
+		t.Errorf("topic = %v, want 42", got.TelegramTopicID)
+	}
+}
+
+func TestSetSeverityBelowThresholdDeletesTopic(t *testing.T) {
+	tb, api, incident, user := severityBot(t, "critical", 42)
+
+	c := newCallbackContext(setSeverityData(incident, "warning"), user)
+	if err := tb.handleSetSeverity(c, incident.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := api.count("deleteForumTopic"); n != 1 {
+		t.Errorf("deleteForumTopic calls = %d, want 1", n)
+	}
+	if n := api.count("createForumTopic"); n != 0 {
+		t.Errorf("createForumTopic calls = %d, want 0", n)
+	}
+	if n := api.count("sendMessage"); n != 1 {
+		t.Errorf("sendMessage calls = %d, want 1 (the channel post)", n)
+	}
+	got, err := tb.repo.FindByID(context.Background(), incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TelegramTopicID.Valid && got.TelegramTopicID.Int64 != 0 {
+		t.Errorf("topic = %v, want none", got.TelegramTopicID)
+	}
+}
+
+func TestSetSeverityOnSameSideKeepsPost(t *testing.T) {
+	tb, api, incident, user := severityBot(t, "warning", 0)
+
+	c := newCallbackContext(setSeverityData(incident, "info"), user)
+	if err := tb.handleSetSeverity(c, incident.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(api.calls); n != 0 {
+		t.Errorf("made %d Bot API calls, want none", n)
+	}
+	if len(c.edits) == 0 {
+		t.Error("incident view was not refreshed")
+	}
+}
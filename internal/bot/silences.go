@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+)
+
+// maxSilenceDuration caps how long a silence set from chat may last.
+const maxSilenceDuration = 30 * 24 * time.Hour
+
+// parseSilenceDuration accepts Go durations ("30m", "2h") and whole days ("3d").
+func parseSilenceDuration(arg string) (time.Duration, error) {
+	var duration time.Duration
+	if days, ok := strings.CutSuffix(strings.ToLower(arg), "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		duration = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if duration, err = time.ParseDuration(arg); err != nil {
+			return 0, err
+		}
+	}
+	if duration <= 0 || duration > maxSilenceDuration {
+		return 0, fmt.Errorf("duration %s is out of range", arg)
+	}
+	return duration, nil
+}
+
+// formatMatchers renders matchers as "name=value" pairs sorted by name.
+func formatMatchers(matchers map[string]string) string {
+	pairs := make([]string, 0, len(matchers))
+	for name, value := range matchers {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// handleSilence handles "/silence name=value [name=value ...] <duration>". Admins only.
+func (b *Bot) handleSilence(c telebot.Context) error {
+	user := requestUser(c)
+	if !user.IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	args := c.Args()
+	if len(args) < 2 {
+		return c.Send(b.t(c, "silence.usage"))
+	}
+	duration, err := parseSilenceDuration(args[len(args)-1])
+	if err != nil {
+		return c.Send(b.t(c, "silence.invalid_duration"))
+	}
+	matchers := make(map[string]string)
+	for _, arg := range args[:len(args)-1] {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" || value == "" {
+			return c.Send(b.t(c, "silence.usage"))
+		}
+		matchers[name] = value
+	}
+
+	ctx := requestContext(c)
+	silence, err := b.service.AddSilence(ctx, user.ID, matchers, time.Now().Add(duration))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSilence) {
+			return c.Send(b.t(c, "silence.usage"))
+		}
+		b.logger.ErrorContext(ctx, "Failed to add silence", "matchers", matchers, "error", err)
+		return c.Send(b.t(c, "silence.failed"))
+	}
+	return c.Send(b.t(c, "silence.added", silence.ID, formatMatchers(silence.Matchers), silence.EndsAt.Format("02.01.2006 15:04")))
+}
+
+// handleSilences lists the active silences. Admins only.
+func (b *Bot) handleSilences(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	ctx := requestContext(c)
+	silences, err := b.service.ListActiveSilences(ctx)
+	if err != nil {
+		b.logger.ErrorContext(ctx, "Failed to list silences", "error", err)
+		return c.Send(b.t(c, "silence.failed"))
+	}
+	if len(silences) == 0 {
+		return c.Send(b.t(c, "silence.none"))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(b.t(c, "silence.header", len(silences)) + "\n")
+	for _, silence := range silences {
+		builder.WriteString(b.t(c, "silence.line", silence.ID, formatMatchers(silence.Matchers), silence.EndsAt.Format("02.01.2006 15:04"), b.silenceAuthor(c, silence)) + "\n")
+	}
+	return c.Send(builder.String())
+}
+
+func (b *Bot) silenceAuthor(c telebot.Context, silence *models.Silence) string {
+	author, err := b.userRepo.FindByID(requestContext(c), silence.CreatedBy)
+	if err != nil {
+		return "?"
+	}
+	return userDisplayName(author)
+}
+
+// handleUnsilence handles "/unsilence <ID>". Admins only.
+func (b *Bot) handleUnsilence(c telebot.Context) error {
+	user := requestUser(c)
+	if !user.IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	args := c.Args()
+	if len(args) != 1 {
+		return c.Send(b.t(c, "silence.unsilence_usage"))
+	}
+	silenceID, err := strconv.ParseUint(strings.TrimPrefix(args[0], "#"), 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "silence.unsilence_usage"))
+	}
+
+	ctx := requestContext(c)
+	if err := b.service.RemoveSilence(ctx, user.ID, uint(silenceID)); err != nil {
+		if errors.Is(err, service.ErrSilenceNotFound) {
+			return c.Send(b.t(c, "silence.not_found", silenceID))
+		}
+		b.logger.ErrorContext(ctx, "Failed to remove silence", "silence_id", silenceID, "error", err)
+		return c.Send(b.t(c, "silence.failed"))
+	}
+	return c.Send(b.t(c, "silence.removed", silenceID))
+}
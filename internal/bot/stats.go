@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	defaultStatsDays = 7
+	maxStatsDays     = 365
+)
+
+// parseStatsWindow parses a window given in days, e.g. "7d" or "30d".
+func parseStatsWindow(arg string) (int, bool) {
+	days, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(arg), "d"))
+	if err != nil || days <= 0 || days > maxStatsDays {
+		return 0, false
+	}
+	return days, true
+}
+
+func (b *Bot) handleStats(c telebot.Context) error {
+	days := defaultStatsDays
+	if args := c.Args(); len(args) > 0 {
+		parsed, ok := parseStatsWindow(args[0])
+		if !ok {
+			return c.Send(b.t(c, "stats.usage"))
+		}
+		days = parsed
+	}
+
+	stats, err := b.service.GetStats(requestContext(c), time.Duration(days)*24*time.Hour, b.severityPolicy.label)
+	if err != nil {
+		b.logger.Error("Failed to collect incident stats", "error", err)
+		return c.Send(b.t(c, "stats.failed"))
+	}
+	if stats.Created == 0 {
+		return c.Send(b.t(c, "stats.empty", days))
+	}
+
+	var table strings.Builder
+	row := func(label, value string) {
+		fmt.Fprintf(&table, "%-14s %s\n", label, value)
+	}
+	row(b.t(c, "stats.created"), strconv.FormatInt(stats.Created, 10))
+	row(b.t(c, "stats.resolved"), strconv.FormatInt(stats.Resolved, 10))
+	row(b.t(c, "stats.rejected"), strconv.FormatInt(stats.Rejected, 10))
+	row(b.t(c, "stats.active"), strconv.FormatInt(stats.Active, 10))
+	if stats.ResolvedWithDuration > 0 {
+		row(b.t(c, "stats.mttr"), fmt.Sprintf("%s (n=%d)", stats.MeanTimeToResolve.Truncate(time.Minute), stats.ResolvedWithDuration))
+	} else {
+		row(b.t(c, "stats.mttr"), "—")
+	}
+
+	severities := make([]string, 0, len(stats.BySeverity))
+	for severity := range stats.BySeverity {
+		severities = append(severities, severity)
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		ci, cj := stats.BySeverity[severities[i]], stats.BySeverity[severities[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return severities[i] < severities[j]
+	})
+	var bySeverity strings.Builder
+	for _, severity := range severities {
+		name := severity
+		if name == "" {
+			name = b.t(c, "stats.no_severity")
+		}
+		fmt.Fprintf(&bySeverity, "%-14s %d\n", name, stats.BySeverity[severity])
+	}
+
+	var builder strings.Builder
+	builder.WriteString(b.t(c, "stats.title", days) + "\n")
+	builder.WriteString("```\n" + escapeMarkdownCodeBlock(table.String()) + "```\n")
+	builder.WriteString(b.t(c, "stats.by_severity", escapeMarkdownCodeBlock(b.severityPolicy.label)) + "\n")
+	builder.WriteString("```\n" + escapeMarkdownCodeBlock(bySeverity.String()) + "```")
+	return b.sendMarkdown(c, builder.String(), &telebot.SendOptions{ParseMode: telebot.ModeMarkdownV2})
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gopkg.in/telebot.v3"
+	"gorm.io/gorm"
+)
+
+// handleTag handles "/tag <ID> name=value".
+func (b *Bot) handleTag(c telebot.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Send(b.t(c, "tag.usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+	name, value, ok := strings.Cut(args[1], "=")
+	if !ok {
+		return c.Send(b.t(c, "tag.usage"))
+	}
+	name = strings.TrimPrefix(name, models.CustomTagPrefix)
+
+	ctx := requestContext(c)
+	_, err = b.service.AddLabel(ctx, requestUser(c).ID, uint(incidentID), name, value)
+	if err != nil {
+		return b.sendTagError(c, uint(incidentID), err)
+	}
+	return c.Send(b.t(c, "tag.added", name, value, incidentID))
+}
+
+// handleUntag handles "/untag <ID> name".
+func (b *Bot) handleUntag(c telebot.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Send(b.t(c, "tag.untag_usage"))
+	}
+	incidentID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return c.Send(b.t(c, "common.invalid_id"))
+	}
+	name := strings.TrimPrefix(args[1], models.CustomTagPrefix)
+
+	ctx := requestContext(c)
+	_, err = b.service.RemoveLabel(ctx, requestUser(c).ID, uint(incidentID), name)
+	if err != nil {
+		return b.sendTagError(c, uint(incidentID), err)
+	}
+	return c.Send(b.t(c, "tag.removed", name, incidentID))
+}
+
+func (b *Bot) sendTagError(c telebot.Context, incidentID uint, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTag):
+		return c.Send(b.t(c, "tag.invalid"))
+	case errors.Is(err, service.ErrTagNotFound):
+		return c.Send(b.t(c, "tag.not_found"))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Send(b.t(c, "common.incident_not_found"))
+	}
+	b.logger.ErrorContext(requestContext(c), "Failed to update incident tags", "incident_id", incidentID, "error", err)
+	return c.Send(b.t(c, "tag.failed"))
+}
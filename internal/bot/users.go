@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/telebot.v3"
+)
+
+const (
+	usersPagePrefix = "up:"
+	usersPageSize   = 25
+)
+
+// handleWhoami shows the caller's stored user record.
+func (b *Bot) handleWhoami(c telebot.Context) error {
+	user := requestUser(c)
+	lang := b.lang(c)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("*%s*\n", escapeMarkdown(b.tr.T(lang, "whoami.header"))))
+	field := func(key, value string) {
+		builder.WriteString(fmt.Sprintf("%s: %s\n", escapeMarkdown(b.tr.T(lang, key)), value))
+	}
+	field("whoami.id", fmt.Sprintf("`%d`", user.ID))
+	field("whoami.telegram_id", fmt.Sprintf("`%d`", user.TelegramID))
+	if user.Username != "" {
+		field("whoami.username", escapeMarkdown("@"+user.Username))
+	}
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		field("whoami.name", escapeMarkdown(name))
+	}
+	field("whoami.language", escapeMarkdown(lang))
+	role := b.tr.T(lang, "users.role_user")
+	if user.IsAdmin {
+		role = "👑 " + b.tr.T(lang, "users.role_admin")
+	}
+	field("whoami.role", escapeMarkdown(role))
+	field("whoami.registered", escapeMarkdown(user.CreatedAt.Format("02.01.2006 15:04")))
+	return b.sendMarkdown(c, builder.String(), telebot.ModeMarkdownV2)
+}
+
+// handleUsers lists registered users. Admins only.
+func (b *Bot) handleUsers(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Send(b.t(c, "flags.admin_only"))
+	}
+	text, keyboard, err := b.buildUsersPage(requestContext(c), b.lang(c), 0)
+	if err != nil {
+		b.logger.ErrorContext(requestContext(c), "Failed to list users", "error", err)
+		return c.Send(b.t(c, "users.failed"))
+	}
+	return b.sendMarkdown(c, text, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+}
+
+func (b *Bot) handleUsersPage(c telebot.Context) error {
+	if !requestUser(c).IsAdmin {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "flags.admin_only")})
+	}
+	parts := strings.Split(c.Data(), ":")
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "common.invalid_page")})
+	}
+
+	text, keyboard, err := b.buildUsersPage(requestContext(c), b.lang(c), offset)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: b.t(c, "users.failed")})
+	}
+	err = b.editMarkdown(c, text, &telebot.ReplyMarkup{InlineKeyboard: keyboard}, telebot.ModeMarkdownV2)
+	if isBenignEditError(err) || isMessageGoneError(err) {
+		return c.Respond()
+	}
+	return err
+}
+
+func (b *Bot) buildUsersPage(ctx context.Context, lang string, offset int) (string, [][]telebot.InlineButton, error) {
+	users, err := b.userRepo.ListAll(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	text, hasNext := formatUsersPage(b.tr.T(lang, "users.header", len(users)), b.tr.T(lang, "users.role_admin"), users, offset, usersPageSize)
+
+	var navRow []telebot.InlineButton
+	if offset > 0 {
+		prevOffset := offset - usersPageSize
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.prev"), Data: b.callbackData(fmt.Sprintf("%s%d", usersPagePrefix, prevOffset))})
+	}
+	if hasNext {
+		navRow = append(navRow, telebot.InlineButton{Text: b.tr.T(lang, "common.next"), Data: b.callbackData(fmt.Sprintf("%s%d", usersPagePrefix, offset+usersPageSize))})
+	}
+	var keyboard [][]telebot.InlineButton
+	if len(navRow) > 0 {
+		keyboard = append(keyboard, navRow)
+	}
+	return text, keyboard, nil
+}
+
+// formatUsersPage renders users[offset:offset+limit] as a MarkdownV2 list and
+// reports whether more users follow.
+func formatUsersPage(header, adminBadge string, users []*models.User, offset, limit int) (string, bool) {
+	if offset > len(users) {
+		offset = len(users)
+	}
+	end := offset + limit
+	if end > len(users) {
+		end = len(users)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("*%s*\n", escapeMarkdown(header)))
+	for _, user := range users[offset:end] {
+		name := "@" + user.Username
+		if user.Username == "" {
+			name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		}
+		line := fmt.Sprintf("• `%d` %s", user.ID, escapeMarkdown(name))
+		if user.IsAdmin {
+			line += " 👑 _" + escapeMarkdown(adminBadge) + "_"
+		}
+		builder.WriteString(line + "\n")
+	}
+	return builder.String(), end < len(users)
+}
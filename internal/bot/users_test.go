@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestFormatUsersPage(t *testing.T) {
+	users := []*models.User{
+		{Username: "alice_ops", IsAdmin: true},
+		{FirstName: "Bob", LastName: "Smith"},
+		{Username: "carol"},
+	}
+	for i, user := range users {
+		user.ID = uint(i + 1)
+	}
+
+	text, hasNext := formatUsersPage("Users (3):", "admin", users, 0, 2)
+	want := "*Users \\(3\\):*\n" +
+		"• `1` @alice\\_ops 👑 _admin_\n" +
+		"• `2` Bob Smith\n"
+	if text != want || !hasNext {
+		t.Errorf("first page = %q, %v; want %q, true", text, hasNext, want)
+	}
+
+	text, hasNext = formatUsersPage("Users (3):", "admin", users, 2, 2)
+	if want := "*Users \\(3\\):*\n• `3` @carol\n"; text != want || hasNext {
+		t.Errorf("last page = %q, %v; want %q, false", text, hasNext, want)
+	}
+
+	text, hasNext = formatUsersPage("Users (3):", "admin", users, 10, 2)
+	if want := "*Users \\(3\\):*\n"; text != want || hasNext {
+		t.Errorf("page past the end = %q, %v; want %q, false", text, hasNext, want)
+	}
+}
+
+func TestUsersRequiresAdmin(t *testing.T) {
+	tb := newTestBot(t)
+	admin := tb.user(t, 1, "en")
+	if err := tb.users.SetAdmin(context.Background(), admin.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	admin.IsAdmin = true
+	member := tb.user(t, 2, "en")
+
+	c := newCommandContext(member)
+	if err := tb.handleUsers(c); err != nil {
+		t.Fatal(err)
+	}
+	if want := "This command is available to admins only."; len(c.sent) != 1 || c.sent[0] != want {
+		t.Errorf("non-admin got %q, want %q", c.sent, want)
+	}
+
+	page := newCallbackContext(usersPagePrefix+"0", member)
+	if err := tb.handleUsersPage(page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.edits) != 0 || page.lastResponse() != "This command is available to admins only." {
+		t.Errorf("non-admin paging: edits %q, response %q", page.edits, page.lastResponse())
+	}
+
+	c = newCommandContext(admin)
+	if err := tb.handleUsers(c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.sent) != 1 || !strings.HasPrefix(c.sent[0], "*Users \\(2\\):*\n") || !strings.Contains(c.sent[0], "@user1 👑 _admin_") {
+		t.Errorf("admin got %q, want the user list", c.sent)
+	}
+}
+
+func TestWhoami(t *testing.T) {
+	tb := newTestBot(t)
+	user := tb.user(t, 42, "en")
+
+	c := newCommandContext(user)
+	if err := tb.handleWhoami(c); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"*Your account*\n", "Telegram ID: `42`\n", "Username: @user42\n", "Role: user\n"} {
+		if !strings.Contains(c.sent[0], want) {
+			t.Errorf("whoami = %q, want it to contain %q", c.sent[0], want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/telebot.v3"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+// TestIncidentViewsSurviveRestart checks that views registered by one process are
+// found by the next one, which starts with an empty registry.
+func TestIncidentViewsSurviveRestart(t *testing.T) {
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := service.NewIncidentService(repo, users, nil, nil, nil, nil, nil, nil, nil, nil, nil, testutil.DiscardLogger())
+	incident := &models.Incident{Fingerprint: "fp-views", Status: models.StatusActive}
+	if err := repo.Create(context.Background(), incident); err != nil {
+		t.Fatal(err)
+	}
+	newBot := func() *Bot {
+		return &Bot{service: svc, viewRegistry: make(map[uint]map[string]telebot.Editable), logger: testutil.DiscardLogger()}
+	}
+
+	alert := &telebot.Message{ID: 10, Chat: &telebot.Chat{ID: -100}}
+	resolved := &telebot.Message{ID: 20, Chat: &telebot.Chat{ID: -200}}
+	first := newBot()
+	first.addIncidentView(incident.ID, alert, models.TelegramMessagePrimary)
+	first.addIncidentView(incident.ID, resolved, models.TelegramMessageResolved)
+
+	second := newBot()
+	views := second.incidentViews(incident.ID)
+	for _, key := range []string{getViewRegistryKey(alert), getViewRegistryKey(resolved)} {
+		if _, ok := views[key]; !ok {
+			t.Errorf("views after restart = %v, want %s", keys(views), key)
+		}
+	}
+
+	second.removeIncidentView(incident.ID)
+	if got := len(second.incidentViews(incident.ID)); got != 2 {
+		t.Errorf("views after clearing the cache = %d, want 2", got)
+	}
+
+	second.forgetIncidentView(incident.ID, alert)
+	views = newBot().incidentViews(incident.ID)
+	if _, ok := views[getViewRegistryKey(alert)]; ok {
+		t.Errorf("forgotten view %s is still persisted", getViewRegistryKey(alert))
+	}
+	if len(views) != 1 {
+		t.Errorf("views after forget = %v, want only %s", keys(views), getViewRegistryKey(resolved))
+	}
+}
+
+func keys(views map[string]telebot.Editable) []string {
+	result := make([]string, 0, len(views))
+	for key := range views {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// envPrefix is the prefix of environment variables overriding config file values.
+const envPrefix = "FENRIR_"
+
+type envOverride struct {
+	name  string
+	apply func(value string) error
+}
+
+func stringVar(field *string) func(string) error {
+	return func(value string) error {
+		*field = value
+		return nil
+	}
+}
+
+func int64Var(field *int64) func(string) error {
+	return func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*field = n
+		return nil
+	}
+}
+
+func intVar(field *int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*field = n
+		return nil
+	}
+}
+
+// optionalIntVar sets a field whose absence differs from zero.
+func optionalIntVar(field **int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*field = &n
+		return nil
+	}
+}
+
+func boolVar(field *bool) func(string) error {
+	return func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		*field = b
+		return nil
+	}
+}
+
+func (c *Config) envOverrides() []envOverride {
+	return []envOverride{
+		{"DB_DRIVER", stringVar(&c.DB.Driver)},
+		{"DB_DSN", stringVar(&c.DB.DSN)},
+		{"DB_MAX_OPEN_CONNS", intVar(&c.DB.MaxOpenConns)},
+		{"DB_MAX_IDLE_CONNS", intVar(&c.DB.MaxIdleConns)},
+		{"DB_CONN_MAX_LIFETIME", int64Var(&c.DB.ConnMaxLifetime)},
+		{"DB_PING_INTERVAL", int64Var(&c.DB.PingInterval)},
+
+		{"APP_PORT", stringVar(&c.Server.AppPort)},
+		{"ALERT_PORT", stringVar(&c.Server.AlertPort)},
+		{"WEBHOOK_TOKEN", stringVar(&c.Server.WebhookToken)},
+		{"API_TOKEN", stringVar(&c.Server.APIToken)},
+		{"REQUEST_TIMEOUT", int64Var(&c.Server.RequestTimeout)},
+
+		{"EXECUTOR_USE_MOCK", boolVar(&c.Executor.UseMock)},
+		{"EXECUTOR_BASE_URL", stringVar(&c.Executor.BaseURL)},
+		{"EXECUTOR_RETRY_COUNT", optionalIntVar(&c.Executor.RetryCount)},
+		{"EXECUTOR_RETRY_BASE_DELAY_MS", int64Var(&c.Executor.RetryBaseDelayMs)},
+		{"EXECUTOR_TIMEOUT", int64Var(&c.Executor.Timeout)},
+		{"EXECUTOR_AUTH_TOKEN", stringVar(&c.Executor.AuthToken)},
+		{"EXECUTOR_AUTH_HEADER", stringVar(&c.Executor.AuthHeader)},
+		{"EXECUTOR_MAX_CONCURRENT_ACTIONS", intVar(&c.Executor.MaxConcurrentActions)},
+
+		{"TELEGRAM_BOT_TOKEN", stringVar(&c.Telegram.BotToken)},
+		{"TELEGRAM_ALERT_CHANNEL_ID", int64Var(&c.Telegram.AlertChannelID)},
+		{"TELEGRAM_RESOLVED_CHANNEL_ID", int64Var(&c.Telegram.ResolvedChannelID)},
+		{"TELEGRAM_ESCALATION_CHANNEL_ID", int64Var(&c.Telegram.EscalationChannelID)},
+		{"TELEGRAM_HISTORY_PAGE_SIZE", intVar(&c.Telegram.HistoryPageSize)},
+		{"TELEGRAM_LANGUAGE", stringVar(&c.Telegram.Language)},
+		{"TELEGRAM_SEVERITY_LABEL", stringVar(&c.Telegram.SeverityLabel)},
+		{"TELEGRAM_DISABLE_TOPICS", boolVar(&c.Telegram.DisableTopics)},
+		{"TELEGRAM_MESSAGE_TEMPLATE_FILE", stringVar(&c.Telegram.MessageTemplateFile)},
+
+		{"TOPIC_DELETION_INTERVAL", int64Var(&c.IncidentService.TopicDeletionInterval)},
+		{"TOPIC_MAX_AGE", int64Var(&c.IncidentService.TopicMaxAge)},
+		{"ESCALATION_CHECK_INTERVAL", int64Var(&c.IncidentService.EscalationCheckInterval)},
+		{"ESCALATION_TIMEOUT", int64Var(&c.IncidentService.EscalationTimeout)},
+		{"SNOOZE_CHECK_INTERVAL", int64Var(&c.IncidentService.SnoozeCheckInterval)},
+		{"REMINDER_INTERVAL", int64Var(&c.IncidentService.ReminderInterval)},
+		{"REMINDER_CHECK_INTERVAL", int64Var(&c.IncidentService.ReminderCheckInterval)},
+		{"ARCHIVE_MAX_AGE", int64Var(&c.IncidentService.ArchiveMaxAge)},
+		{"ARCHIVE_INTERVAL", int64Var(&c.IncidentService.ArchiveInterval)},
+		{"ARCHIVE_KEEP_AUDIT_RECORDS", boolVar(&c.IncidentService.ArchiveKeepAuditRecords)},
+		{"ARCHIVE_DRY_RUN", boolVar(&c.IncidentService.ArchiveDryRun)},
+		{"MIN_SEVERITY", stringVar(&c.IncidentService.MinSeverity)},
+		{"SILENCE_CLEANUP_INTERVAL", int64Var(&c.IncidentService.SilenceCleanupInterval)},
+		{"QUIET_HOURS_START", stringVar(&c.IncidentService.QuietHours.Start)},
+		{"QUIET_HOURS_END", stringVar(&c.IncidentService.QuietHours.End)},
+		{"QUIET_HOURS_TIMEZONE", stringVar(&c.IncidentService.QuietHours.Timezone)},
+		{"QUIET_HOURS_RELEASE_INTERVAL", int64Var(&c.IncidentService.QuietHours.ReleaseInterval)},
+
+		{"ACTIONS_LOG_TAIL_LINES", intVar(&c.Actions.LogTailLines)},
+		{"ACTIONS_MAX_REPLICAS", intVar(&c.Actions.MaxReplicas)},
+
+		{"SUGGESTIONS_RULES_FILE", stringVar(&c.Suggestions.RulesFile)},
+		{"SLACK_WEBHOOK_URL", stringVar(&c.Slack.WebhookURL)},
+		{"OUTBOUND_WEBHOOK_URL", stringVar(&c.OutboundWebhook.URL)},
+		{"OUTBOUND_WEBHOOK_SECRET", stringVar(&c.OutboundWebhook.Secret)},
+		{"OUTBOUND_WEBHOOK_TIMEOUT", int64Var(&c.OutboundWebhook.Timeout)},
+
+		{"LOG_LEVEL", stringVar(&c.Logging.Level)},
+		{"LOG_FORMAT", stringVar(&c.Logging.Format)},
+	}
+}
+
+// applyEnvOverrides sets every config field that has a FENRIR_* variable in the
+// environment. Malformed values are reported together.
+func (c *Config) applyEnvOverrides() error {
+	var errs []error
+	for _, override := range c.envOverrides() {
+		name := envPrefix + override.name
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := override.apply(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseConfig = `{
+	"db": {"driver": "sqlite", "dsn": "file.db"},
+	"server": {"app_port": "8080", "alert_port": "8081", "webhook_token": "file-token"},
+	"executor": {"base_url": "http://file-executor", "retry_count": 2},
+	"telegram": {"alert_channel_id": -100, "bot_token": "file-bot"},
+	"incident_service": {"topic_deletion_interval": 60}
+}`
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv("FENRIR_DB_DSN", "postgres://env")
+	t.Setenv("FENRIR_APP_PORT", "9090")
+	t.Setenv("FENRIR_WEBHOOK_TOKEN", "env-token")
+	t.Setenv("FENRIR_EXECUTOR_BASE_URL", "http://env-executor")
+	t.Setenv("FENRIR_EXECUTOR_USE_MOCK", "true")
+	t.Setenv("FENRIR_TELEGRAM_ALERT_CHANNEL_ID", "-1001234567890")
+	t.Setenv("FENRIR_TOPIC_DELETION_INTERVAL", "300")
+
+	cfg, err := Load(writeConfig(t, baseConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"db.dsn", cfg.DB.DSN, "postgres://env"},
+		{"server.app_port", cfg.Server.AppPort, "9090"},
+		{"server.webhook_token", cfg.Server.WebhookToken, "env-token"},
+		{"executor.base_url", cfg.Executor.BaseURL, "http://env-executor"},
+		{"executor.use_mock", cfg.Executor.UseMock, true},
+		{"telegram.alert_channel_id", cfg.Telegram.AlertChannelID, int64(-1001234567890)},
+		{"incident_service.topic_deletion_interval", cfg.IncidentService.TopicDeletionInterval, int64(300)},
+		// Not overridden: the file values stay.
+		{"server.alert_port", cfg.Server.AlertPort, "8081"},
+		{"executor.retry_count", *cfg.Executor.RetryCount, 2},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadEnvPrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "file", want: "file-bot"},
+		{name: "legacy variable", env: map[string]string{"TELEGRAM_BOT_TOKEN": "legacy-bot"}, want: "legacy-bot"},
+		{
+			name: "prefixed variable wins",
+			env:  map[string]string{"TELEGRAM_BOT_TOKEN": "legacy-bot", "FENRIR_TELEGRAM_BOT_TOKEN": "prefixed-bot"},
+			want: "prefixed-bot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			cfg, err := Load(writeConfig(t, baseConfig))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Telegram.BotToken != tt.want {
+				t.Errorf("bot token = %q, want %q", cfg.Telegram.BotToken, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMalformedEnvOverrides(t *testing.T) {
+	t.Setenv("FENRIR_TELEGRAM_ALERT_CHANNEL_ID", "channel")
+	t.Setenv("FENRIR_EXECUTOR_USE_MOCK", "maybe")
+
+	_, err := Load(writeConfig(t, baseConfig))
+	if err == nil {
+		t.Fatal("Load() = nil error, want malformed overrides reported")
+	}
+	for _, want := range []string{"FENRIR_TELEGRAM_ALERT_CHANNEL_ID", "FENRIR_EXECUTOR_USE_MOCK"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate checks the configuration for missing or nonsensical values and reports
+// every problem found, joined into a single error.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.DB.Driver {
+	case "", "sqlite", "postgres":
+	default:
+		add("db.driver: unsupported driver %q (expected \"sqlite\" or \"postgres\")", c.DB.Driver)
+	}
+	if c.DB.DSN == "" {
+		add("db.dsn is required")
+	}
+	for _, field := range []struct {
+		name  string
+		value int64
+	}{
+		{"max_open_conns", int64(c.DB.MaxOpenConns)},
+		{"max_idle_conns", int64(c.DB.MaxIdleConns)},
+		{"conn_max_lifetime", c.DB.ConnMaxLifetime},
+		{"ping_interval", c.DB.PingInterval},
+	} {
+		if field.value < 0 {
+			add("db.%s must not be negative", field.name)
+		}
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		add("db.max_idle_conns must not exceed max_open_conns")
+	}
+
+	if err := validatePort(c.Server.AppPort); err != nil {
+		add("server.app_port: %v", err)
+	}
+	if err := validatePort(c.Server.AlertPort); err != nil {
+		add("server.alert_port: %v", err)
+	}
+	if c.Server.RequestTimeout < 0 {
+		add("server.request_timeout must not be negative")
+	}
+
+	if !c.Executor.UseMock {
+		if err := validateHTTPURL(c.Executor.BaseURL); err != nil {
+			add("executor.base_url: %v", err)
+		}
+	}
+	if c.Executor.RetryCount != nil && *c.Executor.RetryCount < 0 {
+		add("executor.retry_count must not be negative")
+	}
+	if c.Executor.RetryBaseDelayMs < 0 {
+		add("executor.retry_base_delay_ms must not be negative")
+	}
+	if c.Executor.Timeout < 0 {
+		add("executor.timeout must not be negative")
+	}
+	for action, timeout := range c.Executor.ActionTimeouts {
+		if timeout <= 0 {
+			add("executor.action_timeouts.%s must be positive", action)
+		}
+	}
+
+	if c.Telegram.HistoryPageSize < 0 {
+		add("telegram.history_page_size must not be negative")
+	}
+	for i, route := range c.Telegram.Routes {
+		if len(route.Match) == 0 {
+			add("telegram.routes[%d].match must not be empty", i)
+		}
+		if route.ChannelID == 0 {
+			add("telegram.routes[%d].channel_id is required", i)
+		}
+	}
+
+	svc := c.IncidentService
+	if svc.TopicDeletionInterval > 0 {
+		if svc.TopicMaxAge <= 0 {
+			add("incident_service.topic_max_age must be positive when topic deletion is enabled")
+		} else if svc.ArchiveMaxAge > 0 && svc.ArchiveMaxAge <= svc.TopicMaxAge {
+			// Archived incidents are no longer found by the topic deletion job.
+			add("incident_service.archive_max_age must be greater than topic_max_age")
+		}
+	}
+	for _, field := range []struct {
+		name  string
+		value int64
+	}{
+		{"topic_deletion_interval", svc.TopicDeletionInterval},
+		{"topic_max_age", svc.TopicMaxAge},
+		{"escalation_check_interval", svc.EscalationCheckInterval},
+		{"escalation_timeout", svc.EscalationTimeout},
+		{"snooze_check_interval", svc.SnoozeCheckInterval},
+		{"reminder_interval", svc.ReminderInterval},
+		{"reminder_check_interval", svc.ReminderCheckInterval},
+		{"archive_max_age", svc.ArchiveMaxAge},
+		{"archive_interval", svc.ArchiveInterval},
+		{"silence_cleanup_interval", svc.SilenceCleanupInterval},
+		{"quiet_hours.release_interval", svc.QuietHours.ReleaseInterval},
+	} {
+		if field.value < 0 {
+			add("incident_service.%s must not be negative", field.name)
+		}
+	}
+
+	if svc.MinSeverity != "" {
+		order := svc.SeverityOrder
+		if len(order) == 0 {
+			order = DefaultSeverityOrder
+		}
+		if !containsFold(order, svc.MinSeverity) {
+			add("incident_service.min_severity %q is not listed in severity_order", svc.MinSeverity)
+		}
+	}
+
+	if quiet := svc.QuietHours; quiet.Start != "" || quiet.End != "" {
+		for _, field := range []struct{ name, value string }{{"start", quiet.Start}, {"end", quiet.End}} {
+			if _, err := time.Parse("15:04", field.value); err != nil {
+				add("incident_service.quiet_hours.%s: expected HH:MM, got %q", field.name, field.value)
+			}
+		}
+		if quiet.Start == quiet.End {
+			add("incident_service.quiet_hours.start and end must differ")
+		}
+		if _, err := time.LoadLocation(quiet.Timezone); err != nil {
+			add("incident_service.quiet_hours.timezone: %v", err)
+		}
+	}
+
+	severities := make([]string, 0, len(svc.EscalationThresholds))
+	for severity := range svc.EscalationThresholds {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+	for _, severity := range severities {
+		thresholds := svc.EscalationThresholds[severity]
+		for i, threshold := range thresholds {
+			if threshold <= 0 {
+				add("incident_service.escalation_thresholds[%q] must contain positive values", severity)
+				break
+			}
+			if i > 0 && threshold <= thresholds[i-1] {
+				add("incident_service.escalation_thresholds[%q] must be in ascending order", severity)
+				break
+			}
+		}
+	}
+
+	if c.OutboundWebhook.URL != "" {
+		if err := validateHTTPURL(c.OutboundWebhook.URL); err != nil {
+			add("outbound_webhook.url: %v", err)
+		}
+	}
+	if c.Slack.WebhookURL != "" {
+		if err := validateHTTPURL(c.Slack.WebhookURL); err != nil {
+			add("slack.webhook_url: %v", err)
+		}
+	}
+
+	switch strings.ToLower(c.Logging.Level) {
+	case "", "debug", "info", "warn", "error":
+	default:
+		add("logging.level: unsupported level %q", c.Logging.Level)
+	}
+	switch strings.ToLower(c.Logging.Format) {
+	case "", "text", "json":
+	default:
+		add("logging.format: unsupported format %q (expected \"text\" or \"json\")", c.Logging.Format)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePort(port string) error {
+	if port == "" {
+		return errors.New("is required")
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+func validateHTTPURL(raw string) error {
+	if raw == "" {
+		return errors.New("is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute http(s) URL", raw)
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
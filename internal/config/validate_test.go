@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// validConfig returns a configuration that passes Validate.
+func validConfig() *Config {
+	return &Config{
+		DB:       DBConfig{Driver: "sqlite", DSN: "chatops.db"},
+		Server:   ServerConfig{AppPort: "8080", AlertPort: "8081"},
+		Executor: ExecutorConfig{BaseURL: "http://executor:8080"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(c *Config) {}},
+		{name: "unsupported driver", mutate: func(c *Config) { c.DB.Driver = "mysql" }, wantErr: "db.driver"},
+		{name: "missing dsn", mutate: func(c *Config) { c.DB.DSN = "" }, wantErr: "db.dsn is required"},
+		{name: "negative pool size", mutate: func(c *Config) { c.DB.MaxOpenConns = -1 }, wantErr: "db.max_open_conns"},
+		{name: "idle above open", mutate: func(c *Config) { c.DB.MaxOpenConns, c.DB.MaxIdleConns = 2, 5 }, wantErr: "db.max_idle_conns"},
+		{name: "empty app port", mutate: func(c *Config) { c.Server.AppPort = "" }, wantErr: "server.app_port"},
+		{name: "port out of range", mutate: func(c *Config) { c.Server.AlertPort = "70000" }, wantErr: "server.alert_port"},
+		{name: "missing base url", mutate: func(c *Config) { c.Executor.BaseURL = "" }, wantErr: "executor.base_url"},
+		{name: "base url without scheme", mutate: func(c *Config) { c.Executor.BaseURL = "executor:8080" }, wantErr: "executor.base_url"},
+		{name: "mock needs no base url", mutate: func(c *Config) { c.Executor.UseMock, c.Executor.BaseURL = true, "" }},
+		{name: "negative retry count", mutate: func(c *Config) { n := -1; c.Executor.RetryCount = &n }, wantErr: "executor.retry_count"},
+		{name: "zero action timeout", mutate: func(c *Config) { c.Executor.ActionTimeouts = map[string]int64{"restart_deployment": 0} }, wantErr: "executor.action_timeouts.restart_deployment"},
+		{name: "negative interval", mutate: func(c *Config) { c.IncidentService.EscalationCheckInterval = -5 }, wantErr: "incident_service.escalation_check_interval"},
+		{
+			name:    "topic deletion without max age",
+			mutate:  func(c *Config) { c.IncidentService.TopicDeletionInterval = 60 },
+			wantErr: "incident_service.topic_max_age must be positive",
+		},
+		{
+			name: "archive before topic deletion",
+			mutate: func(c *Config) {
+				c.IncidentService.TopicDeletionInterval, c.IncidentService.TopicMaxAge, c.IncidentService.ArchiveMaxAge = 60, 3600, 600
+			},
+			wantErr: "archive_max_age must be greater than topic_max_age",
+		},
+		{name: "unknown min severity", mutate: func(c *Config) { c.IncidentService.MinSeverity = "P1" }, wantErr: "min_severity"},
+		{
+			name: "escalation thresholds out of order",
+			mutate: func(c *Config) {
+				c.IncidentService.EscalationThresholds = map[string][]int64{"critical": {600, 300}}
+			},
+			wantErr: "ascending order",
+		},
+		{name: "route without match", mutate: func(c *Config) { c.Telegram.Routes = []AlertRoute{{ChannelID: -100}} }, wantErr: "telegram.routes[0].match"},
+		{name: "bad quiet hours", mutate: func(c *Config) {
+			c.IncidentService.QuietHours.Start, c.IncidentService.QuietHours.End = "25:00", "07:00"
+		}, wantErr: "quiet_hours.start"},
+		{name: "bad log level", mutate: func(c *Config) { c.Logging.Level = "trace" }, wantErr: "logging.level"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB.DSN = ""
+	cfg.Server.AppPort = ""
+	cfg.Executor.BaseURL = ""
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+	for _, want := range []string{"db.dsn", "server.app_port", "executor.base_url"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
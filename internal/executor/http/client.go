@@ -1,71 +1,252 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"chatops-bot/internal/config"
 	"chatops-bot/internal/models"
 )
 
+const (
+	defaultRetryCount     = 3
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultTimeout        = 10 * time.Second
+	pingTimeout           = 2 * time.Second
+)
+
+var defaultActionTimeouts = map[models.ActionType]time.Duration{
+	models.ActionGetPodLogs:         60 * time.Second,
+	models.ActionDescribePod:        30 * time.Second,
+	models.ActionDescribeDeployment: 30 * time.Second,
+	models.ActionDescribeNode:       30 * time.Second,
+	models.ActionExecInPod:          30 * time.Second,
+}
+
 type ExecutorClient struct {
-	client  *http.Client
-	baseURL string
+	client         *http.Client
+	baseURL        string
+	retryCount     int
+	retryBaseDelay time.Duration
+	timeout        time.Duration
+	actionTimeouts map[models.ActionType]time.Duration
+	authToken      string
+	authHeader     string
 }
 
-func NewExecutorClient(baseURL string) *ExecutorClient {
+func NewExecutorClient(cfg config.ExecutorConfig) *ExecutorClient {
+	retryCount := defaultRetryCount
+	if cfg.RetryCount != nil {
+		retryCount = max(*cfg.RetryCount, 0)
+	}
+	retryBaseDelay := time.Duration(cfg.RetryBaseDelayMs) * time.Millisecond
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	actionTimeouts := make(map[models.ActionType]time.Duration)
+	for action, t := range defaultActionTimeouts {
+		actionTimeouts[action] = t
+	}
+	for action, t := range cfg.ActionTimeouts {
+		if t > 0 {
+			actionTimeouts[models.ActionType(action)] = time.Duration(t) * time.Second
+		}
+	}
 	return &ExecutorClient{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: baseURL,
+		client:         &http.Client{},
+		baseURL:        cfg.BaseURL,
+		retryCount:     retryCount,
+		retryBaseDelay: retryBaseDelay,
+		timeout:        timeout,
+		actionTimeouts: actionTimeouts,
+		authToken:      cfg.AuthToken,
+		authHeader:     cfg.AuthHeader,
+	}
+}
+
+func (c *ExecutorClient) setAuthHeader(req *http.Request) {
+	if c.authToken == "" {
+		return
+	}
+	if c.authHeader == "" || strings.EqualFold(c.authHeader, "Authorization") {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+		return
+	}
+	req.Header.Set(c.authHeader, c.authToken)
+}
+
+func (c *ExecutorClient) timeoutFor(action models.ActionType) time.Duration {
+	if t, ok := c.actionTimeouts[action]; ok {
+		return t
 	}
+	return c.timeout
 }
 
-func (c *ExecutorClient) ExecuteAction(req models.ActionRequest) models.ActionResult {
-	switch models.ActionType(req.Action) {
+// doWithRetry retries idempotent requests on transport errors and 5xx responses
+// using exponential backoff with jitter, up to retryCount times after the first
+// attempt. Non-idempotent requests (e.g. POST rollback) are sent exactly once. A
+// retried DELETE that gets 404 is reported as a success.
+func (c *ExecutorClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	c.setAuthHeader(req)
+	if !isIdempotent(req.Method) {
+		return c.client.Do(req)
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= c.retryCount; attempt++ {
+		if attempt > 0 {
+			delay := c.retryBaseDelay << (attempt - 1)
+			delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+			slog.Warn("Retrying executor request", "method", req.Method, "url", req.URL.String(), "attempt", attempt+1, "max_attempts", c.retryCount+1, "delay", delay)
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(delay):
+			}
+			if req.GetBody != nil {
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return nil, bodyErr
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err = c.client.Do(req)
+		if err == nil && attempt > 0 && req.Method == http.MethodDelete && resp.StatusCode == http.StatusNotFound {
+			// An earlier attempt most likely deleted the resource and only its
+			// response was lost, so the delete has succeeded.
+			resp.Body.Close()
+			slog.Info("Retried delete found the resource already gone", "url", req.URL.String(), "attempt", attempt+1)
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: http.NoBody, Request: req}, nil
+		}
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+		if attempt < c.retryCount && resp != nil {
+			resp.Body.Close()
+		}
+	}
+	return resp, err
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExecuteAction runs req against the executor. The per-action timeout is applied on
+// top of ctx, so cancelling ctx aborts the in-flight request.
+func (c *ExecutorClient) ExecuteAction(ctx context.Context, req models.ActionRequest) models.ActionResult {
+	actionType := models.ActionType(req.Action)
+	timeout := c.timeoutFor(actionType)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var res models.ActionResult
+	var err error
+	switch actionType {
 	case models.ActionGetDeploymentInfo:
-		res, _ := c.getDeploymentInfo(context.Background(), req)
-		return res
+		res, err = c.getDeploymentInfo(ctx, req)
 	case models.ActionDeletePod:
-		res, _ := c.restartPod(context.Background(), req)
-		return res
+		res, err = c.restartPod(ctx, req)
 	case models.ActionScaleDeployment:
-		res, _ := c.scaleDeployment(context.Background(), req)
-		return res
+		res, err = c.scaleDeployment(ctx, req)
 	case models.ActionListPodsForDeployment:
-		res, _ := c.listPodsByDeployment(context.Background(), req)
-		return res
+		res, err = c.listPodsByDeployment(ctx, req)
 	case models.ActionGetPodLogs:
-		res, _ := c.getPodLogs(context.Background(), req)
-		return res
+		res, err = c.getPodLogs(ctx, req)
 	case models.ActionDescribePod:
-		res, _ := c.describePod(context.Background(), req)
-		return res
+		res, err = c.describePod(ctx, req)
 	case models.ActionDescribeDeployment:
-		res, _ := c.describeDeployment(context.Background(), req)
-		return res
+		res, err = c.describeDeployment(ctx, req)
 	case models.ActionRollbackDeployment:
-		res, _ := c.rollbackDeployment(context.Background(), req)
-		return res
+		res, err = c.rollbackDeployment(ctx, req)
+	case models.ActionExecInPod:
+		res, err = c.execInPod(ctx, req)
+	case models.ActionRestartDeployment:
+		res, err = c.restartDeployment(ctx, req)
+	case models.ActionPauseRollout:
+		res, err = c.rolloutOperation(ctx, req, "pause", "Deployment rollout paused successfully")
+	case models.ActionResumeRollout:
+		res, err = c.rolloutOperation(ctx, req, "resume", "Deployment rollout resumed successfully")
+	case models.ActionCordonNode:
+		res, err = c.nodeOperation(ctx, req, "cordon", "Node cordoned successfully")
+	case models.ActionUncordonNode:
+		res, err = c.nodeOperation(ctx, req, "uncordon", "Node uncordoned successfully")
+	case models.ActionDrainNode:
+		res, err = c.nodeOperation(ctx, req, "drain", "Node drained successfully")
+	case models.ActionDescribeNode:
+		res, err = c.describeNode(ctx, req)
 	default:
 		return models.ActionResult{Error: "unsupported action"}
 	}
+
+	if errors.Is(err, context.Canceled) {
+		slog.Warn("Executor action cancelled", "action", req.Action, "incident_id", req.IncidentID)
+		return models.ActionResult{Error: fmt.Sprintf("%s on %s: request cancelled", req.Action, describeTarget(req))}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.Error("Executor action timed out", "action", req.Action, "incident_id", req.IncidentID, "timeout", timeout)
+		return models.ActionResult{Error: fmt.Sprintf("%s on %s: executor request timed out after %s", req.Action, describeTarget(req), timeout)}
+	}
+	if err != nil {
+		slog.Error("Executor action failed", "action", req.Action, "incident_id", req.IncidentID, "error", err)
+		return models.ActionResult{Error: fmt.Sprintf("%s on %s: %v", req.Action, describeTarget(req), err)}
+	}
+	if res.Error != "" {
+		res.Error = fmt.Sprintf("%s on %s: %s", req.Action, describeTarget(req), res.Error)
+	}
+	return res
+}
+
+func describeTarget(req models.ActionRequest) string {
+	if pod, ok := req.Parameters["pod_name"]; ok {
+		return fmt.Sprintf("pod %s", pod)
+	}
+	if pod, ok := req.Parameters["pod"]; ok {
+		return fmt.Sprintf("pod %s", pod)
+	}
+	if deployment, ok := req.Parameters["deployment"]; ok {
+		return fmt.Sprintf("deployment %s", deployment)
+	}
+	if node, ok := req.Parameters["node"]; ok {
+		return fmt.Sprintf("node %s", node)
+	}
+	return "unknown resource"
 }
 
 func (c *ExecutorClient) restartPod(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/pods/%s", c.baseURL, req.Parameters["namespace"], req.Parameters["pod_name"])
-	log.Printf("ExecutorClient: restarting pod with URL: %s", url)
+	slog.Debug("Executor request", "operation", "restarting pod", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -80,13 +261,13 @@ func (c *ExecutorClient) restartPod(ctx context.Context, req models.ActionReques
 
 func (c *ExecutorClient) scaleDeployment(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s?replicas=%s", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"], req.Parameters["replicas"])
-	log.Printf("ExecutorClient: scaling deployment with URL: %s", url)
+	slog.Debug("Executor request", "operation", "scaling deployment", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -101,13 +282,13 @@ func (c *ExecutorClient) scaleDeployment(ctx context.Context, req models.ActionR
 
 func (c *ExecutorClient) getPodInfo(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/pods/%s", c.baseURL, req.Parameters["namespace"], req.Parameters["pod_name"])
-	log.Printf("ExecutorClient: getting pod info with URL: %s", url)
+	slog.Debug("Executor request", "operation", "getting pod info", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -140,13 +321,13 @@ func (c *ExecutorClient) getPodInfo(ctx context.Context, req models.ActionReques
 
 func (c *ExecutorClient) listPodsByDeployment(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/pods?deployment=%s", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"])
-	log.Printf("ExecutorClient: listing pods with URL: %s", url)
+	slog.Debug("Executor request", "operation", "listing pods", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -172,23 +353,27 @@ func (c *ExecutorClient) listPodsByDeployment(ctx context.Context, req models.Ac
 	}, nil
 }
 
-func (c *ExecutorClient) GetResourceDetails(req models.ResourceDetailsRequest) (*models.ResourceDetails, error) {
+func (c *ExecutorClient) GetResourceDetails(ctx context.Context, req models.ResourceDetailsRequest) (*models.ResourceDetails, error) {
 	var url string
 	if req.ResourceType == "pod" {
 		url = fmt.Sprintf("%s/api/kubernetes/%s/pods/%s", c.baseURL, req.Labels["namespace"], req.ResourceName)
 	} else if req.ResourceType == "deployment" {
 		url = fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s", c.baseURL, req.Labels["namespace"], req.ResourceName)
+	} else if req.ResourceType == "node" {
+		url = fmt.Sprintf("%s/api/kubernetes/nodes/%s", c.baseURL, req.ResourceName)
 	} else {
 		return nil, fmt.Errorf("unsupported resource type: %s", req.ResourceType)
 	}
 
-	log.Printf("ExecutorClient: getting resource details with URL: %s", url)
-	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	slog.Debug("Executor request", "operation", "getting resource details", "incident_id", req.IncidentID, "url", url)
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return nil, err
 	}
@@ -217,23 +402,60 @@ func (c *ExecutorClient) GetResourceDetails(req models.ResourceDetailsRequest) (
 			return nil, err
 		}
 		return &models.ResourceDetails{
-			Status:       "active", // Or some other status, as it's not in the response
-			ReplicasInfo: fmt.Sprintf("%d replicas", deployment.Replicas),
+			Status:            deploymentStatus(deployment),
+			ReplicasInfo:      fmt.Sprintf("%d/%d ready", deployment.ReadyReplicas, deployment.Replicas),
+			DesiredReplicas:   deployment.Replicas,
+			ReadyReplicas:     deployment.ReadyReplicas,
+			AvailableReplicas: deployment.AvailableReplicas,
+			UpdatedReplicas:   deployment.UpdatedReplicas,
+			Paused:            deployment.Paused,
+		}, nil
+	}
+
+	if req.ResourceType == "node" {
+		var node Node
+		if err := json.NewDecoder(resp.Body).Decode(&node); err != nil {
+			return nil, err
+		}
+		status := node.Status
+		if node.Unschedulable {
+			status += ",SchedulingDisabled"
+		}
+		return &models.ResourceDetails{
+			Status: status,
+			Age:    node.Age,
+			Node: &models.NodeResources{
+				CpuAllocatable:    node.CpuAllocatable,
+				MemoryAllocatable: node.MemoryAllocatable,
+				CpuUsage:          node.CpuUsage,
+				MemoryUsage:       node.MemoryUsage,
+				Pods:              node.Pods,
+				PodCapacity:       node.PodCapacity,
+			},
 		}, nil
 	}
 
 	return nil, fmt.Errorf("unsupported resource type: %s", req.ResourceType)
 }
 
+// deploymentStatus reports a deployment as degraded while fewer replicas are ready
+// than its spec asks for.
+func deploymentStatus(deployment Deployment) string {
+	if deployment.ReadyReplicas < deployment.Replicas {
+		return models.DeploymentDegraded
+	}
+	return models.DeploymentHealthy
+}
+
 func (c *ExecutorClient) getDeploymentInfo(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"])
-	log.Printf("ExecutorClient: getting deployment info with URL: %s", url)
+	slog.Debug("Executor request", "operation", "getting deployment info", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -256,7 +478,7 @@ func (c *ExecutorClient) getDeploymentInfo(ctx context.Context, req models.Actio
 			Items: []models.ResourceInfo{
 				{
 					Name:   deploymentInfo.Name,
-					Status: fmt.Sprintf("%d replicas", deploymentInfo.Replicas),
+					Status: fmt.Sprintf("%d/%d ready", deploymentInfo.ReadyReplicas, deploymentInfo.Replicas),
 				},
 			},
 		},
@@ -264,14 +486,20 @@ func (c *ExecutorClient) getDeploymentInfo(ctx context.Context, req models.Actio
 }
 
 func (c *ExecutorClient) getPodLogs(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	if tail, err := strconv.Atoi(req.Parameters["tail"]); err != nil || tail <= 0 {
+		return models.ActionResult{Error: fmt.Sprintf("invalid tail %q: must be a positive integer", req.Parameters["tail"])}, nil
+	}
 	url := fmt.Sprintf("%s/api/kubernetes/%s/pods/%s/logs?container=%s&tail=%s", c.baseURL, req.Parameters["namespace"], req.Parameters["pod_name"], req.Parameters["container"], req.Parameters["tail"])
-	log.Printf("ExecutorClient: getting pod logs with URL: %s", url)
+	if req.Parameters["previous"] == "true" {
+		url += "&previous=true"
+	}
+	slog.Debug("Executor request", "operation", "getting pod logs", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -301,15 +529,56 @@ func (c *ExecutorClient) getPodLogs(ctx context.Context, req models.ActionReques
 	}, nil
 }
 
+func (c *ExecutorClient) execInPod(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/%s/pods/%s/exec?container=%s", c.baseURL, req.Parameters["namespace"], req.Parameters["pod_name"], req.Parameters["container"])
+	slog.Debug("Executor request", "operation", "exec in pod", "incident_id", req.IncidentID, "command", req.Parameters["command"], "url", url)
+	payload, err := json.Marshal(ExecRequest{Command: req.Parameters["command"]})
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.ActionResult{Error: fmt.Sprintf("failed to exec in pod: status code %d", resp.StatusCode)}, nil
+	}
+
+	var execResult ExecResult
+	if err := json.NewDecoder(resp.Body).Decode(&execResult); err != nil {
+		return models.ActionResult{}, err
+	}
+
+	return models.ActionResult{
+		Message: fmt.Sprintf("Command exited with code %d", execResult.ExitCode),
+		ResultData: &models.ResultData{
+			Type:     "exec_output",
+			ItemType: "exec_output",
+			Items: []models.ResourceInfo{
+				{Name: "stdout", Status: execResult.Stdout},
+				{Name: "stderr", Status: execResult.Stderr},
+			},
+		},
+	}, nil
+}
+
 func (c *ExecutorClient) describePod(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/pods/%s/describe", c.baseURL, req.Parameters["namespace"], req.Parameters["pod_name"])
-	log.Printf("ExecutorClient: describing pod with URL: %s", url)
+	slog.Debug("Executor request", "operation", "describing pod", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -341,13 +610,13 @@ func (c *ExecutorClient) describePod(ctx context.Context, req models.ActionReque
 
 func (c *ExecutorClient) describeDeployment(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s/describe", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"])
-	log.Printf("ExecutorClient: describing deployment with URL: %s", url)
+	slog.Debug("Executor request", "operation", "describing deployment", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -377,15 +646,53 @@ func (c *ExecutorClient) describeDeployment(ctx context.Context, req models.Acti
 	}, nil
 }
 
+func (c *ExecutorClient) describeNode(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/nodes/%s/describe", c.baseURL, req.Parameters["node"])
+	slog.Debug("Executor request", "operation", "describing node", "incident_id", req.IncidentID, "url", url)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.ActionResult{Error: fmt.Sprintf("failed to describe node: status code %d", resp.StatusCode)}, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+
+	return models.ActionResult{
+		Message: "Node description retrieved successfully",
+		ResultData: &models.ResultData{
+			Type:     "node_description",
+			ItemType: "node_description",
+			Items: []models.ResourceInfo{
+				{
+					Name:   "description",
+					Status: string(body),
+				},
+			},
+		},
+	}, nil
+}
+
 func (c *ExecutorClient) rollbackDeployment(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
 	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s/rollback", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"])
-	log.Printf("ExecutorClient: rolling back deployment with URL: %s", url)
+	slog.Debug("Executor request", "operation", "rolling back deployment", "incident_id", req.IncidentID, "url", url)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
 
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.doWithRetry(httpReq)
 	if err != nil {
 		return models.ActionResult{}, err
 	}
@@ -398,15 +705,142 @@ func (c *ExecutorClient) rollbackDeployment(ctx context.Context, req models.Acti
 	return models.ActionResult{Message: "Deployment rolled back successfully"}, nil
 }
 
-func (c *ExecutorClient) GetAvailableResources() (*models.AvailableResources, error) {
-	// This is a mock implementation.
-	return &models.AvailableResources{
-		Profiles: []models.ResourceProfile{
-			{Name: "small", Description: "1 CPU, 2Gi RAM", IsDefault: true},
-			{Name: "medium", Description: "2 CPU, 4Gi RAM"},
-			{Name: "large", Description: "4 CPU, 8Gi RAM"},
-		},
-	}, nil
+// nodeOperation calls one of the node maintenance endpoints
+// (POST {base}/api/kubernetes/nodes/{name}/{cordon,uncordon,drain}).
+func (c *ExecutorClient) nodeOperation(ctx context.Context, req models.ActionRequest, operation, successMessage string) (models.ActionResult, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/nodes/%s/%s", c.baseURL, req.Parameters["node"], operation)
+	slog.Debug("Executor request", "operation", operation+" node", "incident_id", req.IncidentID, "url", url)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.ActionResult{Error: fmt.Sprintf("failed to %s node: status code %d", operation, resp.StatusCode)}, nil
+	}
+
+	return models.ActionResult{Message: successMessage}, nil
+}
+
+// restartDeployment triggers a rollout restart (equivalent of
+// `kubectl rollout restart`), replacing pods one by one without changing the spec.
+func (c *ExecutorClient) restartDeployment(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s/restart", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"])
+	slog.Debug("Executor request", "operation", "restarting deployment", "incident_id", req.IncidentID, "url", url)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.ActionResult{Error: fmt.Sprintf("failed to restart deployment: status code %d", resp.StatusCode)}, nil
+	}
+
+	return models.ActionResult{Message: "Deployment rollout restarted successfully"}, nil
+}
+
+// rolloutOperation pauses or resumes a deployment rollout
+// (POST {base}/api/kubernetes/{namespace}/deployments/{name}/{pause,resume}).
+func (c *ExecutorClient) rolloutOperation(ctx context.Context, req models.ActionRequest, operation, successMessage string) (models.ActionResult, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/%s/deployments/%s/%s", c.baseURL, req.Parameters["namespace"], req.Parameters["deployment"], operation)
+	slog.Debug("Executor request", "operation", operation+" rollout", "incident_id", req.IncidentID, "url", url)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return models.ActionResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.ActionResult{Error: fmt.Sprintf("failed to %s rollout: status code %d", operation, resp.StatusCode)}, nil
+	}
+
+	return models.ActionResult{Message: successMessage}, nil
+}
+
+// Ping probes the executor's /healthz endpoint once, without retries, so an
+// outage is detected quickly.
+func (c *ExecutorClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+	c.setAuthHeader(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("executor health check: status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// fallbackResourceProfiles are offered when the executor predates the
+// resource-profiles endpoint.
+var fallbackResourceProfiles = []models.ResourceProfile{
+	{Name: "small", Description: "1 CPU, 2Gi RAM", IsDefault: true},
+	{Name: "medium", Description: "2 CPU, 4Gi RAM"},
+	{Name: "large", Description: "4 CPU, 8Gi RAM"},
+}
+
+func (c *ExecutorClient) GetAvailableResources(ctx context.Context) (*models.AvailableResources, error) {
+	url := fmt.Sprintf("%s/api/kubernetes/resource-profiles", c.baseURL)
+	slog.Debug("Executor request", "operation", "listing resource profiles", "url", url)
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doWithRetry(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		slog.Warn("Executor does not serve resource profiles, using the built-in list", "url", url)
+		return &models.AvailableResources{Profiles: append([]models.ResourceProfile(nil), fallbackResourceProfiles...)}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get resource profiles: status code %d", resp.StatusCode)
+	}
+
+	var profiles ResourceProfiles
+	if err := json.NewDecoder(resp.Body).Decode(&profiles); err != nil {
+		return nil, err
+	}
+	available := &models.AvailableResources{Profiles: make([]models.ResourceProfile, 0, len(profiles.Profiles))}
+	for _, p := range profiles.Profiles {
+		available.Profiles = append(available.Profiles, models.ResourceProfile{
+			Name:        p.Name,
+			Description: p.Description,
+			IsDefault:   p.IsDefault,
+		})
+	}
+	return available, nil
 }
 
 func convertResources(res []*ContainerResources) []models.ContainerResources {
@@ -0,0 +1,366 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+func TestDoWithRetry(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name         string
+		retryCount   *int
+		method       string
+		failures     int32
+		wantAttempts int32
+		wantStatus   int
+	}{
+		{"default retries recover", nil, http.MethodGet, 2, 3, http.StatusOK},
+		{"default retries exhausted", nil, http.MethodGet, 10, 4, http.StatusServiceUnavailable},
+		{"retries on top of the first attempt", intPtr(2), http.MethodPut, 10, 3, http.StatusServiceUnavailable},
+		{"zero disables retries", intPtr(0), http.MethodGet, 1, 1, http.StatusServiceUnavailable},
+		{"post is sent once", intPtr(3), http.MethodPost, 1, 1, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryCount: tt.retryCount, RetryBaseDelayMs: 1})
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, srv.URL+"/api/v1/test", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.doWithRetry(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// TestRetriedDeleteOfMissingPod checks that a pod deleted by an attempt whose
+// response was lost is reported as restarted, while a pod that was never there
+// is still an error.
+func TestRetriedDeleteOfMissingPod(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []int
+		wantError bool
+	}{
+		{"response of the delete lost", []int{http.StatusBadGateway, http.StatusNotFound}, false},
+		{"pod never existed", []int{http.StatusNotFound}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1)) - 1
+				w.WriteHeader(tt.responses[min(n, len(tt.responses)-1)])
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryBaseDelayMs: 1})
+			result := client.ExecuteAction(context.Background(), models.ActionRequest{
+				Action:     string(models.ActionDeletePod),
+				Parameters: map[string]string{"namespace": "prod", "pod_name": "api-0"},
+			})
+			if gotError := result.Error != ""; gotError != tt.wantError {
+				t.Errorf("result = %+v, want error %v", result, tt.wantError)
+			}
+			if got := int(attempts.Load()); got != len(tt.responses) {
+				t.Errorf("attempts = %d, want %d", got, len(tt.responses))
+			}
+		})
+	}
+}
+
+func TestRolloutOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      models.ActionType
+		status      int
+		wantPath    string
+		wantMessage string
+		wantError   string
+	}{
+		{"pause", models.ActionPauseRollout, http.StatusOK, "/api/kubernetes/prod/deployments/api/pause", "Deployment rollout paused successfully", ""},
+		{"resume", models.ActionResumeRollout, http.StatusOK, "/api/kubernetes/prod/deployments/api/resume", "Deployment rollout resumed successfully", ""},
+		{"pause rejected", models.ActionPauseRollout, http.StatusConflict, "/api/kubernetes/prod/deployments/api/pause", "", "pause_rollout on deployment api: failed to pause rollout: status code 409"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path string
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				method, path = r.Method, r.URL.Path
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryBaseDelayMs: 1})
+			res := client.ExecuteAction(context.Background(), models.ActionRequest{
+				Action:     string(tt.action),
+				IncidentID: 1,
+				Parameters: map[string]string{"namespace": "prod", "deployment": "api"},
+			})
+
+			if method != http.MethodPost || path != tt.wantPath {
+				t.Errorf("request = %s %s, want POST %s", method, path, tt.wantPath)
+			}
+			if got := attempts.Load(); got != 1 {
+				t.Errorf("attempts = %d, want 1", got)
+			}
+			if res.Message != tt.wantMessage || res.Error != tt.wantError {
+				t.Errorf("result = {Message: %q, Error: %q}, want {Message: %q, Error: %q}", res.Message, res.Error, tt.wantMessage, tt.wantError)
+			}
+		})
+	}
+}
+
+// TestAuthHeaderOnEveryRequest checks that the configured token is sent on the
+// action, lookup and health requests alike, and that nothing is sent without it.
+func TestAuthHeaderOnEveryRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		wantHeader string
+		wantValue  string
+	}{
+		{"bearer by default", "s3cret", "", "Authorization", "Bearer s3cret"},
+		{"authorization named explicitly", "s3cret", "authorization", "Authorization", "Bearer s3cret"},
+		{"custom header", "s3cret", "X-Api-Key", "X-Api-Key", "s3cret"},
+		{"no token", "", "X-Api-Key", "X-Api-Key", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			seen := map[string]string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				seen[r.Method+" "+r.URL.Path] = r.Header.Get(tt.wantHeader)
+				if tt.wantHeader != "Authorization" && r.Header.Get("Authorization") != "" {
+					t.Errorf("%s %s also carries Authorization", r.Method, r.URL.Path)
+				}
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, AuthToken: tt.token, AuthHeader: tt.header})
+			ctx := context.Background()
+			params := map[string]string{"namespace": "prod", "deployment": "api"}
+			client.ExecuteAction(ctx, models.ActionRequest{Action: string(models.ActionRestartDeployment), Parameters: params})
+			client.ExecuteAction(ctx, models.ActionRequest{Action: string(models.ActionDescribeDeployment), Parameters: params})
+			client.GetResourceDetails(ctx, models.ResourceDetailsRequest{ResourceType: "deployment", ResourceName: "api", Labels: params})
+			client.Ping(ctx)
+
+			if len(seen) < 4 {
+				t.Fatalf("requests = %v, want at least 4", seen)
+			}
+			for request, value := range seen {
+				if value != tt.wantValue {
+					t.Errorf("%s: %s = %q, want %q", request, tt.wantHeader, value, tt.wantValue)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAvailableResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "profiles from the executor",
+			status:    http.StatusOK,
+			body:      `{"profiles":[{"name":"gpu","description":"1 GPU, 16Gi RAM"},{"name":"tiny","description":"0.25 CPU","isDefault":true}]}`,
+			wantNames: []string{"gpu", "tiny"},
+		},
+		{name: "endpoint missing falls back", status: http.StatusNotFound, wantNames: []string{"small", "medium", "large"}},
+		{name: "executor error", status: http.StatusInternalServerError, wantErr: true},
+		{name: "malformed body", status: http.StatusOK, body: `{"profiles":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryCount: new(int)})
+			got, err := client.GetAvailableResources(context.Background())
+			if path != "/api/kubernetes/resource-profiles" {
+				t.Errorf("path = %q, want /api/kubernetes/resource-profiles", path)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetAvailableResources = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, p := range got.Profiles {
+				names = append(names, p.Name)
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("profiles = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+// TestGetAvailableResourcesKeepsProfileFields checks that every field of the
+// contract reaches the model, and that the fallback list is not shared.
+func TestGetAvailableResourcesKeepsProfileFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"profiles":[{"name":"tiny","description":"0.25 CPU","isDefault":true}]}`))
+	}))
+	defer srv.Close()
+
+	got, err := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL}).GetAvailableResources(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := models.ResourceProfile{Name: "tiny", Description: "0.25 CPU", IsDefault: true}
+	if len(got.Profiles) != 1 || got.Profiles[0] != want {
+		t.Errorf("profiles = %+v, want [%+v]", got.Profiles, want)
+	}
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+	fallback, err := NewExecutorClient(config.ExecutorConfig{BaseURL: missing.URL}).GetAvailableResources(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fallback.Profiles[0].Name = "changed"
+	if fallbackResourceProfiles[0].Name == "changed" {
+		t.Error("caller modified the shared fallback list")
+	}
+}
+
+func TestGetResourceDetailsDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus string
+		wantInfo   string
+	}{
+		{
+			name:       "all replicas ready",
+			body:       `{"name":"api","replicas":3,"readyReplicas":3,"availableReplicas":3,"updatedReplicas":3}`,
+			wantStatus: models.DeploymentHealthy,
+			wantInfo:   "3/3 ready",
+		},
+		{
+			name:       "rollout in progress",
+			body:       `{"name":"api","replicas":5,"readyReplicas":3,"availableReplicas":2,"updatedReplicas":1,"paused":true}`,
+			wantStatus: models.DeploymentDegraded,
+			wantInfo:   "3/5 ready",
+		},
+		{
+			name:       "scaled to zero",
+			body:       `{"name":"api","replicas":0}`,
+			wantStatus: models.DeploymentHealthy,
+			wantInfo:   "0/0 ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryCount: new(int)})
+			got, err := client.GetResourceDetails(context.Background(), models.ResourceDetailsRequest{
+				ResourceType: "deployment",
+				ResourceName: "api",
+				Labels:       models.JSONBMap{"namespace": "prod"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != "/api/kubernetes/prod/deployments/api" {
+				t.Errorf("path = %q, want /api/kubernetes/prod/deployments/api", path)
+			}
+			if got.Status != tt.wantStatus || got.ReplicasInfo != tt.wantInfo {
+				t.Errorf("details = %s %q, want %s %q", got.Status, got.ReplicasInfo, tt.wantStatus, tt.wantInfo)
+			}
+		})
+	}
+}
+
+// TestGetResourceDetailsDeploymentCounts checks that every replica count of the
+// contract reaches the model.
+func TestGetResourceDetailsDeploymentCounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"api","replicas":5,"readyReplicas":4,"availableReplicas":3,"updatedReplicas":2,"paused":true}`))
+	}))
+	defer srv.Close()
+
+	client := NewExecutorClient(config.ExecutorConfig{BaseURL: srv.URL, RetryCount: new(int)})
+	got, err := client.GetResourceDetails(context.Background(), models.ResourceDetailsRequest{ResourceType: "deployment", ResourceName: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := models.ResourceDetails{
+		Status:            models.DeploymentDegraded,
+		ReplicasInfo:      "4/5 ready",
+		DesiredReplicas:   5,
+		ReadyReplicas:     4,
+		AvailableReplicas: 3,
+		UpdatedReplicas:   2,
+		Paused:            true,
+	}
+	if got.Status != want.Status || got.ReplicasInfo != want.ReplicasInfo || got.DesiredReplicas != want.DesiredReplicas ||
+		got.ReadyReplicas != want.ReadyReplicas || got.AvailableReplicas != want.AvailableReplicas ||
+		got.UpdatedReplicas != want.UpdatedReplicas || got.Paused != want.Paused {
+		t.Errorf("details = %+v, want %+v", *got, want)
+	}
+}
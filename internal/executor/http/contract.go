@@ -26,6 +26,43 @@ type DeploymentPod struct {
 }
 
 type Deployment struct {
-	Name     string `json:"name"`
-	Replicas int    `json:"replicas"`
+	Name              string `json:"name"`
+	Replicas          int    `json:"replicas"`
+	ReadyReplicas     int    `json:"readyReplicas"`
+	AvailableReplicas int    `json:"availableReplicas"`
+	UpdatedReplicas   int    `json:"updatedReplicas"`
+	Paused            bool   `json:"paused"`
+}
+
+type Node struct {
+	Name              string `json:"name"`
+	Status            string `json:"status"`
+	Unschedulable     bool   `json:"unschedulable"`
+	Age               string `json:"age"`
+	CpuAllocatable    int64  `json:"cpuAllocatable"`
+	MemoryAllocatable int64  `json:"memoryAllocatable"`
+	CpuUsage          int64  `json:"cpuUsage"`
+	MemoryUsage       int64  `json:"memoryUsage"`
+	Pods              int    `json:"pods"`
+	PodCapacity       int    `json:"podCapacity"`
+}
+
+type ResourceProfiles struct {
+	Profiles []ResourceProfile `json:"profiles"`
+}
+
+type ResourceProfile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
+type ExecRequest struct {
+	Command string `json:"command"`
+}
+
+type ExecResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exitCode"`
 }
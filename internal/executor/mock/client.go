@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"chatops-bot/internal/models"
+)
+
+// ExecutorClientMock is an in-memory ExecutorClient for tests and local runs. It
+// records every action request and answers with canned results, which can be
+// overridden per action through Responses.
+type ExecutorClientMock struct {
+	// FailNextCall makes the next call fail; it resets after that call.
+	FailNextCall bool
+	// Responses overrides the canned result for an action.
+	Responses map[models.ActionType]models.ActionResult
+	// Details, when set, is returned by GetResourceDetails.
+	Details *models.ResourceDetails
+	// Down makes Ping fail, as if the executor were unreachable.
+	Down bool
+
+	mu    sync.Mutex
+	calls []models.ActionRequest
+}
+
+func NewExecutorClientMock() *ExecutorClientMock {
+	return &ExecutorClientMock{Responses: make(map[models.ActionType]models.ActionResult)}
+}
+
+var errMockFailure = errors.New("mock executor: simulated failure")
+
+// takeFailure reports whether this call should fail and clears FailNextCall.
+func (m *ExecutorClientMock) takeFailure() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fail := m.FailNextCall
+	m.FailNextCall = false
+	return fail
+}
+
+func (m *ExecutorClientMock) ExecuteAction(ctx context.Context, req models.ActionRequest) models.ActionResult {
+	m.mu.Lock()
+	m.calls = append(m.calls, copyRequest(req))
+	m.mu.Unlock()
+
+	if m.takeFailure() {
+		return models.ActionResult{Error: errMockFailure.Error()}
+	}
+	m.mu.Lock()
+	result, ok := m.Responses[models.ActionType(req.Action)]
+	m.mu.Unlock()
+	if ok {
+		return result
+	}
+	return cannedResult(req)
+}
+
+func (m *ExecutorClientMock) GetResourceDetails(ctx context.Context, req models.ResourceDetailsRequest) (*models.ResourceDetails, error) {
+	if m.takeFailure() {
+		return nil, errMockFailure
+	}
+	if m.Details != nil {
+		details := *m.Details
+		return &details, nil
+	}
+	return &models.ResourceDetails{
+		Status:            models.DeploymentHealthy,
+		ReplicasInfo:      "2/2 ready",
+		DesiredReplicas:   2,
+		ReadyReplicas:     2,
+		AvailableReplicas: 2,
+		UpdatedReplicas:   2,
+		Age:               "1d",
+		RawOutput:         fmt.Sprintf("mock %s %s", req.ResourceType, req.ResourceName),
+	}, nil
+}
+
+func (m *ExecutorClientMock) GetAvailableResources(ctx context.Context) (*models.AvailableResources, error) {
+	if m.takeFailure() {
+		return nil, errMockFailure
+	}
+	return &models.AvailableResources{Profiles: []models.ResourceProfile{
+		{Name: "small", Description: "0.5 CPU, 512Mi", IsDefault: true},
+		{Name: "large", Description: "2 CPU, 4Gi"},
+	}}, nil
+}
+
+func (m *ExecutorClientMock) Ping(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Down {
+		return errMockFailure
+	}
+	return nil
+}
+
+// Calls returns the action requests received so far, oldest first.
+func (m *ExecutorClientMock) Calls() []models.ActionRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]models.ActionRequest, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// LastCall returns the most recent action request, if any.
+func (m *ExecutorClientMock) LastCall() (models.ActionRequest, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.calls) == 0 {
+		return models.ActionRequest{}, false
+	}
+	return m.calls[len(m.calls)-1], true
+}
+
+// Reset forgets the recorded calls.
+func (m *ExecutorClientMock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// copyRequest detaches the recorded request from the caller's parameter map.
+func copyRequest(req models.ActionRequest) models.ActionRequest {
+	params := make(map[string]string, len(req.Parameters))
+	for key, value := range req.Parameters {
+		params[key] = value
+	}
+	req.Parameters = params
+	return req
+}
+
+func cannedResult(req models.ActionRequest) models.ActionResult {
+	switch models.ActionType(req.Action) {
+	case models.ActionGetPodLogs:
+		return models.ActionResult{
+			Message: "Pod logs retrieved successfully",
+			ResultData: &models.ResultData{
+				Type:     "pod_logs",
+				ItemType: "pod_logs",
+				Items:    []models.ResourceInfo{{Name: "logs", Status: fmt.Sprintf("mock logs of %s\n", req.Parameters["pod_name"])}},
+			},
+		}
+	case models.ActionListPodsForDeployment:
+		return models.ActionResult{
+			Message: "mock pods",
+			ResultData: &models.ResultData{
+				Type:     "list",
+				ItemType: "pod",
+				Items: []models.ResourceInfo{
+					{Name: req.Parameters["deployment"] + "-0", Status: "Running"},
+					{Name: req.Parameters["deployment"] + "-1", Status: "Running"},
+				},
+			},
+		}
+	case models.ActionDescribePod, models.ActionDescribeDeployment, models.ActionDescribeNode:
+		return models.ActionResult{
+			Message: "mock description",
+			ResultData: &models.ResultData{
+				Type:     "description",
+				ItemType: "description",
+				Items:    []models.ResourceInfo{{Name: "description", Status: fmt.Sprintf("mock description for %v\n", req.Parameters)}},
+			},
+		}
+	default:
+		return models.ActionResult{Message: fmt.Sprintf("mock: %s executed", req.Action)}
+	}
+}
@@ -0,0 +1,133 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+func TestExecuteActionRecordsRequests(t *testing.T) {
+	m := NewExecutorClientMock()
+	params := map[string]string{"deployment": "api", "namespace": "prod", "replicas": "3"}
+	m.ExecuteAction(context.Background(), models.ActionRequest{Action: string(models.ActionScaleDeployment), IncidentID: 7, Parameters: params})
+	m.ExecuteAction(context.Background(), models.ActionRequest{Action: string(models.ActionRestartDeployment), IncidentID: 7})
+
+	// The recorded request must not change with the caller's map.
+	params["replicas"] = "10"
+
+	calls := m.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("got %d calls, want 2", len(calls))
+	}
+	if calls[0].Action != string(models.ActionScaleDeployment) || calls[0].Parameters["replicas"] != "3" {
+		t.Errorf("first call = %+v, want scale_deployment with replicas=3", calls[0])
+	}
+	last, ok := m.LastCall()
+	if !ok || last.Action != string(models.ActionRestartDeployment) {
+		t.Errorf("LastCall() = %+v, %v, want restart_deployment", last, ok)
+	}
+
+	m.Reset()
+	if _, ok := m.LastCall(); ok || len(m.Calls()) != 0 {
+		t.Error("Reset did not forget the recorded calls")
+	}
+}
+
+func TestExecuteActionResponses(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(m *ExecutorClientMock)
+		action    models.ActionType
+		wantMsg   string
+		wantError bool
+	}{
+		{
+			name:    "canned result",
+			action:  models.ActionRestartDeployment,
+			wantMsg: "mock: restart_deployment executed",
+		},
+		{
+			name: "per-action override",
+			setup: func(m *ExecutorClientMock) {
+				m.Responses[models.ActionRestartDeployment] = models.ActionResult{Message: "restarted api"}
+			},
+			action:  models.ActionRestartDeployment,
+			wantMsg: "restarted api",
+		},
+		{
+			name: "override of another action is ignored",
+			setup: func(m *ExecutorClientMock) {
+				m.Responses[models.ActionScaleDeployment] = models.ActionResult{Error: "quota exceeded"}
+			},
+			action:  models.ActionRestartDeployment,
+			wantMsg: "mock: restart_deployment executed",
+		},
+		{
+			name:      "fail next call",
+			setup:     func(m *ExecutorClientMock) { m.FailNextCall = true },
+			action:    models.ActionRestartDeployment,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewExecutorClientMock()
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			result := m.ExecuteAction(context.Background(), models.ActionRequest{Action: string(tt.action)})
+			if (result.Error != "") != tt.wantError {
+				t.Fatalf("error = %q, wantError %v", result.Error, tt.wantError)
+			}
+			if !tt.wantError && result.Message != tt.wantMsg {
+				t.Errorf("message = %q, want %q", result.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFailNextCallResets(t *testing.T) {
+	m := NewExecutorClientMock()
+	m.FailNextCall = true
+	if _, err := m.GetAvailableResources(context.Background()); err == nil {
+		t.Fatal("first call succeeded, want failure")
+	}
+	if _, err := m.GetAvailableResources(context.Background()); err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	m := NewExecutorClientMock()
+	if err := m.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() = %v, want nil", err)
+	}
+	m.Down = true
+	if err := m.Ping(context.Background()); err == nil {
+		t.Fatal("Ping() = nil with Down set")
+	}
+}
+
+func TestGetResourceDetails(t *testing.T) {
+	m := NewExecutorClientMock()
+	got, err := m.GetResourceDetails(context.Background(), models.ResourceDetailsRequest{ResourceType: "deployment", ResourceName: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.DeploymentHealthy || got.ReplicasInfo != "2/2 ready" {
+		t.Errorf("default details = %s %q, want Healthy \"2/2 ready\"", got.Status, got.ReplicasInfo)
+	}
+	if got.DesiredReplicas != 2 || got.ReadyReplicas != 2 || got.AvailableReplicas != 2 || got.UpdatedReplicas != 2 {
+		t.Errorf("replicas = %+v, want 2 desired, ready, available and updated", got)
+	}
+
+	m.Details = &models.ResourceDetails{Status: models.DeploymentDegraded, ReplicasInfo: "1/3 ready"}
+	got, err = m.GetResourceDetails(context.Background(), models.ResourceDetailsRequest{ResourceType: "deployment", ResourceName: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == m.Details || got.Status != models.DeploymentDegraded {
+		t.Errorf("details = %+v, want a copy of the configured Details", got)
+	}
+}
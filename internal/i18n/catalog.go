@@ -0,0 +1,743 @@
+package i18n
+
+var ru = map[string]string{
+	"start.welcome": "Добро пожаловать! Используйте /help для просмотра доступных команд.",
+	"help.text": `
+*Доступные команды:*
+
+*/incidents* \- Показать список активных инцидентов\.
+  • *Использование:* /incidents
+  • *Просмотр конкретного инцидента:* /incidents <ID\>
+
+*/history* \- Показать историю закрытых инцидентов\.
+  • *Использование:* /history
+  • *Просмотр конкретного инцидента:* /history <ID\>
+
+*/find* \- Найти инциденты по лейблам\.
+  • *Использование:* /find namespace\=production
+  • *С фильтром по статусу:* /find severity\=critical status\=active
+
+*/resolve\_all* \- Решить все активные инциденты по лейблам \(только для администраторов, с подтверждением\)\.
+  • *Использование:* /resolve\_all namespace\=staging
+
+*/ack* \- Взять инцидент в работу\.
+  • *Использование:* /ack <ID\>
+  • *Перехватить у другого пользователя:* /ack <ID\> force
+
+*/ack\_all* \- Взять в работу все активные непринятые инциденты, например при заступлении на смену \(только для администраторов\)\.
+  • *Использование:* /ack\_all \[team\=payments\]
+
+*/assign* \- Назначить ответственного за инцидент\.
+  • *Использование:* /assign <ID\> @username
+
+*/comment* \- Добавить комментарий к инциденту\.
+  • *Использование:* /comment <ID\> <текст\>
+
+*/tag*, */untag* \- Добавить или удалить тег инцидента\.
+  • *Использование:* /tag <ID\> postmortem\=required
+  • *Удаление:* /untag <ID\> postmortem
+
+*/oncall* \- Показать дежурного и текущую нагрузку по инцидентам\.
+
+*/stats* \- Статистика инцидентов за период\.
+  • *Использование:* /stats 7d\|30d
+
+*/flags* \- Показать и переключить feature\-флаги \(только для администраторов\)\.
+  • *Использование:* /flags
+  • *Переключение:* /flags <имя\> on\|off
+
+*/lang* \- Выбрать язык бота\.
+  • *Использование:* /lang ru\|en
+
+*/notify* \- Настроить личные уведомления о назначенных вам инцидентах\.
+  • *Использование:* /notify
+  • *Переключение:* /notify assigned\|escalation\|reminder on\|off
+
+*/whoami* \- Показать вашу учётную запись в боте\.
+
+*/users* \- Список зарегистрированных пользователей \(только для администраторов\)\.
+
+*/grant\_admin*, */revoke\_admin* \- Выдать или снять права администратора \(только для администраторов\)\.
+  • *Использование:* /grant\_admin @username
+
+*/refresh* \- Перерисовать сообщения инцидента по сохранённым ID, если они устарели \(только для администраторов\)\.
+  • *Использование:* /refresh <ID\>
+
+*/silence*, */silences*, */unsilence* \- Не создавать инциденты по подходящим алертам в течение заданного времени \(только для администраторов\)\.
+  • *Использование:* /silence alertname\=DiskFull 2h
+  • *Снятие:* /unsilence <ID\>
+
+*/help* \- Показать это сообщение\.
+`,
+
+	"common.invalid_id":         "Неверный ID инцидента. Пожалуйста, введите число.",
+	"common.incident_not_found": "Не удалось найти инцидент.",
+	"common.incident_closed":    "Инцидент уже закрыт.",
+	"common.unauthorized":       "Недостаточно прав",
+	"common.auth_failed":        "Произошла ошибка аутентификации.",
+	"common.prev":               "⬅️ Предыдущие",
+	"common.next":               "Следующие ➡️",
+	"common.back":               "⬅️ Назад",
+	"common.button_expired":     "Кнопка устарела. Откройте инцидент заново.",
+	"common.message_outdated":   "Это сообщение устарело, откройте инцидент заново.",
+	"common.invalid_page":       "Неверная страница.",
+	"common.invalid_duration":   "Неверная длительность.",
+	"common.invalid_callback":   "Неверные данные кнопки.",
+	"common.invalid_action":     "Неверный номер действия.",
+	"common.action_outdated":    "Действие больше недоступно.",
+	"common.to_incident":        "🏠 К инциденту",
+	"common.close_incident":     "✅ Закрыть инцидент",
+
+	"incidents.list_failed":  "Не удалось получить список инцидентов.",
+	"incidents.none":         "Активных инцидентов нет.",
+	"incidents.active_count": "Активных инцидентов: %d",
+	"incidents.shown_range":  "Показаны %d–%d:",
+
+	"history.failed":     "Не удалось получить историю инцидентов.",
+	"history.empty":      "История закрытых инцидентов пуста.",
+	"history.recent":     "Последние закрытые инциденты:",
+	"history.range":      "Закрытые инциденты (%d–%d):",
+	"history.show":       "📖 Показать историю",
+	"history.hide":       "📖 Скрыть историю",
+	"history.header":     "*📖 История действий:*\n",
+	"history.hidden":     "_История действий скрыта \\(%d записей\\)\\. Нажмите кнопку ниже, чтобы показать\\._\n",
+	"history.no_entries": "_Нет записей в истории\\._\n",
+
+	"topic.delete_usage":       "Пожалуйста, укажите ID инцидента. \nИспользование: `/delete_incident_topic <ID>`",
+	"topic.incident_not_found": "Инцидент с ID %d не найден.",
+	"topic.none":               "У инцидента #%d нет связанного топика для удаления.",
+	"topic.delete_failed":      "Не удалось удалить топик для инцидента #%d. Ошибка: %v",
+	"topic.deleted":            "Топик для инцидента #%d успешно удален.",
+
+	"flags.admin_only":    "Эта команда доступна только администраторам.",
+	"flags.invalid_value": "Неверное значение. Использование: /flags <имя> on|off",
+	"flags.set_failed":    "Не удалось изменить флаг: %v",
+	"flags.usage":         "Использование: /flags или /flags <имя> on|off",
+	"flags.header":        "Feature-флаги:",
+
+	"oncall.not_configured": "Ротация дежурств не настроена.",
+	"oncall.current":        "Дежурный: @%s (до %s)",
+	"oncall.assigned":       "Назначено дежурному: %d",
+	"oncall.oldest":         "Самый старый непринятый: #%d %s (открыт %s назад)",
+	"oncall.all_acked":      "Все активные инциденты взяты в работу.",
+
+	"stats.usage":       "Использование: /stats [7d|30d]",
+	"stats.failed":      "Не удалось собрать статистику.",
+	"stats.empty":       "За последние %d дн. инцидентов не было.",
+	"stats.title":       "*📊 Инциденты за %d дн\\.*",
+	"stats.created":     "Создано",
+	"stats.resolved":    "Решено",
+	"stats.rejected":    "Отклонено",
+	"stats.active":      "Активно",
+	"stats.mttr":        "Среднее время",
+	"stats.by_severity": "*По значению `%s`:*",
+	"stats.no_severity": "(не указано)",
+
+	"find.usage":          "Использование: /find <лейбл>=<значение> [status=active|resolved|rejected]",
+	"find.invalid_filter": "Неверный фильтр: %s. Ожидается формат ключ=значение.",
+	"find.invalid_status": "Неверный статус. Допустимые значения: active, resolved, rejected.",
+	"find.no_labels":      "Укажите хотя бы один лейбл для поиска.",
+	"find.failed":         "Не удалось выполнить поиск инцидентов.",
+	"find.none":           "Инцидентов, подходящих под фильтр, не найдено.",
+	"find.found":          "Найдено инцидентов: %d",
+	"find.truncated":      "Показаны первые %d.",
+
+	"resolve_all.usage":     "Использование: /resolve_all <лейбл>=<значение> [...]",
+	"resolve_all.none":      "Активных инцидентов, подходящих под фильтр, нет.",
+	"resolve_all.prompt":    "⚠️ Будет решено активных инцидентов: %d (фильтр: %s). Продолжить?",
+	"resolve_all.confirm":   "Да, решить все",
+	"resolve_all.cancel":    "Отмена",
+	"resolve_all.cancelled": "Массовое решение отменено.",
+	"resolve_all.done":      "✅ Решено инцидентов: %d",
+	"resolve_all.partial":   "⚠️ Решено инцидентов: %d, остальные не удалось обновить.",
+
+	"ack.usage":         "Использование: /ack <ID> [force]",
+	"ack.done":          "Инцидент #%d взят в работу.",
+	"ack.done_short":    "Инцидент взят в работу",
+	"ack.already_taken": "Инцидент уже взят в работу другим пользователем. Используйте /ack <ID> force, чтобы перехватить.",
+	"ack.failed":        "Не удалось взять инцидент в работу.",
+
+	"ack_all.none":    "Нет активных инцидентов, которые никто не взял в работу.",
+	"ack_all.done":    "✅ Взято в работу инцидентов: %d",
+	"ack_all.partial": "⚠️ Взято в работу инцидентов: %d, остальные не удалось обновить.",
+
+	"assign.usage":          "Использование: /assign <ID> @username",
+	"assign.user_not_found": "Пользователь @%s не найден. Он должен хотя бы раз написать боту.",
+	"assign.failed":         "Не удалось назначить ответственного.",
+	"assign.done":           "Инцидент #%d назначен на %s.",
+
+	"comment.usage":  "Использование: /comment <ID> <текст>",
+	"comment.prompt": "Введите комментарий к инциденту #%d одним сообщением.",
+	"comment.empty":  "Комментарий не может быть пустым.",
+	"comment.failed": "Не удалось добавить комментарий.",
+	"comment.added":  "Комментарий к инциденту #%d добавлен.",
+
+	"tag.usage":       "Использование: /tag <ID> имя=значение",
+	"tag.untag_usage": "Использование: /untag <ID> имя",
+	"tag.invalid":     "Имя тега может содержать только латинские буквы, цифры, «_», «-» и «.», значение не может быть пустым.",
+	"tag.not_found":   "У инцидента нет такого тега.",
+	"tag.failed":      "Не удалось изменить теги инцидента.",
+	"tag.added":       "Тег %s=%s добавлен к инциденту #%d.",
+	"tag.removed":     "Тег %s удалён у инцидента #%d.",
+
+	"snooze.15m":    "15 мин",
+	"snooze.1h":     "1 час",
+	"snooze.4h":     "4 часа",
+	"snooze.prompt": "На сколько отложить уведомления по инциденту?",
+	"snooze.failed": "Не удалось отложить уведомления.",
+	"snooze.done":   "Уведомления отложены на %s",
+
+	"reopen.done":           "Инцидент #%d переоткрыт",
+	"reopen.already_active": "Инцидент уже активен.",
+	"reopen.failed":         "Не удалось переоткрыть инцидент.",
+
+	"severity.prompt": "Выберите серьезность инцидента #%d (сейчас: %s):",
+	"severity.done":   "Серьезность изменена на %s",
+	"severity.failed": "Не удалось изменить серьезность.",
+
+	"lang.current":     "Текущий язык: %s. Доступные: %s.\nИспользование: /lang <код>",
+	"lang.unsupported": "Язык %s не поддерживается. Доступные: %s.",
+	"lang.failed":      "Не удалось сохранить язык.",
+	"lang.set":         "Язык бота: русский.",
+
+	"executor.unavailable": "⚠️ Исполнитель недоступен. Действия, изменяющие кластер, временно скрыты.",
+
+	"prefs.header":          "Личные уведомления:",
+	"prefs.usage":           "Использование: /notify <тип> on|off",
+	"prefs.unknown_kind":    "Неизвестный тип уведомлений: %s.",
+	"prefs.failed":          "Не удалось сохранить настройки уведомлений.",
+	"prefs.kind.assigned":   "вам назначен инцидент",
+	"prefs.kind.escalation": "эскалация назначенного вам инцидента",
+	"prefs.kind.reminder":   "напоминания о назначенных вам инцидентах",
+	"prefs.dm_assigned":     "📌 Вам назначен инцидент #%d: %s (назначил %s).",
+	"prefs.dm_escalation":   "⚠️ Назначенный вам инцидент #%d эскалирован до уровня %d: %s",
+	"prefs.dm_reminder":     "⏰ Назначенный вам инцидент #%d всё ещё активен (%d мин.): %s",
+
+	"whoami.header":      "Ваша учётная запись",
+	"whoami.id":          "ID",
+	"whoami.telegram_id": "Telegram ID",
+	"whoami.username":    "Имя пользователя",
+	"whoami.name":        "Имя",
+	"whoami.language":    "Язык",
+	"whoami.role":        "Роль",
+	"whoami.registered":  "Зарегистрирован",
+
+	"users.header":     "Пользователи (%d):",
+	"users.failed":     "Не удалось получить список пользователей.",
+	"users.role_admin": "администратор",
+	"users.role_user":  "пользователь",
+
+	"admins.grant_usage":   "Использование: /grant_admin @username",
+	"admins.revoke_usage":  "Использование: /revoke_admin @username",
+	"admins.granted":       "%s теперь администратор.",
+	"admins.revoked":       "%s больше не администратор.",
+	"admins.already_admin": "%s уже администратор.",
+	"admins.not_admin":     "%s не является администратором.",
+	"admins.last_admin":    "Нельзя снять права с последнего администратора.",
+	"admins.bootstrap":     "%s указан в telegram.admin_ids конфигурации: уберите его оттуда, чтобы снять права.",
+	"admins.failed":        "Не удалось изменить права администратора.",
+
+	"refresh.usage":       "Использование: /refresh <ID>",
+	"refresh.no_messages": "У инцидента #%d нет сохранённых сообщений, обновлять нечего.",
+	"refresh.failed":      "Не удалось обновить сообщения инцидента.",
+	"refresh.done":        "Сообщения инцидента #%d обновлены (%d шт.).",
+
+	"silence.usage":            "Использование: /silence имя=значение [имя=значение ...] <длительность>, например /silence alertname=DiskFull 2h",
+	"silence.unsilence_usage":  "Использование: /unsilence <ID тишины>",
+	"silence.invalid_duration": "Неверная длительность. Примеры: 30m, 2h, 3d (не больше 30 дней).",
+	"silence.added":            "🔇 Тишина #%d: %s до %s. Подходящие алерты не будут создавать инциденты.",
+	"silence.removed":          "Тишина #%d снята.",
+	"silence.not_found":        "Тишина #%d не найдена.",
+	"silence.none":             "Активных тишин нет.",
+	"silence.header":           "🔇 Активные тишины (%d):",
+	"silence.line":             "• #%d %s до %s (%s)",
+	"silence.failed":           "Не удалось выполнить операцию с тишиной.",
+
+	"notify.topic_name":        "Инцидент #%d",
+	"notify.go_to_topic":       "Перейти к обсуждению",
+	"notify.escalation_banner": "@here ⚠️ *НЕ ПРИНЯТ за %d минут* ⚠️ уровень %d\n\n",
+	"notify.escalation_oncall": "Дежурный: @%s\n\n",
+	"notify.snooze_expired":    "🔔 Откладывание истекло: инцидент #%d всё ещё активен.",
+	"notify.still_firing":      "⏰ Инцидент #%d всё ещё активен и не взят в работу (%d мин.).",
+	"notify.reopened":          "♻️ Инцидент #%d переоткрыт.",
+
+	"common.error": "Ошибка: %v",
+
+	"status.update_failed":        "Не удалось обновить статус инцидента.",
+	"status.updated":              "Статус инцидента обновлен на '%s'.",
+	"status.rejected":             "Инцидент отклонен. Спасибо за обратную связь!",
+	"status.close_prompt":         "Выберите статус для закрытия инцидента:",
+	"status.reject_reason_prompt": "Пожалуйста, введите причину отклонения инцидента одним сообщением.",
+	"status.resolved_button":      "Решен",
+	"status.rejected_button":      "Отклонен",
+
+	"replicas.prompt":         "Введите желаемое количество реплик (от 0 до %d):",
+	"replicas.prompt_current": "Сейчас реплик: %d. Введите желаемое количество (от 0 до %d):",
+	"replicas.invalid":        "%s Введите целое число от 0 до %d:",
+	"replicas.missing":        "Количество реплик не указано.",
+	"replicas.not_integer":    "«%s» не является целым числом.",
+	"replicas.negative":       "Количество реплик не может быть отрицательным.",
+	"replicas.too_many":       "Нельзя масштабировать больше чем до %d реплик.",
+	"replicas.large_scale_up": "📈 Сейчас реплик: %d, запрошено: %d — больше чем в %d раз.\n",
+	"replicas.invalid_count":  "Неверное количество реплик.",
+
+	"hardware.invalid_format": "Неверный формат: %v. Введите ресурсы в формате `cpu=1.5, memory=512Mi`:",
+	"hardware.prompt":         "Введите запрашиваемые ресурсы в формате `cpu=1.5, memory=512Mi`:",
+	"hardware.choose_profile": "Выберите профиль ресурсов:",
+	"hardware.custom":         "✏️ Указать вручную",
+
+	"resource.title":              "*Ресурс: %s `%s`*\n\n",
+	"resource.details_failed":     "_Не удалось загрузить детали ресурса\\._\n\n",
+	"resource.choose_action":      "Выберите действие:",
+	"resource.status":             "∙ *Статус:* `%s`\n",
+	"resource.status_icon":        "∙ *Статус:* %s `%s`\n",
+	"resource.replicas":           "∙ *Реплики:* `%s`\n",
+	"resource.available_updated":  "∙ *Доступно:* `%d`, *обновлено:* `%d`\n",
+	"resource.rollout_paused":     "∙ *Выкатка:* ⏸ `Rollout paused`\n",
+	"resource.age":                "∙ *Возраст:* `%s`\n",
+	"resource.restarts":           "∙ *Перезапуски:* `%d`\n",
+	"resource.usage_allocatable":  "*Использование / allocatable:*\n",
+	"resource.pods":               "  ∙ *Поды:* `%d`\n",
+	"resource.pods_capacity":      "  ∙ *Поды:* `%d/%d`\n",
+	"resource.usage":              "*Потребление ресурсов:*\n",
+	"resource.container_usage":    "  ∙ *Контейнер:* `%s`\n    ∙ *CPU:* `%s`\n    ∙ *Memory:* `%s`\n",
+	"resource.unlimited":          "без лимита",
+	"resource.scale":              "↔️ Масштабировать",
+	"resource.restore_replicas":   "🔁 Восстановить до %d реплик",
+	"resource.describe":           "📖 Описать",
+	"resource.rollback":           "⏪ Откатить",
+	"resource.pause_rollout":      "⏸ Приостановить выкатку",
+	"resource.resume_rollout":     "▶️ Возобновить выкатку",
+	"resource.allocate":           "⚙️ Выделить ресурсы",
+	"resource.containers":         "Контейнеры",
+	"resource.deployment_actions": "🗂️ Действия с Deployment",
+	"resource.node_actions":       "🖥️ Действия с Node",
+	"resource.pod_info":           "*Информация о поде: %s*\n\n",
+	"resource.no_pods":            "Поды деплоймента не найдены.",
+	"resource.pod_details_failed": "Не удалось получить данные пода.",
+	"resource.choose_container":   "Выберите контейнер для просмотра логов:",
+	"resource.previous_container": "📄 %s (предыдущий)",
+	"resource.previous_logs":      "Логи предыдущего контейнера",
+	"resource.invalid_log_size":   "Неверный размер логов.",
+
+	"logfollow.button":          "📄 %s — Логи (live)",
+	"logfollow.too_many":        "Слишком много активных трансляций логов, попробуйте позже.",
+	"logfollow.already_stopped": "Трансляция уже завершена.",
+	"logfollow.stopped":         "Трансляция остановлена.",
+	"logfollow.stop":            "⏹ Стоп",
+	"logfollow.header":          "*📄 Логи \\(live\\):* `%s/%s`\n",
+	"logfollow.finished":        "_Трансляция завершена\\._",
+	"logfollow.refreshing":      "_Обновляется каждые %d сек\\._",
+	"logfollow.no_logs":         "(нет логов)",
+
+	"exec.disabled":    "Exec отключен: список разрешенных команд пуст.",
+	"exec.not_allowed": "Команда больше не разрешена.",
+	"exec.prompt":      "Выберите команду для контейнера `%s` пода `%s`:",
+
+	"kubectl.show":   "📋 Показать команду",
+	"kubectl.header": "*Команды kubectl:*\n",
+
+	"links.prometheus": "🔗 Открыть в Prometheus",
+	"links.runbook":    "📘 Runbook",
+	"links.dashboard":  "📊 Дашборд",
+
+	"history.truncated":        "_Показаны последние %d из %d записей_\n",
+	"history.show_all":         "📄 Показать все",
+	"history.document_caption": "История действий инцидента #%d (%d записей)",
+	"history.send_failed":      "Не удалось отправить историю.",
+
+	"confirm.prompt":               "⚠️ Вы уверены?\nДействие: %s\nРесурс: %s",
+	"confirm.replicas":             "\nРеплики: %s",
+	"confirm.command":              "\nКоманда: %s",
+	"confirm.yes":                  "Да, выполнить",
+	"confirm.cancel":               "Отмена",
+	"confirm.cancelled":            "Действие отменено",
+	"confirm.expired":              "Запрос на подтверждение устарел.",
+	"confirm.not_requester":        "Подтвердить действие может только тот, кто его запросил.",
+	"confirm.cancel_not_requester": "Отменить действие может только тот, кто его запросил.",
+
+	"incident.acknowledge": "🙋 Взять в работу",
+	"incident.actions":     "▶️ Выполнить действия",
+	"incident.snooze":      "🔕 Отложить",
+	"incident.comment":     "💬 Добавить комментарий",
+	"incident.severity":    "⚠️ Изменить серьезность",
+	"incident.reopen":      "♻️ Переоткрыть",
+
+	"audit.reason":    "  *Причина:* %s\n",
+	"audit.command":   "  *Команда:* `%s` в `%s/%s`\n",
+	"audit.operation": "  *Операция:* `%s`\n",
+	"audit.replicas":  "  *Реплики:* `%s`\n",
+	"audit.profile":   "  *Профиль:* `%s`\n",
+	"audit.resources": "  *Ресурсы:* `%s`\n",
+}
+
+var en = map[string]string{
+	"start.welcome": "Welcome! Use /help to see the available commands.",
+	"help.text": `
+*Available commands:*
+
+*/incidents* \- Show active incidents\.
+  • *Usage:* /incidents
+  • *View a specific incident:* /incidents <ID\>
+
+*/history* \- Show closed incidents\.
+  • *Usage:* /history
+  • *View a specific incident:* /history <ID\>
+
+*/find* \- Search incidents by labels\.
+  • *Usage:* /find namespace\=production
+  • *With a status filter:* /find severity\=critical status\=active
+
+*/resolve\_all* \- Resolve all active incidents matching labels \(admins only, asks for confirmation\)\.
+  • *Usage:* /resolve\_all namespace\=staging
+
+*/ack* \- Take an incident\.
+  • *Usage:* /ack <ID\>
+  • *Take over from another user:* /ack <ID\> force
+
+*/ack\_all* \- Take all active unacknowledged incidents, e.g. when starting a shift \(admins only\)\.
+  • *Usage:* /ack\_all \[team\=payments\]
+
+*/assign* \- Assign an owner to an incident\.
+  • *Usage:* /assign <ID\> @username
+
+*/comment* \- Add a comment to an incident\.
+  • *Usage:* /comment <ID\> <text\>
+
+*/tag*, */untag* \- Add or remove an incident tag\.
+  • *Usage:* /tag <ID\> postmortem\=required
+  • *Removal:* /untag <ID\> postmortem
+
+*/oncall* \- Show the on\-call engineer and current incident load\.
+
+*/stats* \- Show incident statistics for a period\.
+  • *Usage:* /stats 7d\|30d
+
+*/flags* \- Show and toggle feature flags \(admins only\)\.
+  • *Usage:* /flags
+  • *Toggle:* /flags <name\> on\|off
+
+*/lang* \- Choose the bot language\.
+  • *Usage:* /lang ru\|en
+
+*/notify* \- Configure direct messages about incidents assigned to you\.
+  • *Usage:* /notify
+  • *Toggle:* /notify assigned\|escalation\|reminder on\|off
+
+*/whoami* \- Show your bot account\.
+
+*/users* \- List registered users \(admins only\)\.
+
+*/grant\_admin*, */revoke\_admin* \- Grant or revoke admin rights \(admins only\)\.
+  • *Usage:* /grant\_admin @username
+
+*/refresh* \- Re\-render the incident messages from their stored IDs when they are stale \(admins only\)\.
+  • *Usage:* /refresh <ID\>
+
+*/silence*, */silences*, */unsilence* \- Keep matching alerts from creating incidents for a while \(admins only\)\.
+  • *Usage:* /silence alertname\=DiskFull 2h
+  • *Removal:* /unsilence <ID\>
+
+*/help* \- Show this message\.
+`,
+
+	"common.invalid_id":         "Invalid incident ID. Please enter a number.",
+	"common.incident_not_found": "Incident not found.",
+	"common.incident_closed":    "The incident is already closed.",
+	"common.unauthorized":       "Not enough permissions",
+	"common.auth_failed":        "Authentication failed.",
+	"common.prev":               "⬅️ Previous",
+	"common.next":               "Next ➡️",
+	"common.back":               "⬅️ Back",
+	"common.button_expired":     "This button has expired. Open the incident again.",
+	"common.message_outdated":   "This message is outdated, open the incident again.",
+	"common.invalid_page":       "Invalid page.",
+	"common.invalid_duration":   "Invalid duration.",
+	"common.invalid_callback":   "Invalid button data.",
+	"common.invalid_action":     "Invalid action number.",
+	"common.action_outdated":    "This action is no longer available.",
+	"common.to_incident":        "🏠 To the incident",
+	"common.close_incident":     "✅ Close incident",
+
+	"incidents.list_failed":  "Failed to list incidents.",
+	"incidents.none":         "There are no active incidents.",
+	"incidents.active_count": "Active incidents: %d",
+	"incidents.shown_range":  "Showing %d–%d:",
+
+	"history.failed":     "Failed to load incident history.",
+	"history.empty":      "There are no closed incidents yet.",
+	"history.recent":     "Recently closed incidents:",
+	"history.range":      "Closed incidents (%d–%d):",
+	"history.show":       "📖 Show history",
+	"history.hide":       "📖 Hide history",
+	"history.header":     "*📖 Action history:*\n",
+	"history.hidden":     "_Action history is hidden \\(%d entries\\)\\. Press the button below to show it\\._\n",
+	"history.no_entries": "_No history entries\\._\n",
+
+	"topic.delete_usage":       "Please specify the incident ID. \nUsage: `/delete_incident_topic <ID>`",
+	"topic.incident_not_found": "Incident with ID %d not found.",
+	"topic.none":               "Incident #%d has no topic to delete.",
+	"topic.delete_failed":      "Failed to delete the topic for incident #%d. Error: %v",
+	"topic.deleted":            "Topic for incident #%d deleted.",
+
+	"flags.admin_only":    "This command is available to admins only.",
+	"flags.invalid_value": "Invalid value. Usage: /flags <name> on|off",
+	"flags.set_failed":    "Failed to change the flag: %v",
+	"flags.usage":         "Usage: /flags or /flags <name> on|off",
+	"flags.header":        "Feature flags:",
+
+	"oncall.not_configured": "On-call rotation is not configured.",
+	"oncall.current":        "On call: @%s (until %s)",
+	"oncall.assigned":       "Assigned to the on-call: %d",
+	"oncall.oldest":         "Oldest unacknowledged: #%d %s (open for %s)",
+	"oncall.all_acked":      "All active incidents are acknowledged.",
+
+	"stats.usage":       "Usage: /stats [7d|30d]",
+	"stats.failed":      "Failed to collect statistics.",
+	"stats.empty":       "There were no incidents in the last %d days.",
+	"stats.title":       "*📊 Incidents in the last %d days*",
+	"stats.created":     "Created",
+	"stats.resolved":    "Resolved",
+	"stats.rejected":    "Rejected",
+	"stats.active":      "Active",
+	"stats.mttr":        "MTTR",
+	"stats.by_severity": "*By `%s`:*",
+	"stats.no_severity": "(none)",
+
+	"find.usage":          "Usage: /find <label>=<value> [status=active|resolved|rejected]",
+	"find.invalid_filter": "Invalid filter: %s. Expected key=value.",
+	"find.invalid_status": "Invalid status. Allowed values: active, resolved, rejected.",
+	"find.no_labels":      "Specify at least one label to search by.",
+	"find.failed":         "Failed to search incidents.",
+	"find.none":           "No incidents match the filter.",
+	"find.found":          "Incidents found: %d",
+	"find.truncated":      "Showing the first %d.",
+
+	"resolve_all.usage":     "Usage: /resolve_all <label>=<value> [...]",
+	"resolve_all.none":      "No active incidents match the filter.",
+	"resolve_all.prompt":    "⚠️ This will resolve %d active incident(s) (filter: %s). Continue?",
+	"resolve_all.confirm":   "Yes, resolve all",
+	"resolve_all.cancel":    "Cancel",
+	"resolve_all.cancelled": "Bulk resolve cancelled.",
+	"resolve_all.done":      "✅ Incidents resolved: %d",
+	"resolve_all.partial":   "⚠️ Incidents resolved: %d, the rest could not be updated.",
+
+	"ack.usage":         "Usage: /ack <ID> [force]",
+	"ack.done":          "Incident #%d acknowledged.",
+	"ack.done_short":    "Incident acknowledged",
+	"ack.already_taken": "The incident is already taken by another user. Use /ack <ID> force to take it over.",
+	"ack.failed":        "Failed to acknowledge the incident.",
+
+	"ack_all.none":    "No active incidents are waiting to be acknowledged.",
+	"ack_all.done":    "✅ Incidents acknowledged: %d",
+	"ack_all.partial": "⚠️ Incidents acknowledged: %d, the rest could not be updated.",
+
+	"assign.usage":          "Usage: /assign <ID> @username",
+	"assign.user_not_found": "User @%s not found. They need to message the bot at least once.",
+	"assign.failed":         "Failed to assign the incident.",
+	"assign.done":           "Incident #%d assigned to %s.",
+
+	"comment.usage":  "Usage: /comment <ID> <text>",
+	"comment.prompt": "Send your comment for incident #%d as a single message.",
+	"comment.empty":  "The comment cannot be empty.",
+	"comment.failed": "Failed to add the comment.",
+	"comment.added":  "Comment added to incident #%d.",
+
+	"tag.usage":       "Usage: /tag <ID> name=value",
+	"tag.untag_usage": "Usage: /untag <ID> name",
+	"tag.invalid":     "Tag names may only contain Latin letters, digits, '_', '-' and '.', and the value cannot be empty.",
+	"tag.not_found":   "The incident has no such tag.",
+	"tag.failed":      "Failed to update the incident tags.",
+	"tag.added":       "Tag %s=%s added to incident #%d.",
+	"tag.removed":     "Tag %s removed from incident #%d.",
+
+	"snooze.15m":    "15 min",
+	"snooze.1h":     "1 hour",
+	"snooze.4h":     "4 hours",
+	"snooze.prompt": "How long should notifications for this incident be snoozed?",
+	"snooze.failed": "Failed to snooze notifications.",
+	"snooze.done":   "Notifications snoozed for %s",
+
+	"reopen.done":           "Incident #%d reopened",
+	"reopen.already_active": "The incident is already active.",
+	"reopen.failed":         "Failed to reopen the incident.",
+
+	"severity.prompt": "Choose the severity of incident #%d (now: %s):",
+	"severity.done":   "Severity changed to %s",
+	"severity.failed": "Failed to change the severity.",
+
+	"lang.current":     "Current language: %s. Available: %s.\nUsage: /lang <code>",
+	"lang.unsupported": "Language %s is not supported. Available: %s.",
+	"lang.failed":      "Failed to save the language.",
+	"lang.set":         "Bot language: English.",
+
+	"executor.unavailable": "⚠️ Executor is unavailable. Actions that change the cluster are hidden for now.",
+
+	"prefs.header":          "Direct notifications:",
+	"prefs.usage":           "Usage: /notify <kind> on|off",
+	"prefs.unknown_kind":    "Unknown notification kind: %s.",
+	"prefs.failed":          "Failed to save notification preferences.",
+	"prefs.kind.assigned":   "an incident is assigned to you",
+	"prefs.kind.escalation": "an incident assigned to you escalates",
+	"prefs.kind.reminder":   "reminders about incidents assigned to you",
+	"prefs.dm_assigned":     "📌 Incident #%d has been assigned to you: %s (by %s).",
+	"prefs.dm_escalation":   "⚠️ Incident #%d assigned to you escalated to level %d: %s",
+	"prefs.dm_reminder":     "⏰ Incident #%d assigned to you is still active (%d min): %s",
+
+	"whoami.header":      "Your account",
+	"whoami.id":          "ID",
+	"whoami.telegram_id": "Telegram ID",
+	"whoami.username":    "Username",
+	"whoami.name":        "Name",
+	"whoami.language":    "Language",
+	"whoami.role":        "Role",
+	"whoami.registered":  "Registered",
+
+	"users.header":     "Users (%d):",
+	"users.failed":     "Failed to list users.",
+	"users.role_admin": "admin",
+	"users.role_user":  "user",
+
+	"admins.grant_usage":   "Usage: /grant_admin @username",
+	"admins.revoke_usage":  "Usage: /revoke_admin @username",
+	"admins.granted":       "%s is now an admin.",
+	"admins.revoked":       "%s is no longer an admin.",
+	"admins.already_admin": "%s is already an admin.",
+	"admins.not_admin":     "%s is not an admin.",
+	"admins.last_admin":    "The last admin's rights cannot be revoked.",
+	"admins.bootstrap":     "%s is listed in telegram.admin_ids in the configuration: remove them there to revoke their rights.",
+	"admins.failed":        "Failed to change admin rights.",
+
+	"refresh.usage":       "Usage: /refresh <ID>",
+	"refresh.no_messages": "Incident #%d has no stored messages, nothing to refresh.",
+	"refresh.failed":      "Failed to refresh the incident messages.",
+	"refresh.done":        "Refreshed %[2]d message(s) of incident #%[1]d.",
+
+	"silence.usage":            "Usage: /silence name=value [name=value ...] <duration>, e.g. /silence alertname=DiskFull 2h",
+	"silence.unsilence_usage":  "Usage: /unsilence <silence ID>",
+	"silence.invalid_duration": "Invalid duration. Examples: 30m, 2h, 3d (at most 30 days).",
+	"silence.added":            "🔇 Silence #%d: %s until %s. Matching alerts will not create incidents.",
+	"silence.removed":          "Silence #%d removed.",
+	"silence.not_found":        "Silence #%d not found.",
+	"silence.none":             "No active silences.",
+	"silence.header":           "🔇 Active silences (%d):",
+	"silence.line":             "• #%d %s until %s (%s)",
+	"silence.failed":           "Failed to update silences.",
+
+	"notify.topic_name":        "Incident #%d",
+	"notify.go_to_topic":       "Go to discussion",
+	"notify.escalation_banner": "@here ⚠️ *NOT ACKNOWLEDGED for %d minutes* ⚠️ level %d\n\n",
+	"notify.escalation_oncall": "On call: @%s\n\n",
+	"notify.snooze_expired":    "🔔 Snooze expired: incident #%d is still active.",
+	"notify.still_firing":      "⏰ Incident #%d is still active and not acknowledged (%d min).",
+	"notify.reopened":          "♻️ Incident #%d has been reopened.",
+
+	"common.error": "Error: %v",
+
+	"status.update_failed":        "Failed to update the incident status.",
+	"status.updated":              "Incident status changed to '%s'.",
+	"status.rejected":             "Incident rejected. Thanks for the feedback!",
+	"status.close_prompt":         "Choose the status to close the incident with:",
+	"status.reject_reason_prompt": "Please send the reason for rejecting the incident in one message.",
+	"status.resolved_button":      "Resolved",
+	"status.rejected_button":      "Rejected",
+
+	"replicas.prompt":         "Enter the desired number of replicas (0 to %d):",
+	"replicas.prompt_current": "Current replicas: %d. Enter the desired number (0 to %d):",
+	"replicas.invalid":        "%s Enter a whole number from 0 to %d:",
+	"replicas.missing":        "The replica count is missing.",
+	"replicas.not_integer":    "“%s” is not a whole number.",
+	"replicas.negative":       "The replica count cannot be negative.",
+	"replicas.too_many":       "Cannot scale beyond %d replicas.",
+	"replicas.large_scale_up": "📈 Current replicas: %d, requested: %d — more than %d times as many.\n",
+	"replicas.invalid_count":  "Invalid replica count.",
+
+	"hardware.invalid_format": "Invalid format: %v. Enter the resources as `cpu=1.5, memory=512Mi`:",
+	"hardware.prompt":         "Enter the requested resources as `cpu=1.5, memory=512Mi`:",
+	"hardware.choose_profile": "Choose a resource profile:",
+	"hardware.custom":         "✏️ Enter manually",
+
+	"resource.title":              "*Resource: %s `%s`*\n\n",
+	"resource.details_failed":     "_Failed to load the resource details\\._\n\n",
+	"resource.choose_action":      "Choose an action:",
+	"resource.status":             "∙ *Status:* `%s`\n",
+	"resource.status_icon":        "∙ *Status:* %s `%s`\n",
+	"resource.replicas":           "∙ *Replicas:* `%s`\n",
+	"resource.available_updated":  "∙ *Available:* `%d`, *updated:* `%d`\n",
+	"resource.rollout_paused":     "∙ *Rollout:* ⏸ `Rollout paused`\n",
+	"resource.age":                "∙ *Age:* `%s`\n",
+	"resource.restarts":           "∙ *Restarts:* `%d`\n",
+	"resource.usage_allocatable":  "*Usage / allocatable:*\n",
+	"resource.pods":               "  ∙ *Pods:* `%d`\n",
+	"resource.pods_capacity":      "  ∙ *Pods:* `%d/%d`\n",
+	"resource.usage":              "*Resource usage:*\n",
+	"resource.container_usage":    "  ∙ *Container:* `%s`\n    ∙ *CPU:* `%s`\n    ∙ *Memory:* `%s`\n",
+	"resource.unlimited":          "no limit",
+	"resource.scale":              "↔️ Scale",
+	"resource.restore_replicas":   "🔁 Restore to %d replicas",
+	"resource.describe":           "📖 Describe",
+	"resource.rollback":           "⏪ Roll back",
+	"resource.pause_rollout":      "⏸ Pause rollout",
+	"resource.resume_rollout":     "▶️ Resume rollout",
+	"resource.allocate":           "⚙️ Allocate resources",
+	"resource.containers":         "Containers",
+	"resource.deployment_actions": "🗂️ Deployment actions",
+	"resource.node_actions":       "🖥️ Node actions",
+	"resource.pod_info":           "*Pod information: %s*\n\n",
+	"resource.no_pods":            "No pods found for this deployment.",
+	"resource.pod_details_failed": "Could not get the pod details.",
+	"resource.choose_container":   "Choose a container to view its logs:",
+	"resource.previous_container": "📄 %s (previous)",
+	"resource.previous_logs":      "Previous container logs",
+	"resource.invalid_log_size":   "Invalid log size.",
+
+	"logfollow.button":          "📄 %s — Logs (live)",
+	"logfollow.too_many":        "Too many live log streams are running, try again later.",
+	"logfollow.already_stopped": "The stream has already finished.",
+	"logfollow.stopped":         "The stream has been stopped.",
+	"logfollow.stop":            "⏹ Stop",
+	"logfollow.header":          "*📄 Logs \\(live\\):* `%s/%s`\n",
+	"logfollow.finished":        "_The stream has finished\\._",
+	"logfollow.refreshing":      "_Refreshed every %d s\\._",
+	"logfollow.no_logs":         "(no logs)",
+
+	"exec.disabled":    "Exec is disabled: the command allowlist is empty.",
+	"exec.not_allowed": "This command is no longer allowed.",
+	"exec.prompt":      "Choose a command for container `%s` of pod `%s`:",
+
+	"kubectl.show":   "📋 Show command",
+	"kubectl.header": "*kubectl commands:*\n",
+
+	"links.prometheus": "🔗 Open in Prometheus",
+	"links.runbook":    "📘 Runbook",
+	"links.dashboard":  "📊 Dashboard",
+
+	"history.truncated":        "_Showing the last %d of %d entries_\n",
+	"history.show_all":         "📄 Show all",
+	"history.document_caption": "Action history of incident #%d (%d entries)",
+	"history.send_failed":      "Failed to send the history.",
+
+	"confirm.prompt":               "⚠️ Are you sure?\nAction: %s\nResource: %s",
+	"confirm.replicas":             "\nReplicas: %s",
+	"confirm.command":              "\nCommand: %s",
+	"confirm.yes":                  "Yes, run it",
+	"confirm.cancel":               "Cancel",
+	"confirm.cancelled":            "Action cancelled",
+	"confirm.expired":              "The confirmation request has expired.",
+	"confirm.not_requester":        "Only the user who requested the action can confirm it.",
+	"confirm.cancel_not_requester": "Only the user who requested the action can cancel it.",
+
+	"incident.acknowledge": "🙋 Take it",
+	"incident.actions":     "▶️ Run actions",
+	"incident.snooze":      "🔕 Snooze",
+	"incident.comment":     "💬 Add a comment",
+	"incident.severity":    "⚠️ Change severity",
+	"incident.reopen":      "♻️ Reopen",
+
+	"audit.reason":    "  *Reason:* %s\n",
+	"audit.command":   "  *Command:* `%s` in `%s/%s`\n",
+	"audit.operation": "  *Operation:* `%s`\n",
+	"audit.replicas":  "  *Replicas:* `%s`\n",
+	"audit.profile":   "  *Profile:* `%s`\n",
+	"audit.resources": "  *Resources:* `%s`\n",
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used when the user's language is unknown or has no catalog.
+const DefaultLanguage = "ru"
+
+// Translator looks up user-facing messages by ID in per-language catalogs.
+type Translator struct {
+	catalogs map[string]map[string]string
+	fallback string
+}
+
+func NewTranslator() *Translator {
+	return &Translator{
+		catalogs: map[string]map[string]string{
+			"ru": ru,
+			"en": en,
+		},
+		fallback: DefaultLanguage,
+	}
+}
+
+// T returns the message for key in lang, falling back to the default language and
+// then to the key itself. Args are applied with fmt.Sprintf.
+func (t *Translator) T(lang, key string, args ...interface{}) string {
+	msg, ok := t.catalogs[Normalize(lang)][key]
+	if !ok {
+		msg, ok = t.catalogs[t.fallback][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func (t *Translator) Supports(lang string) bool {
+	_, ok := t.catalogs[Normalize(lang)]
+	return ok
+}
+
+func (t *Translator) Languages() []string {
+	langs := make([]string, 0, len(t.catalogs))
+	for lang := range t.catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Normalize reduces a Telegram language code such as "en-US" to its base language.
+func Normalize(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func TestTranslatorFallback(t *testing.T) {
+	tr := &Translator{
+		catalogs: map[string]map[string]string{
+			"ru": {"greet": "Привет, %s", "ru_only": "только по-русски"},
+			"en": {"greet": "Hello, %s"},
+		},
+		fallback: "ru",
+	}
+
+	tests := []struct {
+		name string
+		lang string
+		key  string
+		args []interface{}
+		want string
+	}{
+		{name: "exact language", lang: "en", key: "greet", args: []interface{}{"Ann"}, want: "Hello, Ann"},
+		{name: "region is ignored", lang: "en-US", key: "greet", args: []interface{}{"Ann"}, want: "Hello, Ann"},
+		{name: "missing key falls back to default language", lang: "en", key: "ru_only", want: "только по-русски"},
+		{name: "unknown language falls back to default language", lang: "de", key: "greet", args: []interface{}{"Ann"}, want: "Привет, Ann"},
+		{name: "empty language", lang: "", key: "greet", args: []interface{}{"Ann"}, want: "Привет, Ann"},
+		{name: "unknown key returns the key", lang: "en", key: "no.such.key", want: "no.such.key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.T(tt.lang, tt.key, tt.args...); got != tt.want {
+				t.Errorf("T(%q, %q) = %q, want %q", tt.lang, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for code, want := range map[string]string{"ru": "ru", "EN": "en", "en-US": "en", "pt_BR": "pt", "": ""} {
+		if got := Normalize(code); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%(?:\[\d+\])?[-+# 0-9.]*([a-zA-Z%])`)
+
+// TestCatalogsMatch checks that every message exists in every language with the
+// same format verbs, so no language silently falls back or misformats arguments.
+func TestCatalogsMatch(t *testing.T) {
+	tr := NewTranslator()
+	base := tr.catalogs[DefaultLanguage]
+	for _, lang := range tr.Languages() {
+		catalog := tr.catalogs[lang]
+		for key := range base {
+			msg, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, key)
+				continue
+			}
+			if got, want := verbs(msg), verbs(base[key]); !equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := base[key]; !ok {
+				t.Errorf("%s: %q is not in the %s catalog", lang, key, DefaultLanguage)
+			}
+		}
+	}
+}
+
+// verbs returns the sorted verb letters of msg; explicit argument indexes are
+// ignored, since translations may reorder arguments.
+func verbs(msg string) []string {
+	var found []string
+	for _, match := range verbPattern.FindAllStringSubmatch(msg, -1) {
+		if match[1] != "%" {
+			found = append(found, match[1])
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,22 +1,134 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
 type ActionType string
 
 const (
 	ActionRollbackDeployment ActionType = "rollback_deployment"
 	ActionScaleDeployment    ActionType = "scale_deployment"
 	ActionDescribeDeployment ActionType = "describe_deployment"
+	ActionRestartDeployment  ActionType = "restart_deployment"
+	ActionPauseRollout       ActionType = "pause_rollout"
+	ActionResumeRollout      ActionType = "resume_rollout"
 
 	ActionGetPodLogs  ActionType = "get_pod_logs"
 	ActionDescribePod ActionType = "describe_pod"
 	ActionDeletePod   ActionType = "delete_pod"
+	ActionExecInPod   ActionType = "exec_in_pod"
 
 	ActionListPodsForDeployment ActionType = "list_pods_for_deployment"
 
+	ActionCordonNode   ActionType = "cordon_node"
+	ActionUncordonNode ActionType = "uncordon_node"
+	ActionDrainNode    ActionType = "drain_node"
+	ActionDescribeNode ActionType = "describe_node"
+
 	ActionAllocateHardware  ActionType = "allocate_hardware"
 	ActionGetDeploymentInfo ActionType = "get_deployment_info"
 )
 
+func (a ActionType) IsReadOnly() bool {
+	switch a {
+	case ActionDescribeDeployment, ActionGetPodLogs, ActionDescribePod, ActionListPodsForDeployment, ActionGetDeploymentInfo, ActionDescribeNode:
+		return true
+	default:
+		return false
+	}
+}
+
+// kubectlCommands render the kubectl equivalent of an action from its parameters,
+// for operators who prefer to run it themselves.
+var kubectlCommands = map[ActionType]func(p map[string]string) string{
+	ActionRollbackDeployment: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s rollout undo deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionScaleDeployment: func(p map[string]string) string {
+		replicas := p["replicas"]
+		if replicas == "" {
+			replicas = "<N>"
+		}
+		return fmt.Sprintf("kubectl%s scale deployment/%s --replicas=%s", namespaceFlag(p), p["deployment"], replicas)
+	},
+	ActionDescribeDeployment: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s describe deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionRestartDeployment: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s rollout restart deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionPauseRollout: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s rollout pause deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionResumeRollout: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s rollout resume deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionGetDeploymentInfo: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s get deployment/%s", namespaceFlag(p), p["deployment"])
+	},
+	ActionGetPodLogs: func(p map[string]string) string {
+		cmd := fmt.Sprintf("kubectl%s logs %s", namespaceFlag(p), p["pod_name"])
+		if p["container"] != "" {
+			cmd += " -c " + p["container"]
+		}
+		if p["tail"] != "" {
+			cmd += " --tail=" + p["tail"]
+		}
+		if p["previous"] == "true" {
+			cmd += " --previous"
+		}
+		return cmd
+	},
+	ActionDescribePod: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s describe pod/%s", namespaceFlag(p), p["pod_name"])
+	},
+	ActionDeletePod: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl%s delete pod/%s", namespaceFlag(p), p["pod_name"])
+	},
+	ActionExecInPod: func(p map[string]string) string {
+		cmd := fmt.Sprintf("kubectl%s exec %s", namespaceFlag(p), p["pod_name"])
+		if p["container"] != "" {
+			cmd += " -c " + p["container"]
+		}
+		return cmd + " -- sh -c " + shellQuote(p["command"])
+	},
+	ActionCordonNode: func(p map[string]string) string {
+		return "kubectl cordon " + p["node"]
+	},
+	ActionUncordonNode: func(p map[string]string) string {
+		return "kubectl uncordon " + p["node"]
+	},
+	ActionDrainNode: func(p map[string]string) string {
+		return fmt.Sprintf("kubectl drain %s --ignore-daemonsets --delete-emptydir-data", p["node"])
+	},
+	ActionDescribeNode: func(p map[string]string) string {
+		return "kubectl describe node/" + p["node"]
+	},
+}
+
+// KubectlCommand returns the kubectl command equivalent to the action, or "" if
+// there is none (e.g. allocate_hardware goes through the executor's own API).
+func KubectlCommand(action string, params map[string]string) string {
+	render, ok := kubectlCommands[ActionType(action)]
+	if !ok {
+		return ""
+	}
+	return render(params)
+}
+
+func namespaceFlag(p map[string]string) string {
+	if p["namespace"] == "" {
+		return ""
+	}
+	return " -n " + p["namespace"]
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 type ActionResult struct {
 	Message    string      `json:"message"`
 	Error      string      `json:"error,omitempty"`
@@ -40,6 +152,11 @@ type ActionRequest struct {
 	IncidentID uint              `json:"incident_id"`
 	UserID     uint              `json:"user_id"`
 	Parameters map[string]string `json:"parameters"`
+	// IdempotencyKey, when set, makes the service reject a second execution of the
+	// same request (e.g. a callback delivered twice by Telegram).
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// DryRun audits a mutating action without sending it to the executor.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 type SuggestedAction struct {
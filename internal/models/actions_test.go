@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestKubectlCommand(t *testing.T) {
+	deployment := map[string]string{"deployment": "api-gateway", "namespace": "prod"}
+	pod := map[string]string{"pod_name": "api-0", "namespace": "prod"}
+	node := map[string]string{"node": "worker-1"}
+
+	tests := []struct {
+		action ActionType
+		params map[string]string
+		want   string
+	}{
+		{ActionRollbackDeployment, deployment, "kubectl -n prod rollout undo deployment/api-gateway"},
+		{ActionScaleDeployment, map[string]string{"deployment": "api-gateway", "namespace": "prod", "replicas": "3"}, "kubectl -n prod scale deployment/api-gateway --replicas=3"},
+		{ActionScaleDeployment, deployment, "kubectl -n prod scale deployment/api-gateway --replicas=<N>"},
+		{ActionDescribeDeployment, deployment, "kubectl -n prod describe deployment/api-gateway"},
+		{ActionRestartDeployment, deployment, "kubectl -n prod rollout restart deployment/api-gateway"},
+		{ActionPauseRollout, deployment, "kubectl -n prod rollout pause deployment/api-gateway"},
+		{ActionResumeRollout, deployment, "kubectl -n prod rollout resume deployment/api-gateway"},
+		{ActionGetDeploymentInfo, map[string]string{"deployment": "api-gateway"}, "kubectl get deployment/api-gateway"},
+		{ActionGetPodLogs, pod, "kubectl -n prod logs api-0"},
+		{
+			ActionGetPodLogs,
+			map[string]string{"pod_name": "api-0", "namespace": "prod", "container": "app", "tail": "100", "previous": "true"},
+			"kubectl -n prod logs api-0 -c app --tail=100 --previous",
+		},
+		{ActionDescribePod, pod, "kubectl -n prod describe pod/api-0"},
+		{ActionDeletePod, pod, "kubectl -n prod delete pod/api-0"},
+		{
+			ActionExecInPod,
+			map[string]string{"pod_name": "api-0", "namespace": "prod", "container": "app", "command": "echo 'hi'"},
+			`kubectl -n prod exec api-0 -c app -- sh -c 'echo '\''hi'\'''`,
+		},
+		{ActionCordonNode, node, "kubectl cordon worker-1"},
+		{ActionUncordonNode, node, "kubectl uncordon worker-1"},
+		{ActionDrainNode, node, "kubectl drain worker-1 --ignore-daemonsets --delete-emptydir-data"},
+		{ActionDescribeNode, node, "kubectl describe node/worker-1"},
+		{ActionListPodsForDeployment, deployment, ""},
+		{ActionAllocateHardware, deployment, ""},
+		{"unknown", deployment, ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			if got := KubectlCommand(string(tt.action), tt.params); got != tt.want {
+				t.Errorf("KubectlCommand(%s, %v) = %q, want %q", tt.action, tt.params, got, tt.want)
+			}
+		})
+	}
+}
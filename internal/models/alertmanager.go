@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+)
 
 type AlertmanagerWebhookMessage struct {
 	Version           string            `json:"version"`
@@ -28,3 +33,26 @@ type Alert struct {
 	GeneratorURL string      `json:"generatorURL"`
 	Fingerprint  string      `json:"fingerprint"`
 }
+
+// labelSeparator separates label names and values when hashing, as in Prometheus.
+const labelSeparator = 0xff
+
+// ComputeFingerprint hashes the sorted label set the way Prometheus does (FNV-1a
+// over names and values), so alerts that arrive without a fingerprint still
+// deduplicate. It is used only when Alertmanager omits the field.
+func (a Alert) ComputeFingerprint() string {
+	names := make([]string, 0, len(a.Labels))
+	for name := range a.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := fnv.New64a()
+	for _, name := range names {
+		hash.Write([]byte(name))
+		hash.Write([]byte{labelSeparator})
+		hash.Write([]byte(a.Labels[name]))
+		hash.Write([]byte{labelSeparator})
+	}
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
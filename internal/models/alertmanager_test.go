@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestComputeFingerprint(t *testing.T) {
+	base := Labels{"alertname": "HighCPU", "namespace": "prod", "pod": "api-0"}
+
+	tests := []struct {
+		name      string
+		labels    Labels
+		wantEqual bool
+	}{
+		{name: "identical labels", labels: Labels{"alertname": "HighCPU", "namespace": "prod", "pod": "api-0"}, wantEqual: true},
+		{name: "different value", labels: Labels{"alertname": "HighCPU", "namespace": "prod", "pod": "api-1"}},
+		{name: "extra label", labels: Labels{"alertname": "HighCPU", "namespace": "prod", "pod": "api-0", "severity": "critical"}},
+		{name: "missing label", labels: Labels{"alertname": "HighCPU", "namespace": "prod"}},
+		{name: "value moved between labels", labels: Labels{"alertname": "HighCPU", "namespace": "prodpod", "": "api-0"}},
+	}
+	want := Alert{Labels: base}.ComputeFingerprint()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Alert{Labels: tt.labels}.ComputeFingerprint()
+			if (got == want) != tt.wantEqual {
+				t.Errorf("fingerprint %s vs %s, want equal = %v", got, want, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestComputeFingerprintIgnoresAnnotationsAndTimes(t *testing.T) {
+	labels := Labels{"alertname": "HighCPU"}
+	a := Alert{Labels: labels, Status: "firing", Annotations: Annotations{"summary": "a"}}
+	b := Alert{Labels: labels, Status: "resolved", Annotations: Annotations{"summary": "b"}, GeneratorURL: "http://prometheus"}
+	if a.ComputeFingerprint() != b.ComputeFingerprint() {
+		t.Error("fingerprint depends on more than the labels")
+	}
+	if got := a.ComputeFingerprint(); len(got) != 16 {
+		t.Errorf("fingerprint %q is not 16 hex digits", got)
+	}
+}
+
+func TestComputeFingerprintSeparatesNamesAndValues(t *testing.T) {
+	a := Alert{Labels: Labels{"a": "bc"}}.ComputeFingerprint()
+	b := Alert{Labels: Labels{"ab": "c"}}.ComputeFingerprint()
+	if a == b {
+		t.Error("label name and value boundaries are not part of the hash")
+	}
+}
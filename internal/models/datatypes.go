@@ -4,6 +4,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 type JSONBMap map[string]string
@@ -15,10 +18,27 @@ func (m JSONBMap) Value() (driver.Value, error) {
 	return json.Marshal(m)
 }
 
+// Scan accepts both []byte (SQLite) and string (PostgreSQL jsonb) column values.
 func (m *JSONBMap) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("type assertion to []byte or string failed")
 	}
 	return json.Unmarshal(b, &m)
 }
+
+// GormDBDataType maps the column to native jsonb on PostgreSQL and to text elsewhere.
+func (JSONBMap) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
@@ -7,13 +7,37 @@ type ResourceDetailsRequest struct {
 	Labels       map[string]string `json:"labels"`
 }
 
+// Deployment health reported in ResourceDetails.Status.
+const (
+	DeploymentHealthy  = "Healthy"
+	DeploymentDegraded = "Degraded"
+)
+
 type ResourceDetails struct {
-	Status       string               `json:"status"`
-	ReplicasInfo string               `json:"replicas_info,omitempty"`
-	Restarts     int                  `json:"restarts,omitempty"`
-	Age          string               `json:"age"`
-	RawOutput    string               `json:"raw_output"`
-	Resources    []ContainerResources `json:"resources,omitempty"`
+	Status            string               `json:"status"`
+	ReplicasInfo      string               `json:"replicas_info,omitempty"`
+	DesiredReplicas   int                  `json:"desired_replicas,omitempty"`
+	ReadyReplicas     int                  `json:"ready_replicas,omitempty"`
+	AvailableReplicas int                  `json:"available_replicas,omitempty"`
+	UpdatedReplicas   int                  `json:"updated_replicas,omitempty"`
+	Restarts          int                  `json:"restarts,omitempty"`
+	Age               string               `json:"age"`
+	RawOutput         string               `json:"raw_output"`
+	Resources         []ContainerResources `json:"resources,omitempty"`
+	Node              *NodeResources       `json:"node,omitempty"`
+	// Paused is set for deployments whose rollout is paused.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// NodeResources compares a node's allocatable capacity with what is in use.
+// CPU is in millicores and memory in bytes, as for containers.
+type NodeResources struct {
+	CpuAllocatable    int64 `json:"cpuAllocatable"`
+	MemoryAllocatable int64 `json:"memoryAllocatable"`
+	CpuUsage          int64 `json:"cpuUsage"`
+	MemoryUsage       int64 `json:"memoryUsage"`
+	Pods              int   `json:"pods"`
+	PodCapacity       int   `json:"podCapacity"`
 }
 
 type ContainerResources struct {
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	FlagAutoResolve         = "auto_resolve"
+	FlagConfirmationPrompts = "confirmation_prompts"
+	FlagAdminGating         = "admin_gating"
+	FlagDryRun              = "dry_run"
+)
+
+// KnownFeatureFlags maps every supported flag to its default value.
+var KnownFeatureFlags = map[string]bool{
+	FlagAutoResolve:         true,
+	FlagConfirmationPrompts: true,
+	FlagAdminGating:         true,
+	FlagDryRun:              false,
+}
+
+type FeatureFlag struct {
+	Name      string `gorm:"primaryKey"`
+	Enabled   bool   `gorm:"not null"`
+	UpdatedAt time.Time
+}
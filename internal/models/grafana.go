@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// GrafanaWebhookMessage is the payload of Grafana unified alerting's webhook
+// contact point.
+type GrafanaWebhookMessage struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Title             string            `json:"title"`
+	State             string            `json:"state"`
+	Message           string            `json:"message"`
+}
+
+type GrafanaAlert struct {
+	Status       string             `json:"status"`
+	Labels       Labels             `json:"labels"`
+	Annotations  Annotations        `json:"annotations"`
+	StartsAt     time.Time          `json:"startsAt"`
+	EndsAt       time.Time          `json:"endsAt"`
+	Values       map[string]float64 `json:"values"`
+	ValueString  string             `json:"valueString"`
+	GeneratorURL string             `json:"generatorURL"`
+	Fingerprint  string             `json:"fingerprint"`
+	SilenceURL   string             `json:"silenceURL"`
+	DashboardURL string             `json:"dashboardURL"`
+	PanelURL     string             `json:"panelURL"`
+}
+
+// ToAlert maps a Grafana alert to the Alertmanager shape the incident service
+// works with. The evaluated values go to the "value_string" annotation, the
+// dashboard link to "dashboard_url", and the panel link, when there is one,
+// replaces the rule link as GeneratorURL.
+func (g GrafanaAlert) ToAlert() Alert {
+	annotations := make(Annotations, len(g.Annotations)+2)
+	for key, value := range g.Annotations {
+		annotations[key] = value
+	}
+	if g.ValueString != "" {
+		annotations["value_string"] = g.ValueString
+	}
+	// Grafana-managed rules often have no summary annotation.
+	if annotations["summary"] == "" {
+		annotations["summary"] = g.Labels["alertname"]
+	}
+	if annotations["dashboard_url"] == "" && g.DashboardURL != "" {
+		annotations["dashboard_url"] = g.DashboardURL
+	}
+
+	generatorURL := g.GeneratorURL
+	if g.PanelURL != "" {
+		generatorURL = g.PanelURL
+	}
+
+	return Alert{
+		Status:       g.Status,
+		Labels:       g.Labels,
+		Annotations:  annotations,
+		StartsAt:     g.StartsAt,
+		EndsAt:       g.EndsAt,
+		GeneratorURL: generatorURL,
+		Fingerprint:  g.Fingerprint,
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,30 +22,188 @@ type User struct {
 	Username   string `gorm:"uniqueIndex"`
 	FirstName  string
 	LastName   string
-	IsAdmin    bool `gorm:"default:true"`
+	IsAdmin    bool
+	Language   string `gorm:"not null;default:''"`
+	// NotifyPrefs selects which direct messages the user receives.
+	NotifyPrefs NotificationPrefs `gorm:"not null;default:0"`
+}
+
+// IncidentExportRow is one incident in a data export.
+type IncidentExportRow struct {
+	ID          uint           `json:"id"`
+	Fingerprint string         `json:"fingerprint"`
+	Status      IncidentStatus `json:"status"`
+	Severity    string         `json:"severity" gorm:"-"`
+	Summary     string         `json:"summary"`
+	Labels      JSONBMap       `json:"labels"`
+	StartsAt    time.Time      `json:"starts_at"`
+	EndsAt      *time.Time     `json:"ends_at"`
+	ResolvedBy  string         `json:"resolved_by"`
+	ActionCount int64          `json:"action_count"`
+}
+
+// PermissionChange records an admin right being granted or revoked from chat.
+type PermissionChange struct {
+	ID        uint `gorm:"primarykey"`
+	ActorID   uint `gorm:"not null"`
+	UserID    uint `gorm:"index;not null"`
+	IsAdmin   bool `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// Silence suppresses incident creation for alerts whose labels match all of
+// Matchers until EndsAt.
+type Silence struct {
+	ID        uint      `gorm:"primarykey"`
+	Matchers  JSONBMap  `gorm:"not null"`
+	CreatedBy uint      `gorm:"not null"`
+	EndsAt    time.Time `gorm:"index;not null"`
+	CreatedAt time.Time
+}
+
+// Matches reports whether every matcher of the silence equals the label of the
+// same name.
+func (s *Silence) Matches(labels map[string]string) bool {
+	if len(s.Matchers) == 0 {
+		return false
+	}
+	for name, value := range s.Matchers {
+		if labelValue, ok := labels[name]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// NotificationPrefs is a set of direct-message kinds a user has opted in to. The
+// zero value means no direct messages.
+type NotificationPrefs uint
+
+const (
+	// NotifyAssigned sends a message when an incident is assigned to the user.
+	NotifyAssigned NotificationPrefs = 1 << iota
+	// NotifyEscalation sends a message when an incident assigned to the user escalates.
+	NotifyEscalation
+	// NotifyReminder forwards the "still firing" reminders of incidents assigned to the user.
+	NotifyReminder
+)
+
+// NotificationKinds maps the names used by /notify to their flags.
+var NotificationKinds = []struct {
+	Name string
+	Flag NotificationPrefs
+}{
+	{"assigned", NotifyAssigned},
+	{"escalation", NotifyEscalation},
+	{"reminder", NotifyReminder},
+}
+
+func (p NotificationPrefs) Has(flag NotificationPrefs) bool {
+	return p&flag != 0
+}
+
+// With returns p with flag turned on or off.
+func (p NotificationPrefs) With(flag NotificationPrefs, enabled bool) NotificationPrefs {
+	if enabled {
+		return p | flag
+	}
+	return p &^ flag
 }
 
 type Incident struct {
 	gorm.Model
-	ID                uint           `gorm:"primarykey"`
-	Fingerprint       string         `gorm:"uniqueIndex;not null"`
-	Status            IncidentStatus `gorm:"index;not null"`
-	StartsAt          time.Time
-	EndsAt            *time.Time
-	Summary           string
-	Description       string
-	Labels            JSONBMap
-	AffectedResources JSONBMap
-	AuditLog          []AuditRecord `gorm:"foreignKey:IncidentID"`
-	ResolvedBy        *uint
-	ResolvedByUser    User `gorm:"foreignKey:ResolvedBy"`
-	RejectionReason   string
+	ID          uint           `gorm:"primarykey"`
+	Fingerprint string         `gorm:"uniqueIndex;not null"`
+	Status      IncidentStatus `gorm:"index;not null"`
+	StartsAt    time.Time
+	EndsAt      *time.Time
+	Summary     string
+	Description string
+	Labels      JSONBMap
+	Annotations JSONBMap
+	// GeneratorURL links to the alerting rule's expression in Prometheus.
+	GeneratorURL       string `gorm:"not null;default:''"`
+	AffectedResources  JSONBMap
+	AuditLog           []AuditRecord `gorm:"foreignKey:IncidentID"`
+	ResolvedBy         *uint
+	ResolvedByUser     User `gorm:"foreignKey:ResolvedBy"`
+	RejectionReason    string
+	EscalatedAt        *time.Time
+	EscalationLevel    int `gorm:"not null;default:0"`
+	AcknowledgedBy     *uint
+	AcknowledgedByUser User `gorm:"foreignKey:AcknowledgedBy"`
+	AcknowledgedAt     *time.Time
+	SnoozedUntil       *time.Time
+	AssignedTo         *uint
+	AssignedToUser     User `gorm:"foreignKey:AssignedTo"`
+	OccurrenceCount    int  `gorm:"not null;default:1"`
+	// ActiveSince is when the incident was opened or last reopened; escalation
+	// and reminder ages count from it.
+	ActiveSince time.Time
+	// LastRemindedAt is when the last "still firing" reminder was sent since the
+	// incident was last opened.
+	LastRemindedAt *time.Time
+	// NotificationPending marks incidents created during quiet hours that have
+	// not been announced yet.
+	NotificationPending bool `gorm:"not null;default:false"`
 
 	TelegramChatID    sql.NullInt64 `gorm:"index"`
 	TelegramMessageID sql.NullInt64 `gorm:"index"`
 	TelegramTopicID   sql.NullInt64 `gorm:"index"`
 }
 
+// Kinds of Telegram messages showing an incident.
+const (
+	TelegramMessagePrimary  = "primary"
+	TelegramMessageSummary  = "summary"
+	TelegramMessageResolved = "resolved"
+	TelegramMessageView     = "view"
+)
+
+// TelegramMessage is one Telegram message rendering an incident. An incident can have
+// several (topic message, channel summary, views opened via commands); all of them are
+// re-rendered when the incident changes, including after a restart.
+type TelegramMessage struct {
+	ID         uint   `gorm:"primarykey"`
+	IncidentID uint   `gorm:"index;not null"`
+	ChatID     int64  `gorm:"not null"`
+	MessageID  int64  `gorm:"not null"`
+	ThreadID   int64  `gorm:"not null;default:0"`
+	Kind       string `gorm:"not null;default:''"`
+	CreatedAt  time.Time
+}
+
+func (i *Incident) IsSnoozed(now time.Time) bool {
+	return i.SnoozedUntil != nil && i.SnoozedUntil.After(now)
+}
+
+// CustomTagPrefix marks incident labels added by operators with /tag, as opposed
+// to labels that came with the alert. Prometheus label names cannot contain ':'.
+const CustomTagPrefix = "tag:"
+
+// CustomTags returns the operator-added tags without their prefix, or nil if
+// there are none.
+func (i *Incident) CustomTags() map[string]string {
+	var tags map[string]string
+	for key, value := range i.Labels {
+		if name, ok := strings.CutPrefix(key, CustomTagPrefix); ok {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[name] = value
+		}
+	}
+	return tags
+}
+
+// LastAuditAction returns the action of the most recent audit record, if any.
+func (i *Incident) LastAuditAction() string {
+	if len(i.AuditLog) == 0 {
+		return ""
+	}
+	return i.AuditLog[len(i.AuditLog)-1].Action
+}
+
 type AuditRecord struct {
 	gorm.Model
 	IncidentID uint `gorm:"index;not null"`
@@ -55,4 +214,9 @@ type AuditRecord struct {
 	Timestamp  time.Time `gorm:"not null"`
 	Success    bool
 	Result     string `gorm:"type:text"`
+	// IdempotencyKey identifies the user interaction that triggered the action, so a
+	// replayed callback is not executed twice. Empty for entries without one.
+	IdempotencyKey string
+	// DryRun marks actions that were only simulated.
+	DryRun bool `gorm:"not null;default:false"`
 }
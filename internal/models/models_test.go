@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestSilenceMatches(t *testing.T) {
+	labels := map[string]string{"alertname": "HighCPU", "namespace": "prod", "pod": "api-0"}
+
+	tests := []struct {
+		name     string
+		matchers JSONBMap
+		want     bool
+	}{
+		{"single matcher", JSONBMap{"alertname": "HighCPU"}, true},
+		{"all matchers equal", JSONBMap{"alertname": "HighCPU", "namespace": "prod"}, true},
+		{"one matcher differs", JSONBMap{"alertname": "HighCPU", "namespace": "staging"}, false},
+		{"label missing", JSONBMap{"cluster": "eu-1"}, false},
+		{"values are case-sensitive", JSONBMap{"alertname": "highcpu"}, false},
+		{"no matchers silence nothing", JSONBMap{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			silence := &Silence{Matchers: tt.matchers}
+			if got := silence.Matches(labels); got != tt.want {
+				t.Errorf("Matches(%v) with matchers %v = %v, want %v", labels, tt.matchers, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+const notifyTimeout = 15 * time.Second
+
+type Notifier interface {
+	Name() string
+	NotifyNewIncident(ctx context.Context, incident *models.Incident) error
+	NotifyUpdate(ctx context.Context, incident *models.Incident) error
+}
+
+// Fanout delivers incident events to every registered notifier. Each notifier runs
+// in its own goroutine so a slow or failing one does not block the others.
+type Fanout struct {
+	notifiers []Notifier
+}
+
+func NewFanout(notifiers ...Notifier) *Fanout {
+	return &Fanout{notifiers: notifiers}
+}
+
+func (f *Fanout) Register(n Notifier) {
+	f.notifiers = append(f.notifiers, n)
+}
+
+func (f *Fanout) NotifyNewIncident(incident *models.Incident) {
+	f.dispatch(incident, "new incident", Notifier.NotifyNewIncident)
+}
+
+func (f *Fanout) NotifyUpdate(incident *models.Incident) {
+	f.dispatch(incident, "update", Notifier.NotifyUpdate)
+}
+
+func (f *Fanout) dispatch(incident *models.Incident, kind string, notify func(Notifier, context.Context, *models.Incident) error) {
+	for _, n := range f.notifiers {
+		go func(n Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+			if err := notify(n, ctx, incident); err != nil {
+				slog.Error("Notifier failed to deliver event", "notifier", n.Name(), "kind", kind, "incident_id", incident.ID, "error", err)
+			}
+		}(n)
+	}
+}
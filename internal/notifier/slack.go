@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"chatops-bot/internal/models"
+)
+
+type SlackNotifier struct {
+	client        *http.Client
+	webhookURL    string
+	severityLabel string
+}
+
+// NewSlackNotifier posts to the Slack incoming webhook. severityLabel is the alert
+// label holding the severity; empty means "severity".
+func NewSlackNotifier(webhookURL, severityLabel string) *SlackNotifier {
+	if severityLabel == "" {
+		severityLabel = "severity"
+	}
+	return &SlackNotifier{
+		client:        &http.Client{},
+		webhookURL:    webhookURL,
+		severityLabel: severityLabel,
+	}
+}
+
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+func (n *SlackNotifier) NotifyNewIncident(ctx context.Context, incident *models.Incident) error {
+	return n.post(ctx, fmt.Sprintf(":rotating_light: *Новый инцидент #%d*", incident.ID), incident)
+}
+
+// NotifyUpdate only mirrors lifecycle changes; routine actions such as viewing logs are skipped.
+func (n *SlackNotifier) NotifyUpdate(ctx context.Context, incident *models.Incident) error {
+	switch incident.LastAuditAction() {
+	case "update_status", "acknowledge":
+	default:
+		return nil
+	}
+	return n.post(ctx, fmt.Sprintf(":information_source: *Инцидент #%d обновлен*", incident.ID), incident)
+}
+
+// telegramLink links to the incident's topic, or to its message when it has no
+// topic, in the chat it was actually posted to. Empty until it is posted.
+func telegramLink(incident *models.Incident) string {
+	if !incident.TelegramChatID.Valid || incident.TelegramChatID.Int64 == 0 {
+		return ""
+	}
+	chatIDForLink := strings.TrimPrefix(strconv.FormatInt(incident.TelegramChatID.Int64, 10), "-100")
+	switch {
+	case incident.TelegramTopicID.Valid && incident.TelegramTopicID.Int64 != 0:
+		return fmt.Sprintf("https://t.me/c/%s/%d", chatIDForLink, incident.TelegramTopicID.Int64)
+	case incident.TelegramMessageID.Valid && incident.TelegramMessageID.Int64 != 0:
+		return fmt.Sprintf("https://t.me/c/%s/%d", chatIDForLink, incident.TelegramMessageID.Int64)
+	}
+	return ""
+}
+
+func (n *SlackNotifier) post(ctx context.Context, header string, incident *models.Incident) error {
+	severity := incident.Labels[n.severityLabel]
+	if severity == "" {
+		severity = "N/A"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(header + "\n")
+	builder.WriteString(fmt.Sprintf("*%s*\n", incident.Summary))
+	builder.WriteString(fmt.Sprintf("Статус: `%s` | Серьезность: `%s`", incident.Status, severity))
+	if namespace, ok := incident.Labels["namespace"]; ok {
+		builder.WriteString(fmt.Sprintf(" | Namespace: `%s`", namespace))
+	}
+	if link := telegramLink(incident); link != "" {
+		builder.WriteString(fmt.Sprintf("\n<%s|Обсуждение в Telegram>", link))
+	}
+
+	payload, err := json.Marshal(map[string]string{"text": builder.String()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack webhook returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+)
+
+// slackServer records the text of every message posted to it.
+func slackServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		texts = append(texts, payload["text"])
+	}))
+	t.Cleanup(server.Close)
+	return server, &texts
+}
+
+func TestSlackNotifierMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		severityLabel string
+		incident      models.Incident
+		want          []string
+		notWant       []string
+	}{
+		{
+			name: "topic link in the incident's chat",
+			incident: models.Incident{
+				Labels:            models.JSONBMap{"severity": "critical"},
+				TelegramChatID:    sql.NullInt64{Int64: -1002000000001, Valid: true},
+				TelegramMessageID: sql.NullInt64{Int64: 55, Valid: true},
+				TelegramTopicID:   sql.NullInt64{Int64: 7, Valid: true},
+			},
+			want: []string{"https://t.me/c/2000000001/7|", "Серьезность: `critical`"},
+		},
+		{
+			name: "message link without a topic",
+			incident: models.Incident{
+				Labels:            models.JSONBMap{"severity": "warning"},
+				TelegramChatID:    sql.NullInt64{Int64: -1003000000002, Valid: true},
+				TelegramMessageID: sql.NullInt64{Int64: 55, Valid: true},
+			},
+			want: []string{"https://t.me/c/3000000002/55|"},
+		},
+		{
+			name:     "not posted yet",
+			incident: models.Incident{Labels: models.JSONBMap{}},
+			want:     []string{"Серьезность: `N/A`"},
+			notWant:  []string{"t.me"},
+		},
+		{
+			name:          "custom severity label",
+			severityLabel: "priority",
+			incident:      models.Incident{Labels: models.JSONBMap{"priority": "P1", "severity": "ignored"}},
+			want:          []string{"Серьезность: `P1`"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, texts := slackServer(t)
+			n := NewSlackNotifier(server.URL, tt.severityLabel)
+			incident := tt.incident
+			incident.ID = 1
+			if err := n.NotifyNewIncident(context.Background(), &incident); err != nil {
+				t.Fatal(err)
+			}
+			if len(*texts) != 1 {
+				t.Fatalf("got %d messages, want 1", len(*texts))
+			}
+			text := (*texts)[0]
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("message %q does not contain %q", text, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("message %q contains %q", text, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestSlackNotifierSkipsRoutineUpdates(t *testing.T) {
+	server, texts := slackServer(t)
+	n := NewSlackNotifier(server.URL, "")
+	for _, action := range []string{"get_pod_logs", "update_status"} {
+		incident := &models.Incident{AuditLog: []models.AuditRecord{{Action: action}}}
+		if err := n.NotifyUpdate(context.Background(), incident); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(*texts) != 1 {
+		t.Errorf("got %d messages, want only the status change", len(*texts))
+	}
+}
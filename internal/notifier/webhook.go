@@ -0,0 +1,154 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+const (
+	EventIncidentCreated      = "incident.created"
+	EventIncidentAcknowledged = "incident.acknowledged"
+	EventIncidentClosed       = "incident.closed"
+
+	SignatureHeader = "X-Signature-256"
+
+	defaultWebhookTimeout    = 5 * time.Second
+	defaultWebhookRetryCount = 3
+	defaultWebhookQueueSize  = 100
+	webhookRetryBaseDelay    = 500 * time.Millisecond
+)
+
+var ErrWebhookQueueFull = errors.New("webhook queue is full")
+
+type WebhookPayload struct {
+	Event     string           `json:"event"`
+	Incident  *models.Incident `json:"incident"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// WebhookNotifier POSTs incident lifecycle events to an external URL. Events are
+// delivered by a background worker from a bounded queue so a slow receiver never
+// stalls the bot's listeners.
+type WebhookNotifier struct {
+	client     *http.Client
+	url        string
+	secret     string
+	retryCount int
+	queue      chan webhookDelivery
+}
+
+type webhookDelivery struct {
+	event      string
+	incidentID uint
+	body       []byte
+}
+
+func NewWebhookNotifier(url, secret string, timeout time.Duration, retryCount, queueSize int) *WebhookNotifier {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	if retryCount <= 0 {
+		retryCount = defaultWebhookRetryCount
+	}
+	if queueSize <= 0 {
+		queueSize = defaultWebhookQueueSize
+	}
+	n := &WebhookNotifier{
+		client:     &http.Client{Timeout: timeout},
+		url:        url,
+		secret:     secret,
+		retryCount: retryCount,
+		queue:      make(chan webhookDelivery, queueSize),
+	}
+	go n.worker()
+	return n
+}
+
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) NotifyNewIncident(ctx context.Context, incident *models.Incident) error {
+	return n.enqueue(EventIncidentCreated, incident)
+}
+
+func (n *WebhookNotifier) NotifyUpdate(ctx context.Context, incident *models.Incident) error {
+	switch incident.LastAuditAction() {
+	case "acknowledge":
+		return n.enqueue(EventIncidentAcknowledged, incident)
+	case "update_status":
+		if incident.Status == models.StatusResolved || incident.Status == models.StatusRejected {
+			return n.enqueue(EventIncidentClosed, incident)
+		}
+	}
+	return nil
+}
+
+func (n *WebhookNotifier) enqueue(event string, incident *models.Incident) error {
+	body, err := json.Marshal(WebhookPayload{Event: event, Incident: incident, Timestamp: time.Now()})
+	if err != nil {
+		return err
+	}
+	select {
+	case n.queue <- webhookDelivery{event: event, incidentID: incident.ID, body: body}:
+		return nil
+	default:
+		return ErrWebhookQueueFull
+	}
+}
+
+func (n *WebhookNotifier) worker() {
+	for delivery := range n.queue {
+		if err := n.deliver(delivery.body); err != nil {
+			slog.Error("Webhook notifier gave up on delivery", "event", delivery.event, "incident_id", delivery.incidentID, "error", err)
+		}
+	}
+}
+
+func (n *WebhookNotifier) deliver(body []byte) error {
+	var err error
+	for attempt := 0; attempt < n.retryCount; attempt++ {
+		if attempt > 0 {
+			time.Sleep(webhookRetryBaseDelay << (attempt - 1))
+		}
+		if err = n.post(body); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (n *WebhookNotifier) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+// auditTestEnv is an API router over an incident whose audit log holds the
+// executed actions, one minute apart.
+type auditTestEnv struct {
+	handler    http.Handler
+	incidentID uint
+	actions    []string
+}
+
+func newAuditTestEnv(t *testing.T, actions ...models.ActionType) *auditTestEnv {
+	t.Helper()
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := service.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	svc := service.NewIncidentService(repo, users, mock.NewExecutorClientMock(), nil, nil, nil, nil, nil, nil, nil, clock, testutil.DiscardLogger())
+
+	ctx := context.Background()
+	user, err := users.FindOrCreateByTelegramID(ctx, 42, "alice", "Alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	incident, err := svc.CreateIncidentFromAlert(ctx, models.Alert{Status: "firing", Fingerprint: "fp-audit", Labels: map[string]string{"alertname": "Audit"}, StartsAt: clock.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &auditTestEnv{incidentID: incident.ID}
+	for _, entry := range incident.AuditLog {
+		env.actions = append(env.actions, entry.Action)
+	}
+	for _, action := range actions {
+		clock.Advance(time.Minute)
+		_, err := svc.ExecuteAction(ctx, models.ActionRequest{
+			Action:     string(action),
+			IncidentID: incident.ID,
+			UserID:     user.ID,
+			Parameters: map[string]string{"deployment": "api", "namespace": "prod", "replicas": "2"},
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+		env.actions = append(env.actions, string(action))
+	}
+	env.handler = newRouter(testutil.DiscardLogger(), svc, users, fakePinger{}, "secret", time.Second)
+	return env
+}
+
+func (e *auditTestEnv) get(t *testing.T, query string) ([]auditEntryResponse, *httptest.ResponseRecorder) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/"+query, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, req)
+	var entries []auditEntryResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+			t.Fatalf("invalid body %s: %v", rec.Body, err)
+		}
+	}
+	return entries, rec
+}
+
+func TestGetAuditLogOrder(t *testing.T) {
+	env := newAuditTestEnv(t, models.ActionRestartDeployment, models.ActionScaleDeployment, models.ActionRollbackDeployment)
+
+	entries, rec := env.get(t, "1/audit")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if len(entries) != len(env.actions) {
+		t.Fatalf("entries = %d, want %d", len(entries), len(env.actions))
+	}
+	for i, entry := range entries {
+		if entry.Action != env.actions[i] {
+			t.Errorf("entry %d = %s, want %s", i, entry.Action, env.actions[i])
+		}
+		if i > 0 && entry.Timestamp.Before(entries[i-1].Timestamp) {
+			t.Errorf("entry %d at %v is older than the one before it", i, entry.Timestamp)
+		}
+	}
+	last := entries[len(entries)-1]
+	if last.User != "alice" || !last.Success || last.Parameters["deployment"] != "api" {
+		t.Errorf("last entry = %+v, want a successful rollback by alice", last)
+	}
+}
+
+func TestGetAuditLogPagination(t *testing.T) {
+	env := newAuditTestEnv(t, models.ActionRestartDeployment, models.ActionScaleDeployment, models.ActionRollbackDeployment, models.ActionRestartDeployment, models.ActionScaleDeployment)
+	total := len(env.actions)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantFirst  int
+		wantCount  int
+	}{
+		{"default page", "", http.StatusOK, 0, total},
+		{"first page", "?limit=2", http.StatusOK, 0, 2},
+		{"second page", "?limit=2&offset=2", http.StatusOK, 2, 2},
+		{"last partial page", "?limit=2&offset=4", http.StatusOK, 4, total - 4},
+		{"offset past the end", "?offset=100", http.StatusOK, 0, 0},
+		{"zero limit", "?limit=0", http.StatusBadRequest, 0, 0},
+		{"limit too large", "?limit=501", http.StatusBadRequest, 0, 0},
+		{"negative offset", "?offset=-1", http.StatusBadRequest, 0, 0},
+		{"malformed limit", "?limit=ten", http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, rec := env.get(t, "1/audit"+tt.query)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("X-Total-Count"); got != strconv.Itoa(total) {
+				t.Errorf("X-Total-Count = %q, want %d", got, total)
+			}
+			if len(entries) != tt.wantCount {
+				t.Fatalf("entries = %d, want %d", len(entries), tt.wantCount)
+			}
+			for i, entry := range entries {
+				if entry.Action != env.actions[tt.wantFirst+i] {
+					t.Errorf("entry %d = %s, want %s", i, entry.Action, env.actions[tt.wantFirst+i])
+				}
+			}
+		})
+	}
+
+	if _, rec := env.get(t, "99/audit"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown incident: status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	users, err := gormrepo.NewGormUserRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.FindOrCreateByTelegramID(context.Background(), 42, "alice", "Alice", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		apiToken   string
+		header     string
+		userID     string
+		wantStatus int
+		wantUser   string
+	}{
+		{"no token configured", "", "Bearer secret", "", http.StatusUnauthorized, ""},
+		{"missing header", "secret", "", "", http.StatusUnauthorized, ""},
+		{"wrong scheme", "secret", "Basic secret", "", http.StatusUnauthorized, ""},
+		{"wrong token", "secret", "Bearer other", "", http.StatusForbidden, ""},
+		{"valid token", "secret", "Bearer secret", "", http.StatusOK, ""},
+		{"valid token and user", "secret", "Bearer secret", "42", http.StatusOK, "alice"},
+		{"unknown user", "secret", "Bearer secret", "7", http.StatusForbidden, ""},
+		{"malformed user", "secret", "Bearer secret", "alice", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if user, ok := r.Context().Value("user").(*models.User); ok {
+					gotUser = user.Username
+				}
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/1", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.userID != "" {
+				req.Header.Set(apiUserHeader, tt.userID)
+			}
+			rec := httptest.NewRecorder()
+			authMiddleware(users, tt.apiToken)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("user = %q, want %q", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestDebugVarsRequiresToken(t *testing.T) {
+	users, err := gormrepo.NewGormUserRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := newRouter(testutil.DiscardLogger(), newTestService(t, nil), users, fakePinger{}, "secret", time.Second)
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{"without token", "", http.StatusUnauthorized},
+		{"with token", "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
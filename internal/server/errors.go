@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"chatops-bot/internal/service"
+)
+
+// Error codes of JSON error responses. Clients should branch on the code; the
+// message is for humans and may change.
+const (
+	errCodeInvalidInput = "invalid_input"
+	errCodeUnauthorized = "unauthorized"
+	errCodeNotFound     = "not_found"
+	errCodeConflict     = "conflict"
+	errCodeUnavailable  = "unavailable"
+	errCodeInternal     = "internal"
+)
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeJSONError answers with status and a {"error": {"code", "message"}} body.
+func writeJSONError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: errorBody{Code: code, Message: message}})
+}
+
+// writeIncidentLookupError reports a failed incident lookup: 404 if the incident
+// does not exist, 500 otherwise.
+func writeIncidentLookupError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, incidentID uint, err error) {
+	if errors.Is(err, service.ErrIncidentNotFound) {
+		writeJSONError(w, http.StatusNotFound, errCodeNotFound, "Incident not found")
+		return
+	}
+	logger.ErrorContext(r.Context(), "Failed to load incident", "incident_id", incidentID, "error", err)
+	writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to load incident")
+}
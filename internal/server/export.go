@@ -0,0 +1,137 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+var exportCSVHeader = []string{"id", "fingerprint", "status", "severity", "summary", "starts_at", "ends_at", "resolved_by", "action_count"}
+
+// handleExportIncidents streams the incidents that started in a time range, e.g.
+// GET /api/v1/incidents/export?from=2024-05-01&to=2024-06-01&format=csv. Times are
+// RFC 3339 or dates (UTC midnight); to defaults to now and format to json.
+func handleExportIncidents(logger *slog.Logger, incidentService *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		from, err := parseExportTime(query.Get("from"))
+		if err != nil || query.Get("from") == "" {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid or missing from, expected RFC 3339 time or YYYY-MM-DD")
+			return
+		}
+		to := time.Now()
+		if raw := query.Get("to"); raw != "" {
+			if to, err = parseExportTime(raw); err != nil {
+				writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid to, expected RFC 3339 time or YYYY-MM-DD")
+				return
+			}
+		}
+
+		var write func(row *models.IncidentExportRow) error
+		var finish func() error
+		format := query.Get("format")
+		switch format {
+		case "", "json":
+			format = "json"
+			w.Header().Set("Content-Type", "application/json")
+			encoder := json.NewEncoder(w)
+			first := true
+			write = func(row *models.IncidentExportRow) error {
+				separator := ","
+				if first {
+					separator, first = "[", false
+				}
+				if _, err := w.Write([]byte(separator)); err != nil {
+					return err
+				}
+				return encoder.Encode(row)
+			}
+			finish = func() error {
+				closing := "]\n"
+				if first {
+					closing = "[]\n"
+				}
+				_, err := w.Write([]byte(closing))
+				return err
+			}
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			// The CSV writer buffers, so the header reaches the client together with
+			// the first rows and a failing query can still be answered with 500.
+			writer := csv.NewWriter(w)
+			writer.Write(exportCSVHeader)
+			write = func(row *models.IncidentExportRow) error {
+				return writer.Write(exportCSVRecord(row))
+			}
+			finish = func() error {
+				writer.Flush()
+				return writer.Error()
+			}
+		default:
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid format, expected csv or json")
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="incidents-%s-%s.%s"`, from.Format("20060102"), to.Format("20060102"), format))
+
+		rows := 0
+		err = incidentService.ExportIncidents(r.Context(), from, to, func(row *models.IncidentExportRow) error {
+			rows++
+			return write(row)
+		})
+		if err == nil {
+			err = finish()
+		}
+		if err != nil {
+			if rows == 0 {
+				w.Header().Del("Content-Disposition")
+				switch {
+				case errors.Is(err, service.ErrInvalidRange):
+					writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "to must be after from")
+				case errors.Is(err, service.ErrRangeTooLarge):
+					writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Time range is too large, at most %d days", int(service.MaxExportRange.Hours()/24)))
+				default:
+					logger.ErrorContext(r.Context(), "Failed to export incidents", "error", err)
+					writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to export incidents")
+				}
+				return
+			}
+			// The status line is already sent; the client sees a truncated body.
+			logger.ErrorContext(r.Context(), "Incident export aborted", "rows", rows, "error", err)
+			return
+		}
+		logger.InfoContext(r.Context(), "Exported incidents", "from", from, "to", to, "format", format, "rows", rows)
+	}
+}
+
+func parseExportTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func exportCSVRecord(row *models.IncidentExportRow) []string {
+	endsAt := ""
+	if row.EndsAt != nil {
+		endsAt = row.EndsAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatUint(uint64(row.ID), 10),
+		row.Fingerprint,
+		string(row.Status),
+		row.Severity,
+		row.Summary,
+		row.StartsAt.UTC().Format(time.RFC3339),
+		endsAt,
+		row.ResolvedBy,
+		strconv.FormatInt(row.ActionCount, 10),
+	}
+}
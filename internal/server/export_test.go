@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	"chatops-bot/internal/testutil"
+)
+
+// newExportService returns a service holding two incidents that started in May
+// 2024, the second of them resolved.
+func newExportService(t *testing.T) *service.IncidentService {
+	t.Helper()
+	svc := newTestService(t, service.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	alerts := []models.Alert{
+		{Status: "firing", Fingerprint: "disk", Labels: map[string]string{"alertname": "DiskFull", "severity": "critical"}, Annotations: models.Annotations{"summary": "Disk, full"}, StartsAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{Status: "firing", Fingerprint: "cpu", Labels: map[string]string{"alertname": "HighCPU", "severity": "warning"}, StartsAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)},
+		{Status: "resolved", Fingerprint: "cpu", Labels: map[string]string{"alertname": "HighCPU", "severity": "warning"}, StartsAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 5, 2, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, alert := range alerts {
+		if err := processAlert(ctx, testutil.DiscardLogger(), svc, alert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return svc
+}
+
+func getExport(t *testing.T, svc *service.IncidentService, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleExportIncidents(testutil.DiscardLogger(), svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/incidents/export?"+query, nil))
+	return rec
+}
+
+func TestExportIncidentsCSV(t *testing.T) {
+	rec := getExport(t, newExportService(t), "from=2024-05-01&to=2024-06-01&format=csv")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="incidents-20240501-20240601.csv"` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("records = %q, want a header and 2 rows", records)
+	}
+	if got := strings.Join(records[0], ","); got != strings.Join(exportCSVHeader, ",") {
+		t.Errorf("header = %q, want %q", got, exportCSVHeader)
+	}
+	want := []string{"1", "disk", "active", "critical", "Disk, full", "2024-05-01T10:00:00Z", "", "", "0"}
+	if got := strings.Join(records[1], "|"); got != strings.Join(want, "|") {
+		t.Errorf("first row = %q, want %q", records[1], want)
+	}
+	if records[2][1] != "cpu" || records[2][2] != "resolved" || records[2][6] != "2024-05-02T11:00:00Z" {
+		t.Errorf("second row = %q, want the resolved cpu incident", records[2])
+	}
+}
+
+func TestExportIncidentsJSON(t *testing.T) {
+	svc := newExportService(t)
+
+	rec := getExport(t, svc, "from=2024-05-01T00:00:00Z&to=2024-06-01T00:00:00Z")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var rows []models.IncidentExportRow
+	if err := json.Unmarshal(rec.Body.Bytes(), &rows); err != nil {
+		t.Fatalf("body is not a JSON array: %v\n%s", err, rec.Body)
+	}
+	if len(rows) != 2 || rows[0].Fingerprint != "disk" || rows[1].Fingerprint != "cpu" {
+		t.Fatalf("rows = %+v, want disk and cpu", rows)
+	}
+	if rows[0].Severity != "critical" || rows[1].EndsAt == nil {
+		t.Errorf("rows = %+v, want severity and ends_at filled in", rows)
+	}
+
+	// A range without incidents is still a valid, empty array.
+	rec = getExport(t, svc, "from=2023-01-01&to=2023-02-01&format=json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("empty range: status = %d, body %s", rec.Code, rec.Body)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Errorf("empty range body = %q, want []", body)
+	}
+}
+
+func TestExportIncidentsRejectsBadQueries(t *testing.T) {
+	svc := newExportService(t)
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing from", "to=2024-06-01"},
+		{"invalid to", "from=2024-05-01&to=June"},
+		{"unknown format", "from=2024-05-01&to=2024-06-01&format=xml"},
+		{"to before from", "from=2024-06-01&to=2024-05-01"},
+		{"range too large", "from=2022-01-01&to=2024-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := getExport(t, svc, tt.query)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400 (body %s)", rec.Code, rec.Body)
+			}
+			if cd := rec.Header().Get("Content-Disposition"); cd != "" {
+				t.Errorf("Content-Disposition = %q on an error", cd)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"chatops-bot/internal/logging"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// requestLogger assigns every request an ID (reusing X-Request-ID if the caller sent
+// one), stores it in the request context for downstream logging, and logs the request
+// with structured fields once it completes.
+func requestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = logging.NewRequestID()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+			ctx := logging.WithRequestID(r.Context(), requestID)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			logger.InfoContext(ctx, "HTTP request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", ww.Status(),
+				"bytes", ww.BytesWritten(),
+				"duration", time.Since(start),
+				"remote_addr", r.RemoteAddr,
+			)
+		})
+	}
+}
+
+// requestTimeout cancels the request context after timeout and answers 503 with
+// the usual JSON error body, so a slow database or executor call cannot hold the
+// connection indefinitely. Handlers see the cancellation through r.Context().
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		handler := http.TimeoutHandler(next, timeout, timeoutBody)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(timeoutResponseWriter{w}, r)
+		})
+	}
+}
+
+// timeoutBody is the response body http.TimeoutHandler writes on timeout.
+var timeoutBody = func() string {
+	body, _ := json.Marshal(errorResponse{Error: errorBody{Code: errCodeUnavailable, Message: "Request timed out"}})
+	return string(body) + "\n"
+}()
+
+// timeoutResponseWriter labels the bare 503 written by http.TimeoutHandler as
+// JSON. Responses of the wrapped handler keep their own Content-Type.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w timeoutResponseWriter) WriteHeader(status int) {
+	if status == http.StatusServiceUnavailable && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	fast := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("done"))
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		requestTimeout(10*time.Millisecond)(slow).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want 503", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var body errorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("body is not JSON: %v", err)
+		}
+		if body.Error.Code != errCodeUnavailable {
+			t.Errorf("error code = %q, want %q", body.Error.Code, errCodeUnavailable)
+		}
+	})
+
+	t.Run("in time", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		requestTimeout(time.Second)(fast).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusOK || rec.Body.String() != "done" {
+			t.Errorf("got %d %q, want 200 \"done\"", rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct == "application/json" {
+			t.Errorf("Content-Type = %q, want the handler's own", ct)
+		}
+	})
+}
+
+// slowRepo blocks incident lookups until the request context is cancelled, like
+// a query stuck on a locked database.
+type slowRepo struct {
+	service.IncidentRepository
+}
+
+func (r slowRepo) FindByID(ctx context.Context, id uint) (*models.Incident, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (r slowRepo) FindByFingerprint(ctx context.Context, fingerprint string) (*models.Incident, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestRoutersTimeOutSlowRequests checks that a request stuck in the service is
+// answered with 503 at the configured deadline, on both routers.
+func TestRoutersTimeOutSlowRequests(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := service.NewIncidentService(slowRepo{repo}, users, mock.NewExecutorClientMock(), nil, nil, nil, nil, nil, nil, nil, nil, testutil.DiscardLogger())
+
+	api := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/1", nil)
+	api.Header.Set("Authorization", "Bearer secret")
+	webhook := httptest.NewRequest(http.MethodPost, "/api/v1/alertmanager",
+		strings.NewReader(`{"alerts":[{"status":"firing","fingerprint":"fp","labels":{"alertname":"Slow"}}]}`))
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		req     *http.Request
+	}{
+		{"api", newRouter(testutil.DiscardLogger(), svc, users, fakePinger{}, "secret", timeout), api},
+		{"webhook", newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", timeout), webhook},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			start := time.Now()
+			tt.handler.ServeHTTP(rec, tt.req)
+			elapsed := time.Since(start)
+
+			if rec.Code != http.StatusServiceUnavailable {
+				t.Errorf("status = %d, want 503 (body %s)", rec.Code, rec.Body)
+			}
+			if elapsed < timeout || elapsed > timeout+time.Second {
+				t.Errorf("answered after %v, want about %v", elapsed, timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestGetResourceProfiles(t *testing.T) {
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := mock.NewExecutorClientMock()
+	svc := service.NewIncidentService(repo, users, executor, nil, nil, nil, nil, nil, nil, nil, nil, testutil.DiscardLogger())
+	handler := handleGetResourceProfiles(testutil.DiscardLogger(), svc)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resources/profiles", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	var got models.AvailableResources
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Profiles) != 2 || got.Profiles[0].Name != "small" || !got.Profiles[0].IsDefault || got.Profiles[1].Name != "large" {
+		t.Errorf("profiles = %+v, want the mock's small (default) and large", got.Profiles)
+	}
+
+	executor.FailNextCall = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/resources/profiles", nil))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status with the executor down = %d, want 502", rec.Code)
+	}
+}
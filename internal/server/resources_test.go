@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestGetResourceDetails(t *testing.T) {
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := mock.NewExecutorClientMock()
+	svc := service.NewIncidentService(repo, users, executor, nil, nil, nil, nil, nil, nil, nil, nil, testutil.DiscardLogger())
+	incident, err := svc.CreateIncidentFromAlert(context.Background(), models.Alert{
+		Status:      "firing",
+		Fingerprint: "fp-details",
+		Labels:      map[string]string{"alertname": "PodCrashLooping", "namespace": "prod", "pod": "api-7d9f-x2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/incidents/{id}/resources/{type}/{name}", handleGetResourceDetails(testutil.DiscardLogger(), svc))
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) models.ResourceDetails {
+		t.Helper()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var details models.ResourceDetails
+		if err := json.NewDecoder(rec.Body).Decode(&details); err != nil {
+			t.Fatal(err)
+		}
+		return details
+	}
+	base := fmt.Sprintf("/api/v1/incidents/%d/resources/", incident.ID)
+
+	deployment := decode(get(base + "deployment/api"))
+	if deployment.Status != models.DeploymentHealthy || deployment.ReplicasInfo != "2/2 ready" || deployment.ReadyReplicas != 2 {
+		t.Errorf("deployment = %+v, want the mock's healthy 2/2", deployment)
+	}
+	if deployment.RawOutput != "mock deployment api" {
+		t.Errorf("raw output = %q, want the executor to be asked for deployment api", deployment.RawOutput)
+	}
+
+	executor.Details = &models.ResourceDetails{
+		Status:    "CrashLoopBackOff",
+		Restarts:  7,
+		Age:       "3h",
+		RawOutput: "Back-off restarting failed container",
+		Resources: []models.ContainerResources{{Name: "api", CpuUsage: 250, MemoryUsage: 128 << 20}},
+	}
+	pod := decode(get(base + "pod/api-7d9f-x2"))
+	if pod.Status != "CrashLoopBackOff" || pod.Restarts != 7 || pod.Age != "3h" {
+		t.Errorf("pod = %+v, want the executor's CrashLoopBackOff with 7 restarts", pod)
+	}
+	if len(pod.Resources) != 1 || pod.Resources[0].Name != "api" || pod.Resources[0].CpuUsage != 250 {
+		t.Errorf("pod resources = %+v, want the api container", pod.Resources)
+	}
+
+	if rec := get(base + "service/api"); rec.Code != http.StatusBadRequest {
+		t.Errorf("unsupported type: status = %d, want 400", rec.Code)
+	}
+	if rec := get("/api/v1/incidents/999/resources/pod/api"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown incident: status = %d, want 404", rec.Code)
+	}
+	executor.FailNextCall = true
+	if rec := get(base + "pod/api-7d9f-x2"); rec.Code != http.StatusBadGateway {
+		t.Errorf("executor failure: status = %d, want 502", rec.Code)
+	}
+}
@@ -2,70 +2,140 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net/http"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"chatops-bot/internal/config"
 	"chatops-bot/internal/models"
 	"chatops-bot/internal/service"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"gorm.io/gorm"
 )
 
-func Start(ctx context.Context, service *service.IncidentService, userRepo service.UserRepository, appPort, alertPort, webhookToken string) {
+const defaultRequestTimeout = 30 * time.Second
+
+// readinessTimeout bounds the database ping of a readiness probe.
+const readinessTimeout = 2 * time.Second
+
+// Pinger checks that a dependency answers, e.g. the *sql.DB of the database.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+func Start(ctx context.Context, logger *slog.Logger, service *service.IncidentService, userRepo service.UserRepository, db Pinger, cfg config.ServerConfig) {
+	timeout := time.Duration(cfg.RequestTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
 	go func() {
-		log.Printf("Starting main API server on port %s", appPort)
-		router := newRouter(service, userRepo)
-		if err := http.ListenAndServe(fmt.Sprintf(":%s", appPort), router); err != nil {
-			log.Fatalf("Failed to start main API server: %v", err)
+		logger.Info("Starting main API server", "port", cfg.AppPort)
+		router := newRouter(logger, service, userRepo, db, cfg.APIToken, timeout)
+		if err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.AppPort), router); err != nil {
+			logger.Error("Failed to start main API server", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	go func() {
-		log.Printf("Starting Alertmanager webhook server on port %s", alertPort)
-		router := newAlertmanagerRouter(service, webhookToken)
-		if err := http.ListenAndServe(fmt.Sprintf(":%s", alertPort), router); err != nil {
-			log.Fatalf("Failed to start Alertmanager server: %v", err)
+		logger.Info("Starting Alertmanager webhook server", "port", cfg.AlertPort)
+		router := newAlertmanagerRouter(logger, service, cfg.WebhookToken, timeout)
+		if err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.AlertPort), router); err != nil {
+			logger.Error("Failed to start Alertmanager server", "error", err)
+			os.Exit(1)
 		}
 	}()
 }
 
-func newRouter(service *service.IncidentService, userRepo service.UserRepository) http.Handler {
+func newRouter(logger *slog.Logger, service *service.IncidentService, userRepo service.UserRepository, db Pinger, apiToken string, timeout time.Duration) http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(requestLogger(logger))
 	r.Use(middleware.Recoverer)
 
+	r.Get("/readyz", handleReadiness(logger, db))
+	// Runtime counters reveal load and internal state, so they need the API token too.
+	r.With(authMiddleware(userRepo, apiToken), requestTimeout(timeout)).Handle("/debug/vars", expvar.Handler())
+
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Use(authMiddleware(userRepo))
-		r.Get("/incidents/{id}", handleGetIncident(service))
+		r.Use(authMiddleware(userRepo, apiToken))
+		// Exports stream their response, which http.TimeoutHandler would buffer
+		// in memory, so they run without the request timeout.
+		r.Get("/incidents/export", handleExportIncidents(logger, service))
+
+		r.Group(func(r chi.Router) {
+			r.Use(requestTimeout(timeout))
+			r.Get("/incidents", handleFindIncidents(logger, service))
+			r.Post("/incidents", handleCreateIncident(logger, service))
+			r.Get("/incidents/{id}", handleGetIncident(logger, service))
+			r.Get("/incidents/{id}/audit", handleGetAuditLog(logger, service))
+			r.Get("/incidents/{id}/resources/{type}/{name}", handleGetResourceDetails(logger, service))
+			r.Get("/resources/profiles", handleGetResourceProfiles(logger, service))
+		})
 	})
 	return r
 }
 
-func newAlertmanagerRouter(service *service.IncidentService, token string) http.Handler {
+func newAlertmanagerRouter(logger *slog.Logger, service *service.IncidentService, token string, timeout time.Duration) http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(requestLogger(logger))
 	r.Use(middleware.Recoverer)
+	r.Use(requestTimeout(timeout))
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(webhookAuthMiddleware(token))
-		r.Post("/alertmanager", handleAlertmanagerWebhook(service))
+		r.Post("/alertmanager", handleAlertmanagerWebhook(logger, service))
+		r.Post("/grafana", handleGrafanaWebhook(logger, service))
 	})
 	return r
 }
 
-func authMiddleware(userRepo service.UserRepository) func(http.Handler) http.Handler {
+// apiUserHeader optionally names the Telegram user an API request acts for.
+const apiUserHeader = "X-Telegram-User-ID"
+
+// authMiddleware requires the API bearer token on every request. Without a
+// configured token the API is closed rather than open to anyone. A request may
+// act for a registered user by sending their Telegram ID in apiUserHeader.
+func authMiddleware(userRepo service.UserRepository, apiToken string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			const mockTelegramID = 123456789
-			const mockUsername = "api_user"
-			user, err := userRepo.FindOrCreateByTelegramID(r.Context(), mockTelegramID, mockUsername, "API", "User")
+			if apiToken == "" {
+				writeJSONError(w, http.StatusUnauthorized, errCodeUnauthorized, "API token is not configured")
+				return
+			}
+			if !checkBearerToken(w, r, apiToken) {
+				return
+			}
+
+			rawID := r.Header.Get(apiUserHeader)
+			if rawID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			telegramID, err := strconv.ParseInt(rawID, 10, 64)
 			if err != nil {
-				http.Error(w, "Authentication failed", http.StatusInternalServerError)
+				writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Invalid %s header", apiUserHeader))
+				return
+			}
+			user, err := userRepo.FindByTelegramID(r.Context(), telegramID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeJSONError(w, http.StatusForbidden, errCodeUnauthorized, "Unknown user")
+				return
+			}
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Authentication failed")
 				return
 			}
 			ctx := context.WithValue(r.Context(), "user", user)
@@ -81,18 +151,7 @@ func webhookAuthMiddleware(expectedToken string) func(http.Handler) http.Handler
 				next.ServeHTTP(w, r)
 				return
 			}
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Authorization header required", http.StatusUnauthorized)
-				return
-			}
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-				return
-			}
-			if parts[1] != expectedToken {
-				http.Error(w, "Invalid token", http.StatusForbidden)
+			if !checkBearerToken(w, r, expectedToken) {
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -100,17 +159,53 @@ func webhookAuthMiddleware(expectedToken string) func(http.Handler) http.Handler
 	}
 }
 
-func handleGetIncident(service *service.IncidentService) http.HandlerFunc {
+// checkBearerToken reports whether the request carries "Authorization: Bearer
+// <expected>", writing the error response when it does not.
+func checkBearerToken(w http.ResponseWriter, r *http.Request, expected string) bool {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		writeJSONError(w, http.StatusUnauthorized, errCodeUnauthorized, "Authorization header required")
+		return false
+	}
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		writeJSONError(w, http.StatusUnauthorized, errCodeUnauthorized, "Invalid Authorization header format")
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[1]), []byte(expected)) != 1 {
+		writeJSONError(w, http.StatusForbidden, errCodeUnauthorized, "Invalid token")
+		return false
+	}
+	return true
+}
+
+// handleReadiness answers readiness probes: 200 while the database answers a
+// ping, 503 otherwise. It needs no token so orchestrators can call it.
+func handleReadiness(logger *slog.Logger, db Pinger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+		if err := db.PingContext(ctx); err != nil {
+			logger.WarnContext(r.Context(), "Readiness check failed", "error", err)
+			writeJSONError(w, http.StatusServiceUnavailable, errCodeUnavailable, "Database is unavailable")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ready"})
+	}
+}
+
+func handleGetIncident(logger *slog.Logger, service *service.IncidentService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			http.Error(w, "Invalid incident ID", http.StatusBadRequest)
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid incident ID")
 			return
 		}
 		incident, err := service.GetIncidentByID(r.Context(), uint(id))
 		if err != nil {
-			http.Error(w, "Incident not found", http.StatusNotFound)
+			writeIncidentLookupError(w, r, logger, uint(id), err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -118,27 +213,332 @@ func handleGetIncident(service *service.IncidentService) http.HandlerFunc {
 	}
 }
 
-func handleAlertmanagerWebhook(service *service.IncidentService) http.HandlerFunc {
+// handleGetResourceDetails returns live details of a resource of the incident, e.g.
+// GET /api/v1/incidents/42/resources/pod/api-7d9f-x2. Unsupported resource types
+// are rejected with 400 and executor failures are reported as 502.
+func handleGetResourceDetails(logger *slog.Logger, incidentService *service.IncidentService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var msg models.AlertmanagerWebhookMessage
-		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
-			http.Error(w, "Failed to decode alertmanager webhook", http.StatusBadRequest)
+		id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid incident ID")
 			return
 		}
-		if len(msg.Alerts) == 0 {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("Webhook received and processed (no alerts)."))
+		resourceType, resourceName := chi.URLParam(r, "type"), chi.URLParam(r, "name")
+		details, err := incidentService.GetIncidentResourceDetails(r.Context(), uint(id), resourceType, resourceName)
+		switch {
+		case errors.Is(err, service.ErrUnsupportedResource):
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Unsupported resource type %q", resourceType))
+			return
+		case errors.Is(err, service.ErrIncidentNotFound):
+			writeIncidentLookupError(w, r, logger, uint(id), err)
+			return
+		case err != nil:
+			logger.ErrorContext(r.Context(), "Failed to get resource details", "incident_id", id, "resource_type", resourceType, "resource_name", resourceName, "error", err)
+			writeJSONError(w, http.StatusBadGateway, errCodeUnavailable, "Failed to get resource details from executor")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(details)
+	}
+}
+
+// handleGetResourceProfiles returns the hardware-allocation profiles offered by the
+// executor. An unreachable or failing executor is reported as 502.
+func handleGetResourceProfiles(logger *slog.Logger, service *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		available, err := service.GetAvailableResources(r.Context())
+		if err != nil {
+			logger.ErrorContext(r.Context(), "Failed to get resource profiles", "error", err)
+			writeJSONError(w, http.StatusBadGateway, errCodeUnavailable, "Executor is unavailable")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(available)
+	}
+}
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 500
+)
+
+type auditEntryResponse struct {
+	ID         uint              `json:"id"`
+	Action     string            `json:"action"`
+	Parameters map[string]string `json:"parameters"`
+	Success    bool              `json:"success"`
+	Result     string            `json:"result"`
+	Timestamp  time.Time         `json:"timestamp"`
+	User       string            `json:"user"`
+}
+
+// handleGetAuditLog returns the incident's action history, oldest first, e.g.
+// GET /api/v1/incidents/42/audit?limit=50&offset=100. The total number of records
+// is reported in the X-Total-Count header.
+func handleGetAuditLog(logger *slog.Logger, service *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid incident ID")
+			return
+		}
+		limit, err := queryInt(r, "limit", defaultAuditPageSize)
+		if err != nil || limit <= 0 || limit > maxAuditPageSize {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Invalid limit, expected 1..%d", maxAuditPageSize))
+			return
+		}
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil || offset < 0 {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid offset")
 			return
 		}
-		alert := msg.Alerts[0]
-		incident, err := service.CreateIncidentFromAlert(r.Context(), alert)
+
+		records, err := service.GetAuditLog(r.Context(), uint(id))
 		if err != nil {
-			log.Printf("Error creating incident from alert: %v", err)
-			http.Error(w, "Failed to create incident", http.StatusInternalServerError)
+			writeIncidentLookupError(w, r, logger, uint(id), err)
+			return
+		}
+
+		total := len(records)
+		start := min(offset, total)
+		end := min(start+limit, total)
+		entries := make([]auditEntryResponse, 0, end-start)
+		for _, record := range records[start:end] {
+			entries = append(entries, auditEntryResponse{
+				ID:         record.ID,
+				Action:     record.Action,
+				Parameters: record.Parameters,
+				Success:    record.Success,
+				Result:     record.Result,
+				Timestamp:  record.Timestamp,
+				User:       record.User.Username,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		json.NewEncoder(w).Encode(entries)
+	}
+}
+
+func queryInt(r *http.Request, name string, fallback int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
+
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-/]+$`)
+
+type createIncidentRequest struct {
+	Fingerprint       string            `json:"fingerprint"`
+	Summary           string            `json:"summary"`
+	Description       string            `json:"description"`
+	Labels            map[string]string `json:"labels"`
+	AffectedResources map[string]string `json:"affected_resources"`
+}
+
+// handleCreateIncident creates an incident without an alert, e.g. for testing the
+// notification flow or from runbooks.
+func handleCreateIncident(logger *slog.Logger, incidentService *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createIncidentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid request body")
 			return
 		}
-		log.Printf("New incident created from alert: %s (ID: %d)", incident.Summary, incident.ID)
+
+		incident, err := incidentService.CreateIncident(r.Context(), service.NewIncident{
+			Fingerprint:       req.Fingerprint,
+			Summary:           req.Summary,
+			Description:       req.Description,
+			Labels:            req.Labels,
+			AffectedResources: req.AffectedResources,
+		})
+		switch {
+		case errors.Is(err, service.ErrEmptySummary):
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "summary is required")
+			return
+		case errors.Is(err, service.ErrIncidentExists):
+			writeJSONError(w, http.StatusConflict, errCodeConflict, "Incident with this fingerprint already exists")
+			return
+		case err != nil:
+			logger.ErrorContext(r.Context(), "Failed to create incident", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to create incident")
+			return
+		}
+		logger.InfoContext(r.Context(), "Created incident via API", "incident_id", incident.ID, "fingerprint", incident.Fingerprint)
+
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		w.Write([]byte("Incident created successfully"))
+		json.NewEncoder(w).Encode(incident)
+	}
+}
+
+// handleFindIncidents filters incidents by labels, e.g.
+// GET /api/v1/incidents?label=namespace=production&label=severity=critical&status=active
+func handleFindIncidents(logger *slog.Logger, service *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		labels := make(map[string]string)
+		for _, filter := range query["label"] {
+			key, value, ok := strings.Cut(filter, "=")
+			if !ok || key == "" || value == "" || !labelKeyPattern.MatchString(key) {
+				writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Invalid label filter %q, expected key=value", filter))
+				return
+			}
+			labels[key] = value
+		}
+		if len(labels) == 0 {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "At least one label filter is required")
+			return
+		}
+
+		status := models.IncidentStatus(query.Get("status"))
+		switch status {
+		case "", models.StatusActive, models.StatusResolved, models.StatusRejected:
+		default:
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Invalid status")
+			return
+		}
+
+		incidents, err := service.FindIncidentsByLabels(r.Context(), labels, status)
+		if err != nil {
+			logger.ErrorContext(r.Context(), "Failed to find incidents by labels", "error", err)
+			writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to find incidents")
+			return
+		}
+		if incidents == nil {
+			incidents = []*models.Incident{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(incidents)
+	}
+}
+
+// maxLoggedBodyBytes caps how much of a rejected payload is written to the log.
+const maxLoggedBodyBytes = 2048
+
+func truncateBody(body []byte) string {
+	if len(body) > maxLoggedBodyBytes {
+		return string(body[:maxLoggedBodyBytes]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// handleAlertmanagerWebhook accepts Alertmanager's webhook. Every alert in the
+// group is handled: firing ones open (or keep) an incident, resolved ones close it.
+func handleAlertmanagerWebhook(logger *slog.Logger, incidentService *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Failed to read request body")
+			return
+		}
+		var msg models.AlertmanagerWebhookMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			logger.DebugContext(r.Context(), "Malformed alertmanager webhook", "error", err, "body", truncateBody(body))
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Failed to decode alertmanager webhook: %v", err))
+			return
+		}
+
+		filtered := 0
+		for _, alert := range msg.Alerts {
+			err := processAlert(r.Context(), logger, incidentService, alert)
+			if errors.Is(err, service.ErrBelowMinSeverity) {
+				logger.InfoContext(r.Context(), "Alert filtered by minimum severity", "fingerprint", alert.Fingerprint, "alertname", alert.Labels["alertname"], "status", alert.Status)
+				filtered++
+				continue
+			}
+			if errors.Is(err, service.ErrSilenced) {
+				filtered++
+				continue
+			}
+			if errors.Is(err, service.ErrMissingFingerprint) {
+				writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Alert must have a fingerprint or labels")
+				return
+			}
+			if err != nil {
+				logger.ErrorContext(r.Context(), "Failed to process alert", "fingerprint", alert.Fingerprint, "status", alert.Status, "error", err)
+				writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to process alert")
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf("Webhook processed (%d alerts, %d filtered).", len(msg.Alerts), filtered)))
+	}
+}
+
+// handleGrafanaWebhook accepts Grafana unified alerting's webhook. Every alert in
+// the group is handled: firing ones open (or keep) an incident, resolved ones close it.
+func handleGrafanaWebhook(logger *slog.Logger, incidentService *service.IncidentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Failed to read request body")
+			return
+		}
+		var msg models.GrafanaWebhookMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			logger.DebugContext(r.Context(), "Malformed grafana webhook", "error", err, "body", truncateBody(body))
+			writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, fmt.Sprintf("Failed to decode grafana webhook: %v", err))
+			return
+		}
+
+		filtered := 0
+		for _, grafanaAlert := range msg.Alerts {
+			alert := grafanaAlert.ToAlert()
+			err := processAlert(r.Context(), logger, incidentService, alert)
+			if errors.Is(err, service.ErrBelowMinSeverity) {
+				logger.InfoContext(r.Context(), "Grafana alert filtered by minimum severity", "fingerprint", alert.Fingerprint, "alertname", alert.Labels["alertname"])
+				filtered++
+				continue
+			}
+			if errors.Is(err, service.ErrSilenced) {
+				filtered++
+				continue
+			}
+			if errors.Is(err, service.ErrMissingFingerprint) {
+				writeJSONError(w, http.StatusBadRequest, errCodeInvalidInput, "Alert must have a fingerprint or labels")
+				return
+			}
+			if err != nil {
+				logger.ErrorContext(r.Context(), "Failed to process grafana alert", "fingerprint", alert.Fingerprint, "status", alert.Status, "error", err)
+				writeJSONError(w, http.StatusInternalServerError, errCodeInternal, "Failed to process alert")
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf("Webhook processed (%d alerts, %d filtered).", len(msg.Alerts), filtered)))
+	}
+}
+
+// processAlert opens (or keeps) the incident of a firing alert and resolves the
+// incident of a resolved one.
+func processAlert(ctx context.Context, logger *slog.Logger, incidentService *service.IncidentService, alert models.Alert) error {
+	if alert.Status == "resolved" {
+		incident, err := incidentService.ResolveIncidentFromAlert(ctx, alert)
+		if errors.Is(err, service.ErrIncidentNotActive) {
+			logger.DebugContext(ctx, "No active incident for resolved alert", "fingerprint", alert.Fingerprint)
+			return nil
+		}
+		if errors.Is(err, service.ErrAutoResolveDisabled) {
+			logger.InfoContext(ctx, "Auto-resolve is disabled, ignoring resolved alert", "fingerprint", alert.Fingerprint)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.InfoContext(ctx, "Incident resolved from alert", "incident_id", incident.ID)
+		return nil
+	}
+
+	incident, err := incidentService.CreateIncidentFromAlert(ctx, alert)
+	if err != nil {
+		return err
 	}
+	logger.InfoContext(ctx, "Incident created from alert", "incident_id", incident.ID, "summary", incident.Summary)
+	return nil
 }
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func newTestService(t *testing.T, clock service.Clock) *service.IncidentService {
+	t.Helper()
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service.NewIncidentService(repo, users, mock.NewExecutorClientMock(), nil, nil, nil, nil, nil, nil, nil, clock, testutil.DiscardLogger())
+}
+
+func postAlertmanager(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/alertmanager", strings.NewReader(body)))
+	return rec
+}
+
+func TestAlertmanagerWebhookResolvedAlertSetsEndsAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(t, service.NewFakeClock(now))
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+
+	firing := `{"alerts": [{"status": "firing", "fingerprint": "fp1", "labels": {"alertname": "HighLatency"}, "startsAt": "2024-05-01T11:00:00Z"}]}`
+	if rec := postAlertmanager(t, handler, firing); rec.Code != http.StatusOK {
+		t.Fatalf("firing: status %d, body %s", rec.Code, rec.Body)
+	}
+	resolved := `{"alerts": [{"status": "resolved", "fingerprint": "fp1", "labels": {"alertname": "HighLatency"}, "startsAt": "2024-05-01T11:00:00Z", "endsAt": "2024-05-01T11:40:00Z"}]}`
+	if rec := postAlertmanager(t, handler, resolved); rec.Code != http.StatusOK {
+		t.Fatalf("resolved: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	incident, err := svc.GetIncidentByID(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incident.Status != models.StatusResolved {
+		t.Fatalf("status = %s, want resolved", incident.Status)
+	}
+	want := time.Date(2024, 5, 1, 11, 40, 0, 0, time.UTC)
+	if incident.EndsAt == nil || !incident.EndsAt.Equal(want) {
+		t.Fatalf("EndsAt = %v, want %v", incident.EndsAt, want)
+	}
+}
+
+func TestAlertmanagerWebhookHandlesEveryAlert(t *testing.T) {
+	svc := newTestService(t, nil)
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+	ctx := context.Background()
+
+	group := `{"alerts": [
+		{"status": "firing", "fingerprint": "a", "labels": {"alertname": "A"}},
+		{"status": "firing", "fingerprint": "b", "labels": {"alertname": "B"}}
+	]}`
+	if rec := postAlertmanager(t, handler, group); rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	for _, id := range []uint{1, 2} {
+		if _, err := svc.GetIncidentByID(ctx, id); err != nil {
+			t.Fatalf("incident %d: %v", id, err)
+		}
+	}
+
+	// A repeated resolved notification for a closed incident must not reopen it.
+	resolved := `{"alerts": [{"status": "resolved", "fingerprint": "a", "labels": {"alertname": "A"}}]}`
+	for i := 0; i < 2; i++ {
+		if rec := postAlertmanager(t, handler, resolved); rec.Code != http.StatusOK {
+			t.Fatalf("resolved #%d: status %d, body %s", i, rec.Code, rec.Body)
+		}
+	}
+	incident, err := svc.GetIncidentByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incident.Status != models.StatusResolved || incident.OccurrenceCount != 1 {
+		t.Fatalf("status = %s, occurrences = %d; want resolved once", incident.Status, incident.OccurrenceCount)
+	}
+}
+
+// TestGrafanaWebhookFixture posts a payload recorded from Grafana unified
+// alerting and checks how its alerts are mapped to incidents.
+func TestGrafanaWebhookFixture(t *testing.T) {
+	body, err := os.ReadFile("testdata/grafana_webhook.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := newTestService(t, service.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)))
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/grafana", strings.NewReader(string(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	if want := "Webhook processed (2 alerts, 0 filtered)."; rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body, want)
+	}
+
+	ctx := context.Background()
+	memory, err := svc.GetIncidentByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memory.Fingerprint != "5a9c8a6e2d3f1b47" || memory.Status != models.StatusActive {
+		t.Errorf("incident 1 = %s (%s), want the active HighMemoryUsage incident", memory.Fingerprint, memory.Status)
+	}
+	if memory.Summary != "payments-api is close to OOM" || memory.Labels["pod"] != "payments-api-7d9f8b6c5d-x2k4q" {
+		t.Errorf("summary = %q, labels = %v", memory.Summary, memory.Labels)
+	}
+	if want := time.Date(2024, 5, 1, 11, 58, 30, 0, time.UTC); !memory.StartsAt.Equal(want) {
+		t.Errorf("starts_at = %v, want %v", memory.StartsAt, want)
+	}
+	if got := memory.Annotations["value_string"]; !strings.Contains(got, "value=0.93") {
+		t.Errorf("value_string annotation = %q, want the evaluated values", got)
+	}
+	if got := memory.Annotations["dashboard_url"]; got != "https://grafana.example.com/d/k8s-pods?orgId=1" {
+		t.Errorf("dashboard_url annotation = %q", got)
+	}
+	if got := memory.GeneratorURL; got != "https://grafana.example.com/d/k8s-pods?orgId=1&viewPanel=4" {
+		t.Errorf("generator_url = %q, want the panel URL", got)
+	}
+
+	// Without a panel or a summary the rule link and the alert name are used.
+	node, err := svc.GetIncidentByID(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Summary != "NodeNotReady" {
+		t.Errorf("summary = %q, want the alert name", node.Summary)
+	}
+	if got := node.GeneratorURL; got != "https://grafana.example.com/alerting/grafana/b7f2k1mq9a/view?orgId=1" {
+		t.Errorf("generator_url = %q, want the rule URL", got)
+	}
+	if _, ok := node.Annotations["dashboard_url"]; ok {
+		t.Errorf("annotations = %v, want no dashboard_url", node.Annotations)
+	}
+}
+
+func TestAlertmanagerWebhookMinSeverity(t *testing.T) {
+	svc := newTestService(t, nil)
+	svc.SetMinSeverity("severity", "high", nil)
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+	ctx := context.Background()
+
+	group := `{"alerts": [
+		{"status": "firing", "fingerprint": "low", "labels": {"alertname": "Low", "severity": "warning"}},
+		{"status": "firing", "fingerprint": "high", "labels": {"alertname": "High", "severity": "critical"}}
+	]}`
+	rec := postAlertmanager(t, handler, group)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	if want := "Webhook processed (2 alerts, 1 filtered)."; rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body, want)
+	}
+	incidents, err := svc.ListActiveIncidents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 1 || incidents[0].Fingerprint != "high" {
+		t.Fatalf("incidents = %d, want only the critical one", len(incidents))
+	}
+
+	// Resolving an alert that never opened an incident is a no-op.
+	resolved := `{"alerts": [{"status": "resolved", "fingerprint": "low", "labels": {"alertname": "Low", "severity": "warning"}}]}`
+	if rec := postAlertmanager(t, handler, resolved); rec.Code != http.StatusOK {
+		t.Fatalf("resolved: status %d, body %s", rec.Code, rec.Body)
+	}
+}
+
+func TestAlertmanagerWebhookMalformedJSON(t *testing.T) {
+	svc := newTestService(t, nil)
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+
+	rec := postAlertmanager(t, handler, `{"alerts": [{"status": "firing", "labels": {"alertname": 1}}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != errCodeInvalidInput || !strings.Contains(resp.Error.Message, "cannot unmarshal number") {
+		t.Errorf("error = %+v, want invalid_input with the decode detail", resp.Error)
+	}
+
+	if rec := postAlertmanager(t, handler, `{"alerts": [`); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "unexpected end of JSON input") {
+		t.Errorf("truncated body: status %d, body %s", rec.Code, rec.Body)
+	}
+}
+
+func TestAlertmanagerWebhookMissingFingerprint(t *testing.T) {
+	svc := newTestService(t, nil)
+	handler := newAlertmanagerRouter(testutil.DiscardLogger(), svc, "", time.Second)
+	ctx := context.Background()
+
+	// Without a fingerprint the alert is identified by its labels, so both
+	// notifications land on one incident instead of colliding on "".
+	firing := `{"alerts": [{"status": "firing", "labels": {"alertname": "NoFP", "pod": "api-0"}}]}`
+	for i := 0; i < 2; i++ {
+		if rec := postAlertmanager(t, handler, firing); rec.Code != http.StatusOK {
+			t.Fatalf("firing #%d: status %d, body %s", i, rec.Code, rec.Body)
+		}
+	}
+	incidents, err := svc.ListActiveIncidents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 1 || incidents[0].Fingerprint == "" {
+		t.Fatalf("incidents = %d, want one with a synthesized fingerprint", len(incidents))
+	}
+
+	rec := postAlertmanager(t, handler, `{"alerts": [{"status": "firing", "labels": {}}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("no fingerprint and no labels: status = %d, want 400", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "fingerprint") {
+		t.Errorf("body = %s, want it to name the missing fingerprint", rec.Body)
+	}
+}
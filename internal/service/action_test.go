@@ -0,0 +1,188 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestExecuteActionPassesParametersAndAudits(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    *models.ActionResult
+		wantSuccess bool
+	}{
+		{name: "success", wantSuccess: true},
+		{name: "executor error", response: &models.ActionResult{Error: "quota exceeded"}, wantSuccess: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			user := env.user(t, 1)
+			incident := env.fire(t, "fp-scale", map[string]string{"alertname": "Scale"})
+			if tt.response != nil {
+				env.executor.Responses[models.ActionScaleDeployment] = *tt.response
+			}
+
+			_, err := env.svc.ExecuteAction(ctx, models.ActionRequest{
+				Action:     string(models.ActionScaleDeployment),
+				IncidentID: incident.ID,
+				UserID:     user.ID,
+				Parameters: map[string]string{"deployment": "api", "namespace": "prod", "replicas": "3"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			call, ok := env.executor.LastCall()
+			if !ok {
+				t.Fatal("executor was not called")
+			}
+			if call.Parameters["replicas"] != "3" || call.Parameters["deployment"] != "api" {
+				t.Errorf("executor got parameters %v", call.Parameters)
+			}
+
+			got, err := env.repo.FindByID(ctx, incident.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var found bool
+			for _, entry := range got.AuditLog {
+				if entry.Action == string(models.ActionScaleDeployment) {
+					found = true
+					if entry.Success != tt.wantSuccess {
+						t.Errorf("audit success = %v, want %v", entry.Success, tt.wantSuccess)
+					}
+				}
+			}
+			if !found {
+				t.Error("no audit record for the action")
+			}
+		})
+	}
+}
+
+func TestExecuteActionDryRunSkipsExecutor(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-dry", map[string]string{"alertname": "Dry"})
+
+	_, err := env.svc.ExecuteAction(context.Background(), models.ActionRequest{
+		Action:     string(models.ActionRestartDeployment),
+		IncidentID: incident.ID,
+		UserID:     user.ID,
+		Parameters: map[string]string{"deployment": "api", "namespace": "prod"},
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls := env.executor.Calls(); len(calls) != 0 {
+		t.Errorf("executor got %d calls on a dry run", len(calls))
+	}
+	got, err := env.repo.FindByID(context.Background(), incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry := got.AuditLog[len(got.AuditLog)-1]; entry.Action != string(models.ActionRestartDeployment) || !entry.DryRun {
+		t.Errorf("last audit entry = %s (dry run %v), want a dry-run restart", entry.Action, entry.DryRun)
+	}
+}
+
+func TestExecuteActionRejectsDuplicateCallbacks(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-dup", map[string]string{"alertname": "Dup"})
+	req := models.ActionRequest{
+		Action:         string(models.ActionRollbackDeployment),
+		IncidentID:     incident.ID,
+		UserID:         user.ID,
+		Parameters:     map[string]string{"deployment": "api", "namespace": "prod"},
+		IdempotencyKey: "cb:12345:rollback_deployment",
+	}
+
+	// Rapid duplicates arrive while the first one is still running.
+	var wg sync.WaitGroup
+	var executed, rejected atomic.Int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := env.svc.ExecuteAction(context.Background(), req)
+			switch {
+			case err == nil:
+				executed.Add(1)
+			case errors.Is(err, service.ErrDuplicateAction):
+				rejected.Add(1)
+			default:
+				t.Errorf("ExecuteAction: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A replay delivered later is rejected from the audit log.
+	if _, err := env.svc.ExecuteAction(context.Background(), req); !errors.Is(err, service.ErrDuplicateAction) {
+		t.Errorf("replay: err = %v, want ErrDuplicateAction", err)
+	}
+
+	if executed.Load() != 1 || rejected.Load() != 4 {
+		t.Errorf("executed %d, rejected %d; want 1 and 4", executed.Load(), rejected.Load())
+	}
+	if calls := env.executor.Calls(); len(calls) != 1 {
+		t.Errorf("executor got %d calls, want 1", len(calls))
+	}
+}
+
+// TestConcurrentActionsKeepEveryAuditRecord checks that actions running at the
+// same time on one incident do not overwrite each other's audit records.
+func TestConcurrentActionsKeepEveryAuditRecord(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-concurrent", map[string]string{"alertname": "Concurrent"})
+
+	actions := []models.ActionType{
+		models.ActionRestartDeployment,
+		models.ActionScaleDeployment,
+		models.ActionRollbackDeployment,
+		models.ActionGetPodLogs,
+	}
+	var wg sync.WaitGroup
+	for _, action := range actions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := env.svc.ExecuteAction(ctx, models.ActionRequest{
+				Action:     string(action),
+				IncidentID: incident.ID,
+				UserID:     user.ID,
+				Parameters: map[string]string{"deployment": "api", "namespace": "prod", "replicas": "2", "pod_name": "api-0"},
+			})
+			if err != nil {
+				t.Errorf("ExecuteAction %s: %v", action, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded []string
+	for _, entry := range got.AuditLog {
+		recorded = append(recorded, entry.Action)
+	}
+	for _, action := range actions {
+		if !contains(recorded, string(action)) {
+			t.Errorf("no audit record for %s; audit actions = %v", action, recorded)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"expvar"
+
+	"chatops-bot/internal/models"
+)
+
+const defaultMaxConcurrentActions = 10
+
+// executorActions tracks actions sent to the executor: "in_flight" are running,
+// "queued" wait for a free slot. Exposed via /debug/vars.
+var executorActions = expvar.NewMap("executor_actions")
+
+// actionLimiter bounds how many actions hit the executor at once. Callers over the
+// limit wait for a slot instead of failing.
+type actionLimiter struct {
+	slots chan struct{}
+}
+
+func newActionLimiter(limit int) *actionLimiter {
+	if limit <= 0 {
+		limit = defaultMaxConcurrentActions
+	}
+	return &actionLimiter{slots: make(chan struct{}, limit)}
+}
+
+// acquire blocks until a slot is free or ctx is done. A nil limiter never blocks.
+func (l *actionLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		executorActions.Add("in_flight", 1)
+		return nil
+	default:
+	}
+
+	executorActions.Add("queued", 1)
+	defer executorActions.Add("queued", -1)
+	select {
+	case l.slots <- struct{}{}:
+		executorActions.Add("in_flight", 1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *actionLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+	executorActions.Add("in_flight", -1)
+}
+
+// SetActionConcurrency limits how many actions run against the executor at the
+// same time; zero or less means the default of 10. Call it before serving requests.
+func (s *IncidentService) SetActionConcurrency(limit int) {
+	s.actionLimiter = newActionLimiter(limit)
+}
+
+// executeOnExecutor runs the action once a slot is free. It fails only if ctx is
+// done while the action is still queued.
+func (s *IncidentService) executeOnExecutor(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	if err := s.actionLimiter.acquire(ctx); err != nil {
+		s.logger.WarnContext(ctx, "Gave up waiting for a free executor slot", "incident_id", req.IncidentID, "action", req.Action, "error", err)
+		return models.ActionResult{Error: "Action was cancelled while waiting in the queue"}, err
+	}
+	defer s.actionLimiter.release()
+	return s.executor.ExecuteAction(ctx, req), nil
+}
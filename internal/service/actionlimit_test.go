@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	"chatops-bot/internal/testutil"
+)
+
+// blockingExecutor holds every action until release is closed and records how
+// many ran at once.
+type blockingExecutor struct {
+	*mock.ExecutorClientMock
+	release chan struct{}
+
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (e *blockingExecutor) ExecuteAction(ctx context.Context, req models.ActionRequest) models.ActionResult {
+	e.mu.Lock()
+	e.running++
+	e.peak = max(e.peak, e.running)
+	e.mu.Unlock()
+
+	<-e.release
+
+	e.mu.Lock()
+	e.running--
+	e.mu.Unlock()
+	return e.ExecutorClientMock.ExecuteAction(ctx, req)
+}
+
+func (e *blockingExecutor) counts() (running, peak int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.running, e.peak
+}
+
+func newLimitedEnv(t *testing.T, limit int) (*testEnv, *blockingExecutor) {
+	t.Helper()
+	env := newTestEnv(t)
+	executor := &blockingExecutor{ExecutorClientMock: env.executor, release: make(chan struct{})}
+	env.svc = service.NewIncidentService(env.repo, env.users, executor, nil, env.notifications, env.updates, env.topicDeletions, env.escalations, env.reminders, env.snoozes, env.clock, testutil.DiscardLogger())
+	env.svc.SetActionConcurrency(limit)
+	return env, executor
+}
+
+func executorActionsMetric(name string) int64 {
+	v, ok := expvar.Get("executor_actions").(*expvar.Map).Get(name).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestActionConcurrencyLimit(t *testing.T) {
+	const limit, actions = 2, 6
+	env, executor := newLimitedEnv(t, limit)
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-limit", map[string]string{"alertname": "Limit"})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, actions)
+	for i := 0; i < actions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := env.svc.ExecuteAction(context.Background(), models.ActionRequest{
+				Action:     string(models.ActionGetPodLogs),
+				IncidentID: incident.ID,
+				UserID:     user.ID,
+			})
+			errs <- err
+		}()
+	}
+
+	waitFor(t, "queued actions", func() bool {
+		return executorActionsMetric("queued") == actions-limit
+	})
+	if running, _ := executor.counts(); running != limit {
+		t.Errorf("running = %d with the rest queued, want %d", running, limit)
+	}
+	if got := executorActionsMetric("in_flight"); got != limit {
+		t.Errorf("in_flight metric = %d, want %d", got, limit)
+	}
+
+	close(executor.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("ExecuteAction: %v", err)
+		}
+	}
+
+	if _, peak := executor.counts(); peak > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", peak, limit)
+	}
+	if n := len(env.executor.Calls()); n != actions {
+		t.Errorf("executor calls = %d, want %d", n, actions)
+	}
+	got, err := env.repo.FindByID(context.Background(), incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var audited int
+	for _, entry := range got.AuditLog {
+		if entry.Action == string(models.ActionGetPodLogs) {
+			audited++
+		}
+	}
+	if audited != actions {
+		t.Errorf("audit records = %d, want %d", audited, actions)
+	}
+	if q, f := executorActionsMetric("queued"), executorActionsMetric("in_flight"); q != 0 || f != 0 {
+		t.Errorf("metrics after completion: queued=%d in_flight=%d, want 0", q, f)
+	}
+}
+
+func TestQueuedActionGivesUpOnCancel(t *testing.T) {
+	env, executor := newLimitedEnv(t, 1)
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-cancel", map[string]string{"alertname": "Cancel"})
+	req := models.ActionRequest{Action: string(models.ActionGetPodLogs), IncidentID: incident.ID, UserID: user.ID}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		env.svc.ExecuteAction(context.Background(), req)
+	}()
+	waitFor(t, "the first action to start", func() bool {
+		running, _ := executor.counts()
+		return running == 1
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := env.svc.ExecuteAction(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("queued action error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(executor.release)
+	<-done
+	if n := len(env.executor.Calls()); n != 1 {
+		t.Errorf("executor calls = %d, want 1", n)
+	}
+}
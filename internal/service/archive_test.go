@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	"chatops-bot/internal/testutil"
+)
+
+func TestArchiveOldIncidentsThreshold(t *testing.T) {
+	const retention = 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		opts     service.ArchiveOptions
+		archived bool
+	}{
+		{"closed exactly at the threshold", retention, service.ArchiveOptions{}, false},
+		{"closed a second before the threshold", retention + time.Second, service.ArchiveOptions{}, true},
+		{"dry run", retention + time.Hour, service.ArchiveOptions{DryRun: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			user := env.user(t, 1)
+			incident := env.fire(t, "fp-archive", map[string]string{"alertname": "Archive"})
+			if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			env.clock.Advance(tt.elapsed)
+			env.svc.ArchiveOldIncidents(ctx, retention, tt.opts)
+
+			_, err := env.repo.FindByID(ctx, incident.ID)
+			switch {
+			case tt.archived && !errors.Is(err, gorm.ErrRecordNotFound):
+				t.Errorf("FindByID error = %v, want the incident archived", err)
+			case !tt.archived && err != nil:
+				t.Errorf("FindByID error = %v, want the incident kept", err)
+			}
+		})
+	}
+}
+
+// TestDeleteOldIncidentTopicsThreshold checks that topics are scheduled for
+// deletion by their own max age, independently of incident archival.
+func TestDeleteOldIncidentTopicsThreshold(t *testing.T) {
+	const topicMaxAge = 6 * time.Hour
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+
+	closeAt := func(fingerprint string, topicID int64) *models.Incident {
+		t.Helper()
+		incident := env.fire(t, fingerprint, map[string]string{"alertname": fingerprint})
+		if topicID != 0 {
+			if err := env.svc.SetTelegramTopicID(ctx, incident.ID, topicID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+			t.Fatal(err)
+		}
+		return incident
+	}
+	old := closeAt("fp-old", 10)
+	closeAt("fp-old-no-topic", 0)
+	env.clock.Advance(2 * time.Hour)
+	recent := closeAt("fp-recent", 11)
+
+	env.clock.Advance(topicMaxAge - time.Hour)
+	env.svc.DeleteOldIncidentTopics(ctx, topicMaxAge)
+	if ids := drain(env.topicDeletions); len(ids) != 1 || ids[0] != old.ID {
+		t.Errorf("scheduled topic deletions = %v, want [%d]", ids, old.ID)
+	}
+
+	env.clock.Advance(2 * time.Hour)
+	env.svc.DeleteOldIncidentTopics(ctx, topicMaxAge)
+	ids := drain(env.topicDeletions)
+	if !slices.Contains(ids, recent.ID) {
+		t.Errorf("scheduled topic deletions = %v, want %d once it is past the max age", ids, recent.ID)
+	}
+
+	// Topic deletion leaves the incidents themselves in place.
+	if _, err := env.repo.FindByID(ctx, old.ID); err != nil {
+		t.Errorf("FindByID after topic deletion: %v", err)
+	}
+}
+
+// TestDeleteOldIncidentTopicsDoesNotBlock checks that scheduling topic deletions
+// returns even when the bot is not reading the channel.
+func TestDeleteOldIncidentTopicsDoesNotBlock(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	topicDeletions := make(chan *models.Incident)
+	env.svc = service.NewIncidentService(env.repo, env.users, env.executor, nil, env.notifications, env.updates, topicDeletions, env.escalations, env.reminders, env.snoozes, env.clock, testutil.DiscardLogger())
+
+	incident := env.fire(t, "fp-blocked", map[string]string{"alertname": "Blocked"})
+	if err := env.svc.SetTelegramTopicID(ctx, incident.ID, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+	env.clock.Advance(2 * time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		env.svc.DeleteOldIncidentTopics(ctx, time.Hour)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("DeleteOldIncidentTopics blocked on a channel nobody reads")
+	}
+
+	// A consumer that starts late still gets the deletion.
+	select {
+	case got := <-topicDeletions:
+		if got.ID != incident.ID {
+			t.Errorf("scheduled topic deletion for %d, want %d", got.ID, incident.ID)
+		}
+	case <-time.After(time.Second):
+		t.Error("topic deletion was not delivered to a late consumer")
+	}
+}
+
+// TestArchivedIncidentReopensWithHistory checks that an incident archived without
+// its audit records gets them back when its alert fires again.
+func TestArchivedIncidentReopensWithHistory(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	labels := map[string]string{"alertname": "Archive"}
+	incident := env.fire(t, "fp-archive", labels)
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	env.clock.Advance(48 * time.Hour)
+	env.svc.ArchiveOldIncidents(ctx, 24*time.Hour, service.ArchiveOptions{})
+	if _, err := env.repo.FindByID(ctx, incident.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("FindByID error = %v, want the incident archived", err)
+	}
+
+	reopened := env.fire(t, "fp-archive", labels)
+	if reopened.ID != incident.ID {
+		t.Fatalf("reopened incident ID = %d, want %d", reopened.ID, incident.ID)
+	}
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatalf("FindByID after reopen: %v", err)
+	}
+	if got.Status != models.StatusActive {
+		t.Errorf("status = %s, want %s", got.Status, models.StatusActive)
+	}
+	var actions []string
+	for _, record := range got.AuditLog {
+		actions = append(actions, record.Action)
+	}
+	if !contains(actions, "update_status") || !contains(actions, "reopen") {
+		t.Errorf("audit actions = %v, want the archived update_status and the reopen", actions)
+	}
+}
@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestBulkResolveOnlyMatching(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+
+	matching := []*models.Incident{
+		env.fire(t, "api-a", map[string]string{"alertname": "HighLatency", "namespace": "prod", "service": "api"}),
+		env.fire(t, "api-b", map[string]string{"alertname": "HighErrorRate", "namespace": "prod", "service": "api"}),
+	}
+	otherNamespace := env.fire(t, "api-staging", map[string]string{"alertname": "HighLatency", "namespace": "staging", "service": "api"})
+	otherService := env.fire(t, "db", map[string]string{"alertname": "HighLatency", "namespace": "prod", "service": "db"})
+	unlabelled := env.fire(t, "bare", map[string]string{"alertname": "HighLatency"})
+
+	resolved, err := env.svc.BulkResolve(ctx, user.ID, map[string]string{"namespace": "prod", "service": "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != len(matching) {
+		t.Errorf("resolved = %d, want %d", resolved, len(matching))
+	}
+
+	for _, incident := range matching {
+		got, err := env.repo.FindByID(ctx, incident.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != models.StatusResolved || got.ResolvedBy == nil || *got.ResolvedBy != user.ID {
+			t.Errorf("%s: status = %s, resolved_by = %v, want resolved by %d", got.Fingerprint, got.Status, got.ResolvedBy, user.ID)
+		}
+		entry := got.AuditLog[len(got.AuditLog)-1]
+		if entry.Action != "update_status" || entry.Parameters["reason"] != "bulk resolve: namespace=prod service=api" {
+			t.Errorf("%s: last audit entry = %s %v, want the bulk resolve", got.Fingerprint, entry.Action, entry.Parameters)
+		}
+	}
+	for _, incident := range []*models.Incident{otherNamespace, otherService, unlabelled} {
+		got, err := env.repo.FindByID(ctx, incident.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != models.StatusActive {
+			t.Errorf("%s: status = %s, want it left active", got.Fingerprint, got.Status)
+		}
+	}
+
+	// The already resolved incidents are not matched again.
+	if resolved, err := env.svc.BulkResolve(ctx, user.ID, map[string]string{"namespace": "prod", "service": "api"}); err != nil || resolved != 0 {
+		t.Errorf("second BulkResolve = %d, %v, want 0", resolved, err)
+	}
+	if _, err := env.svc.BulkResolve(ctx, user.ID, nil); !errors.Is(err, service.ErrEmptyFilter) {
+		t.Errorf("BulkResolve without a filter error = %v, want %v", err, service.ErrEmptyFilter)
+	}
+}
+
+func TestAcknowledgeAll(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	caller := env.user(t, 1)
+	other := env.user(t, 2)
+
+	open := []*models.Incident{
+		env.fire(t, "a", map[string]string{"alertname": "A", "namespace": "prod"}),
+		env.fire(t, "b", map[string]string{"alertname": "B", "namespace": "staging"}),
+		env.fire(t, "c", map[string]string{"alertname": "C"}),
+	}
+	taken := env.fire(t, "taken", map[string]string{"alertname": "Taken"})
+	if err := env.svc.Acknowledge(ctx, other.ID, taken.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	closed := env.fire(t, "closed", map[string]string{"alertname": "Closed"})
+	if err := env.svc.UpdateStatus(ctx, other.ID, closed.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	acknowledged, err := env.svc.AcknowledgeAll(ctx, caller.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acknowledged != len(open) {
+		t.Errorf("acknowledged = %d, want %d", acknowledged, len(open))
+	}
+
+	want := map[uint]*uint{taken.ID: &other.ID, closed.ID: nil}
+	for _, incident := range open {
+		want[incident.ID] = &caller.ID
+	}
+	for id, wantBy := range want {
+		got, err := env.repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case wantBy == nil && got.AcknowledgedBy != nil:
+			t.Errorf("%s: acknowledged by %d, want nobody", got.Fingerprint, *got.AcknowledgedBy)
+		case wantBy != nil && (got.AcknowledgedBy == nil || *got.AcknowledgedBy != *wantBy):
+			t.Errorf("%s: acknowledged_by = %v, want %d", got.Fingerprint, got.AcknowledgedBy, *wantBy)
+		case wantBy != nil && got.AcknowledgedAt == nil:
+			t.Errorf("%s: acknowledged_at is not set", got.Fingerprint)
+		}
+	}
+
+	// Everything is taken now, so a second call acknowledges nothing.
+	if acknowledged, err := env.svc.AcknowledgeAll(ctx, caller.ID, nil); err != nil || acknowledged != 0 {
+		t.Errorf("second AcknowledgeAll = %d, %v, want 0", acknowledged, err)
+	}
+}
+
+func TestAcknowledgeAllWithFilter(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	caller := env.user(t, 1)
+
+	prod := env.fire(t, "prod", map[string]string{"alertname": "A", "namespace": "prod"})
+	staging := env.fire(t, "staging", map[string]string{"alertname": "A", "namespace": "staging"})
+
+	acknowledged, err := env.svc.AcknowledgeAll(ctx, caller.ID, map[string]string{"namespace": "prod"})
+	if err != nil || acknowledged != 1 {
+		t.Fatalf("AcknowledgeAll = %d, %v, want 1", acknowledged, err)
+	}
+	for _, tt := range []struct {
+		incident *models.Incident
+		want     bool
+	}{{prod, true}, {staging, false}} {
+		got, err := env.repo.FindByID(ctx, tt.incident.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got.AcknowledgedBy != nil) != tt.want {
+			t.Errorf("%s: acknowledged_by = %v, want acknowledged %v", got.Fingerprint, got.AcknowledgedBy, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestFakeClock(t *testing.T) {
+	clock := service.NewFakeClock(testStart)
+	clock.Advance(90 * time.Minute)
+	if want := testStart.Add(90 * time.Minute); !clock.Now().Equal(want) {
+		t.Errorf("after Advance: %v, want %v", clock.Now(), want)
+	}
+	later := testStart.AddDate(0, 1, 0)
+	clock.Set(later)
+	if !clock.Now().Equal(later) {
+		t.Errorf("after Set: %v, want %v", clock.Now(), later)
+	}
+}
+
+// TestServiceUsesInjectedClock checks that status changes and action audit
+// records are stamped with the service's clock rather than the wall clock.
+func TestServiceUsesInjectedClock(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-clock", map[string]string{"alertname": "Clock"})
+
+	env.clock.Advance(time.Hour)
+	actionAt := env.clock.Now()
+	if _, err := env.svc.ExecuteAction(ctx, models.ActionRequest{
+		Action:     string(models.ActionGetPodLogs),
+		IncidentID: incident.ID,
+		UserID:     user.ID,
+	}); err != nil {
+		t.Fatalf("ExecuteAction: %v", err)
+	}
+
+	env.clock.Advance(time.Hour)
+	resolvedAt := env.clock.Now()
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.EndsAt == nil || !got.EndsAt.Equal(resolvedAt) {
+		t.Errorf("EndsAt = %v, want %v", got.EndsAt, resolvedAt)
+	}
+	stamps := map[string]time.Time{}
+	for _, entry := range got.AuditLog {
+		stamps[entry.Action] = entry.Timestamp
+	}
+	if ts := stamps[string(models.ActionGetPodLogs)]; !ts.Equal(actionAt) {
+		t.Errorf("action audit timestamp = %v, want %v", ts, actionAt)
+	}
+}
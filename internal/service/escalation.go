@@ -0,0 +1,66 @@
+package service
+
+import (
+	"expvar"
+	"strings"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+// escalations counts fired escalations keyed by level. Exposed via /debug/vars.
+var escalations = expvar.NewMap("incident_escalations")
+
+// EscalationPolicy holds the per-severity thresholds after which an unacknowledged
+// incident is escalated. The n-th threshold of a severity is escalation level n.
+type EscalationPolicy struct {
+	severityLabel string
+	thresholds    map[string][]time.Duration
+}
+
+func NewEscalationPolicy(cfg config.IncidentServiceConfig, severityLabel string) EscalationPolicy {
+	if severityLabel == "" {
+		severityLabel = "severity"
+	}
+	thresholds := make(map[string][]time.Duration)
+	for severity, seconds := range cfg.EscalationThresholds {
+		levels := make([]time.Duration, 0, len(seconds))
+		for _, s := range seconds {
+			levels = append(levels, time.Duration(s)*time.Second)
+		}
+		if len(levels) > 0 {
+			thresholds[strings.ToLower(severity)] = levels
+		}
+	}
+	if len(thresholds) == 0 && cfg.EscalationTimeout > 0 {
+		thresholds["critical"] = []time.Duration{time.Duration(cfg.EscalationTimeout) * time.Second}
+	}
+	return EscalationPolicy{severityLabel: severityLabel, thresholds: thresholds}
+}
+
+func (p EscalationPolicy) Enabled() bool {
+	return len(p.thresholds) > 0
+}
+
+// minThreshold is the youngest age at which any incident can be escalated.
+func (p EscalationPolicy) minThreshold() time.Duration {
+	var shortest time.Duration
+	for _, levels := range p.thresholds {
+		if shortest == 0 || levels[0] < shortest {
+			shortest = levels[0]
+		}
+	}
+	return shortest
+}
+
+// dueLevel returns the highest escalation level an incident of the given age has reached.
+func (p EscalationPolicy) dueLevel(incident *models.Incident, age time.Duration) int {
+	level := 0
+	for _, threshold := range p.thresholds[strings.ToLower(incident.Labels[p.severityLabel])] {
+		if age >= threshold {
+			level++
+		}
+	}
+	return level
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestEscalationLevelsFollowFakeClock(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	policy := service.NewEscalationPolicy(config.IncidentServiceConfig{
+		EscalationThresholds: map[string][]int64{"critical": {300, 900}},
+	}, "severity")
+	incident := env.fire(t, "fp-critical", map[string]string{"alertname": "Down", "severity": "critical"})
+	env.fire(t, "fp-warning", map[string]string{"alertname": "Slow", "severity": "warning"})
+
+	steps := []struct {
+		advance   time.Duration
+		wantLevel int
+		wantSent  int
+	}{
+		{299 * time.Second, 0, 0},
+		{2 * time.Second, 1, 1},
+		{5 * time.Minute, 1, 0},
+		{5 * time.Minute, 2, 1},
+		{time.Hour, 2, 0},
+	}
+	for i, step := range steps {
+		env.clock.Advance(step.advance)
+		env.svc.EscalateUnacknowledgedIncidents(ctx, policy)
+		if sent := drain(env.escalations); len(sent) != step.wantSent {
+			t.Fatalf("step %d: escalations sent = %v, want %d", i, sent, step.wantSent)
+		}
+		got, err := env.svc.GetIncidentByID(ctx, incident.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.EscalationLevel != step.wantLevel {
+			t.Fatalf("step %d: level = %d, want %d", i, got.EscalationLevel, step.wantLevel)
+		}
+	}
+}
+
+func TestEscalationSkipsAcknowledgedIncidents(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	policy := service.NewEscalationPolicy(config.IncidentServiceConfig{EscalationTimeout: 60}, "severity")
+	incident := env.fire(t, "fp", map[string]string{"alertname": "Down", "severity": "critical"})
+	if err := env.svc.Acknowledge(ctx, env.user(t, 1).ID, incident.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	env.clock.Advance(time.Hour)
+	env.svc.EscalateUnacknowledgedIncidents(ctx, policy)
+	if sent := drain(env.escalations); len(sent) != 0 {
+		t.Fatalf("acknowledged incident escalated: %v", sent)
+	}
+}
+
+func TestEscalationAgeRestartsOnReopen(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	policy := service.NewEscalationPolicy(config.IncidentServiceConfig{
+		EscalationThresholds: map[string][]int64{"critical": {300, 900}},
+	}, "severity")
+	labels := map[string]string{"alertname": "Down", "severity": "critical"}
+	incident := env.fire(t, "fp", labels)
+	if err := env.svc.UpdateStatus(ctx, env.user(t, 1).ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopened a day later, the incident starts again from level 0.
+	env.clock.Advance(24 * time.Hour)
+	env.fire(t, "fp", labels)
+	env.svc.EscalateUnacknowledgedIncidents(ctx, policy)
+	if sent := drain(env.escalations); len(sent) != 0 {
+		t.Fatalf("reopened incident escalated immediately: %v", sent)
+	}
+
+	env.clock.Advance(6 * time.Minute)
+	env.svc.EscalateUnacknowledgedIncidents(ctx, policy)
+	got, err := env.svc.GetIncidentByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.EscalationLevel != 1 {
+		t.Fatalf("level = %d, want 1", got.EscalationLevel)
+	}
+}
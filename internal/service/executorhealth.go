@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// executorHealthTTL is how long a health probe result is reused, so rendering
+// keyboards does not ping the executor on every click.
+const executorHealthTTL = 15 * time.Second
+
+type executorHealth struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	available bool
+}
+
+// ExecutorAvailable reports whether the executor answered its health probe. The
+// result is cached for a few seconds.
+func (s *IncidentService) ExecutorAvailable(ctx context.Context) bool {
+	s.executorHealth.mu.Lock()
+	defer s.executorHealth.mu.Unlock()
+
+	now := s.clock.Now()
+	if !s.executorHealth.checkedAt.IsZero() && now.Sub(s.executorHealth.checkedAt) < executorHealthTTL {
+		return s.executorHealth.available
+	}
+	// Only transitions are logged, not every probe during an outage.
+	wasAvailable := s.executorHealth.checkedAt.IsZero() || s.executorHealth.available
+	err := s.executor.Ping(ctx)
+	switch {
+	case err != nil && wasAvailable:
+		s.logger.WarnContext(ctx, "Executor is unavailable", "error", err)
+	case err == nil && !wasAvailable:
+		s.logger.InfoContext(ctx, "Executor is available again")
+	}
+	s.executorHealth.checkedAt = now
+	s.executorHealth.available = err == nil
+	return s.executorHealth.available
+}
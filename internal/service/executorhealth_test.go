@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExecutorAvailableCachesProbe(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	if !env.svc.ExecutorAvailable(ctx) {
+		t.Fatal("executor reported down before any outage")
+	}
+
+	env.executor.Down = true
+	if !env.svc.ExecutorAvailable(ctx) {
+		t.Error("cached probe result was not reused within the TTL")
+	}
+	env.clock.Advance(15 * time.Second)
+	if env.svc.ExecutorAvailable(ctx) {
+		t.Error("executor reported up after the cache expired during an outage")
+	}
+
+	env.executor.Down = false
+	env.clock.Advance(5 * time.Second)
+	if env.svc.ExecutorAvailable(ctx) {
+		t.Error("recovery was seen before the cached outage expired")
+	}
+	env.clock.Advance(10 * time.Second)
+	if !env.svc.ExecutorAvailable(ctx) {
+		t.Error("executor still reported down after it recovered")
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+// MaxExportRange is the longest period a single export may cover.
+const MaxExportRange = 366 * 24 * time.Hour
+
+// ExportIncidents calls fn for every incident that started in [from, to), oldest
+// first, without loading them all into memory. fn may stop the export by
+// returning an error, which is passed through.
+func (s *IncidentService) ExportIncidents(ctx context.Context, from, to time.Time, fn func(row *models.IncidentExportRow) error) error {
+	if !to.After(from) {
+		return ErrInvalidRange
+	}
+	if to.Sub(from) > MaxExportRange {
+		return ErrRangeTooLarge
+	}
+	return s.repo.ExportRange(ctx, from, to, func(row *models.IncidentExportRow) error {
+		row.Severity = row.Labels[s.severityLabel]
+		return fn(row)
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"chatops-bot/internal/models"
+)
+
+type FeatureFlagService struct {
+	repo  FeatureFlagRepository
+	flags map[string]bool
+	mu    sync.RWMutex
+}
+
+func NewFeatureFlagService(ctx context.Context, repo FeatureFlagRepository, defaults map[string]bool) (*FeatureFlagService, error) {
+	flags := make(map[string]bool)
+	for name, enabled := range models.KnownFeatureFlags {
+		flags[name] = enabled
+	}
+	for name, enabled := range defaults {
+		if _, ok := flags[name]; !ok {
+			slog.Warn("Unknown feature flag in configuration, ignoring", "flag", name)
+			continue
+		}
+		flags[name] = enabled
+	}
+
+	stored, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, flag := range stored {
+		if _, ok := flags[flag.Name]; ok {
+			flags[flag.Name] = flag.Enabled
+		}
+	}
+
+	return &FeatureFlagService{repo: repo, flags: flags}, nil
+}
+
+// SetFeatureFlags makes the service consult flags, e.g. auto_resolve before closing
+// an incident from a resolved alert. Call it before serving requests.
+func (s *IncidentService) SetFeatureFlags(flags *FeatureFlagService) {
+	s.flags = flags
+}
+
+func (s *FeatureFlagService) IsEnabled(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flags[name]
+}
+
+func (s *FeatureFlagService) Set(ctx context.Context, name string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.flags[name]; !ok {
+		return fmt.Errorf("unknown feature flag: %s", name)
+	}
+	if err := s.repo.Set(ctx, name, enabled); err != nil {
+		return err
+	}
+	s.flags[name] = enabled
+	return nil
+}
+
+func (s *FeatureFlagService) List() []models.FeatureFlag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flags := make([]models.FeatureFlag, 0, len(s.flags))
+	for name, enabled := range s.flags {
+		flags = append(flags, models.FeatureFlag{Name: name, Enabled: enabled})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestAutoResolveFlag(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		wantErr    error
+		wantStatus models.IncidentStatus
+	}{
+		{"enabled", true, nil, models.StatusResolved},
+		{"disabled", false, service.ErrAutoResolveDisabled, models.StatusActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			flagRepo, err := gormrepo.NewGormFeatureFlagRepository(testutil.OpenDB(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			flags, err := service.NewFeatureFlagService(ctx, flagRepo, map[string]bool{models.FlagAutoResolve: tt.enabled})
+			if err != nil {
+				t.Fatal(err)
+			}
+			env.svc.SetFeatureFlags(flags)
+
+			labels := map[string]string{"alertname": "AutoResolve"}
+			incident := env.fire(t, "fp-auto", labels)
+			_, err = env.svc.ResolveIncidentFromAlert(ctx, models.Alert{Status: "resolved", Fingerprint: "fp-auto", Labels: labels})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveIncidentFromAlert error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, err := env.repo.FindByID(ctx, incident.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
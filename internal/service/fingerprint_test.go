@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestAlertsWithoutFingerprintDeduplicate(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alert := func(pod string) models.Alert {
+		return models.Alert{
+			Status:      "firing",
+			Labels:      models.Labels{"alertname": "HighCPU", "pod": pod},
+			Annotations: models.Annotations{"summary": "cpu"},
+			StartsAt:    env.clock.Now(),
+		}
+	}
+
+	first, err := env.svc.CreateIncidentFromAlert(ctx, alert("api-0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := env.svc.CreateIncidentFromAlert(ctx, alert("api-0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := env.svc.CreateIncidentFromAlert(ctx, alert("api-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Fingerprint == "" {
+		t.Fatal("incident stored with an empty fingerprint")
+	}
+	if again.ID != first.ID {
+		t.Errorf("repeated alert opened incident %d, want %d", again.ID, first.ID)
+	}
+	if other.ID == first.ID {
+		t.Error("alert with different labels joined the same incident")
+	}
+}
+
+func TestAlertWithoutFingerprintOrLabels(t *testing.T) {
+	env := newTestEnv(t)
+	_, err := env.svc.CreateIncidentFromAlert(context.Background(), models.Alert{Status: "firing", StartsAt: env.clock.Now()})
+	if !errors.Is(err, service.ErrMissingFingerprint) {
+		t.Fatalf("err = %v, want ErrMissingFingerprint", err)
+	}
+}
@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/executor/mock"
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+// testEnv is an IncidentService backed by a migrated sqlite database, a fake
+// clock and the mock executor, with buffered channels standing in for the bot.
+type testEnv struct {
+	svc            *service.IncidentService
+	repo           service.IncidentRepository
+	users          service.UserRepository
+	clock          *service.FakeClock
+	executor       *mock.ExecutorClientMock
+	notifications  chan *models.Incident
+	updates        chan *models.Incident
+	topicDeletions chan *models.Incident
+	escalations    chan *models.Incident
+	reminders      chan *models.Incident
+	snoozes        chan *models.Incident
+}
+
+var testStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	db := testutil.OpenDB(t)
+	repo, err := gormrepo.NewGormIncidentRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := gormrepo.NewGormUserRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &testEnv{
+		repo:           repo,
+		users:          users,
+		clock:          service.NewFakeClock(testStart),
+		executor:       mock.NewExecutorClientMock(),
+		notifications:  make(chan *models.Incident, 100),
+		updates:        make(chan *models.Incident, 100),
+		topicDeletions: make(chan *models.Incident, 100),
+		escalations:    make(chan *models.Incident, 100),
+		reminders:      make(chan *models.Incident, 100),
+		snoozes:        make(chan *models.Incident, 100),
+	}
+	env.svc = service.NewIncidentService(repo, users, env.executor, nil, env.notifications, env.updates, env.topicDeletions, env.escalations, env.reminders, env.snoozes, env.clock, testutil.DiscardLogger())
+	return env
+}
+
+// fire sends a firing alert with the given fingerprint and labels.
+func (e *testEnv) fire(t *testing.T, fingerprint string, labels map[string]string) *models.Incident {
+	t.Helper()
+	incident, err := e.svc.CreateIncidentFromAlert(context.Background(), models.Alert{
+		Status:      "firing",
+		Fingerprint: fingerprint,
+		Labels:      labels,
+		Annotations: models.Annotations{"summary": "test alert"},
+		StartsAt:    e.clock.Now(),
+	})
+	if err != nil {
+		t.Fatalf("fire %s: %v", fingerprint, err)
+	}
+	return incident
+}
+
+// user returns a registered user with the given Telegram ID.
+func (e *testEnv) user(t *testing.T, telegramID int64) *models.User {
+	t.Helper()
+	user, err := e.users.FindOrCreateByTelegramID(context.Background(), telegramID, fmt.Sprintf("user%d", telegramID), "Test", "User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// drain empties ch and returns the IDs of the incidents it held.
+func drain(ch chan *models.Incident) []uint {
+	var ids []uint
+	for {
+		select {
+		case incident := <-ch:
+			ids = append(ids, incident.ID)
+		default:
+			return ids
+		}
+	}
+}
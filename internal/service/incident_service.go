@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"expvar"
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"chatops-bot/internal/models"
@@ -12,6 +19,27 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	ErrIncidentNotActive   = errors.New("incident is not active")
+	ErrAlreadyAcknowledged = errors.New("incident is already acknowledged by another user")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrIncidentNotFound    = errors.New("incident not found")
+	ErrEmptyComment        = errors.New("comment text is empty")
+	ErrDuplicateAction     = errors.New("action has already been executed")
+	ErrIncidentExists      = errors.New("incident with this fingerprint already exists")
+	ErrIncidentActive      = errors.New("incident is already active")
+	ErrMissingFingerprint  = errors.New("alert has neither a fingerprint nor labels")
+	ErrEmptySummary        = errors.New("summary is required")
+	ErrEmptyFilter         = errors.New("at least one label filter is required")
+	ErrEmptySeverity       = errors.New("severity is required")
+	ErrBelowMinSeverity    = errors.New("alert severity is below the configured minimum")
+	ErrLastAdmin           = errors.New("cannot revoke the rights of the last admin")
+	ErrInvalidRange        = errors.New("invalid time range")
+	ErrRangeTooLarge       = errors.New("time range is too large")
+	ErrUnsupportedResource = errors.New("unsupported resource type")
+	ErrAutoResolveDisabled = errors.New("auto-resolve is disabled")
+)
+
 type IncidentService struct {
 	repo              IncidentRepository
 	userRepo          UserRepository
@@ -20,9 +48,50 @@ type IncidentService struct {
 	notificationChan  chan<- *models.Incident
 	updateChan        chan<- *models.Incident
 	topicDeletionChan chan<- *models.Incident
+	escalationChan    chan<- *models.Incident
+	reminderChan      chan<- *models.Incident
+	snoozeChan        chan<- *models.Incident
+	logger            *slog.Logger
+	clock             Clock
+	// inFlight holds idempotency keys of actions currently executing, so a replay
+	// arriving before the audit entry is written is rejected as well.
+	inFlight sync.Map
+	// actionLimiter caps concurrent executor calls; nil means no limit.
+	actionLimiter *actionLimiter
+	// executorHealth caches the last executor health probe.
+	executorHealth executorHealth
+	// severityFilter drops low-severity alerts; nil accepts all.
+	severityFilter *severityFilter
+	// severityLabel is the label holding the incident severity.
+	severityLabel string
+	// quietHours holds back announcements of less severe incidents; nil disables it.
+	quietHours *quietHours
+	// flags switches optional behavior at runtime; nil leaves every feature on.
+	flags *FeatureFlagService
 }
 
-func NewIncidentService(repo IncidentRepository, userRepo UserRepository, executor ExecutorClient, suggester *ActionSuggester, notifChan, updateChan, topicDeletionChan chan<- *models.Incident) *IncidentService {
+// Audit entries recorded on behalf of Alertmanager are attributed to this user.
+const (
+	systemTelegramID = 0
+	systemUsername   = "alertmanager"
+)
+
+// droppedUpdates counts bot notifications dropped because the channel was full,
+// keyed by kind ("notification", "update", "escalation", ...). Exposed via /debug/vars.
+var droppedUpdates = expvar.NewMap("incident_updates_dropped")
+
+// publishRetryTimeout bounds how long a message for a full bot channel is retried
+// in the background before it is dropped, so a stalled or absent consumer cannot
+// pile up goroutines forever.
+var publishRetryTimeout = 5 * time.Second
+
+func NewIncidentService(repo IncidentRepository, userRepo UserRepository, executor ExecutorClient, suggester *ActionSuggester, notifChan, updateChan, topicDeletionChan, escalationChan, reminderChan, snoozeChan chan<- *models.Incident, clock Clock, logger *slog.Logger) *IncidentService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if clock == nil {
+		clock = SystemClock{}
+	}
 	return &IncidentService{
 		repo:              repo,
 		userRepo:          userRepo,
@@ -31,139 +100,1071 @@ func NewIncidentService(repo IncidentRepository, userRepo UserRepository, execut
 		notificationChan:  notifChan,
 		updateChan:        updateChan,
 		topicDeletionChan: topicDeletionChan,
+		escalationChan:    escalationChan,
+		reminderChan:      reminderChan,
+		snoozeChan:        snoozeChan,
+		clock:             clock,
+		logger:            logger,
+		severityLabel:     "severity",
+	}
+}
+
+// Clock returns the clock the service measures incident ages with, so callers
+// that report those ages agree with escalation and reminder timing.
+func (s *IncidentService) Clock() Clock {
+	return s.clock
+}
+
+// publish hands the incident to the bot without blocking the caller. If the bot
+// is falling behind and the channel is full, the send is retried in the background
+// for up to publishRetryTimeout, after which the message is dropped and counted.
+// A nil channel means nobody consumes this kind of message.
+func (s *IncidentService) publish(ch chan<- *models.Incident, incident *models.Incident, kind string) {
+	if ch == nil {
+		return
+	}
+	select {
+	case ch <- incident:
+		return
+	default:
 	}
+
+	go func() {
+		timer := time.NewTimer(publishRetryTimeout)
+		defer timer.Stop()
+		select {
+		case ch <- incident:
+		case <-timer.C:
+			droppedUpdates.Add(kind, 1)
+			s.logger.Warn("Bot channel is full, dropping message", "kind", kind, "incident_id", incident.ID)
+		}
+	}()
 }
 
 func (s *IncidentService) GetIncidentByID(ctx context.Context, id uint) (*models.Incident, error) {
-	return s.repo.FindByID(ctx, id)
+	incident, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrIncidentNotFound
+	}
+	return incident, err
+}
+
+// GetAuditLog returns the incident's audit log ordered by timestamp, oldest first.
+func (s *IncidentService) GetAuditLog(ctx context.Context, incidentID uint) ([]models.AuditRecord, error) {
+	incident, err := s.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	records := incident.AuditLog
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
 }
 
 func (s *IncidentService) ListActiveIncidents(ctx context.Context) ([]*models.Incident, error) {
 	return s.repo.ListActive(ctx)
 }
 
+// ListActiveIncidentsPage returns a page of active incidents with the most severe first,
+// together with the total number of active incidents.
+func (s *IncidentService) ListActiveIncidentsPage(ctx context.Context, limit, offset int) ([]*models.Incident, int64, error) {
+	return s.repo.ListActivePage(ctx, s.severityLabel, limit, offset)
+}
+
+// FindIncidentsByLabels returns incidents matching every label pair, optionally filtered by status.
+func (s *IncidentService) FindIncidentsByLabels(ctx context.Context, labels map[string]string, status models.IncidentStatus) ([]*models.Incident, error) {
+	if len(labels) == 0 {
+		return nil, errors.New("at least one label is required")
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	incidents, err := s.repo.FindByLabel(ctx, keys[0], labels[keys[0]], status)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*models.Incident
+	for _, incident := range incidents {
+		ok := true
+		for _, key := range keys[1:] {
+			if incident.Labels[key] != labels[key] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, incident)
+		}
+	}
+	return matched, nil
+}
+
+// BulkResolve resolves every active incident matching all of the labels and returns
+// how many were resolved. An empty filter is rejected so a typo cannot close
+// everything at once. Each incident gets its own audit record and update push; a
+// failure on one does not stop the rest.
+func (s *IncidentService) BulkResolve(ctx context.Context, userID uint, labels map[string]string) (int, error) {
+	if len(labels) == 0 {
+		return 0, ErrEmptyFilter
+	}
+	incidents, err := s.FindIncidentsByLabels(ctx, labels, models.StatusActive)
+	if err != nil {
+		return 0, err
+	}
+
+	reason := "bulk resolve: " + formatLabelFilter(labels)
+	resolved := 0
+	var errs []error
+	for _, incident := range incidents {
+		if err := s.UpdateStatus(ctx, userID, incident.ID, models.StatusResolved, reason); err != nil {
+			errs = append(errs, fmt.Errorf("incident %d: %w", incident.ID, err))
+			continue
+		}
+		resolved++
+	}
+	return resolved, errors.Join(errs...)
+}
+
+func formatLabelFilter(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
 func (s *IncidentService) ListClosed(ctx context.Context, limit int, offset int) ([]*models.Incident, error) {
 	return s.repo.ListClosed(ctx, limit, offset)
 }
 
+// CountClosed returns the number of resolved and rejected incidents, i.e. the size
+// of the list paged by ListClosed.
+func (s *IncidentService) CountClosed(ctx context.Context) (int64, error) {
+	return s.repo.CountClosed(ctx)
+}
+
+// alertFingerprint returns the alert's fingerprint. Some Alertmanager setups omit
+// it; a stable one is derived from the labels so repeated firings still map to the
+// same incident.
+func (s *IncidentService) alertFingerprint(ctx context.Context, alert models.Alert) (string, error) {
+	if alert.Fingerprint != "" {
+		return alert.Fingerprint, nil
+	}
+	if len(alert.Labels) == 0 {
+		return "", ErrMissingFingerprint
+	}
+	fingerprint := alert.ComputeFingerprint()
+	s.logger.DebugContext(ctx, "Alert has no fingerprint, derived one from labels", "fingerprint", fingerprint)
+	return fingerprint, nil
+}
+
+// CreateIncidentFromAlert opens an incident for a firing alert, or reopens the
+// closed one with the same fingerprint. Alerts below the minimum severity are
+// skipped with ErrBelowMinSeverity, alerts matching an active silence with
+// ErrSilenced.
 func (s *IncidentService) CreateIncidentFromAlert(ctx context.Context, alert models.Alert) (*models.Incident, error) {
+	if !s.severityFilter.allows(alert.Labels) {
+		return nil, ErrBelowMinSeverity
+	}
+	silence, err := s.matchingSilence(ctx, alert.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if silence != nil {
+		s.logger.InfoContext(ctx, "Alert matches a silence, skipping incident creation", "silence_id", silence.ID, "alertname", alert.Labels["alertname"], "fingerprint", alert.Fingerprint)
+		return nil, ErrSilenced
+	}
+	fingerprint, err := s.alertFingerprint(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	alert.Fingerprint = fingerprint
 	existing, err := s.repo.FindByFingerprint(ctx, alert.Fingerprint)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
 
 	if err == nil && existing.Status == models.StatusActive {
-		log.Printf("Incident with fingerprint %s already exists and is active. Skipping creation.", alert.Fingerprint)
+		s.logger.InfoContext(ctx, "Active incident with this fingerprint already exists, skipping creation", "incident_id", existing.ID, "fingerprint", alert.Fingerprint)
 		return existing, nil
 	}
-
-	affectedResources := make(models.JSONBMap)
-	if val, ok := alert.Labels["deployment"]; ok {
-		affectedResources["deployment"] = val
-	}
-	if val, ok := alert.Labels["pod"]; ok {
-		affectedResources["pod"] = val
-	}
-	if val, ok := alert.Labels["namespace"]; ok {
-		affectedResources["namespace"] = val
+	if err == nil {
+		return s.reopenIncident(ctx, existing, alert)
 	}
 
-	incident := &models.Incident{
+	return s.createIncident(ctx, &models.Incident{
 		Fingerprint:       alert.Fingerprint,
 		Status:            models.StatusActive,
 		StartsAt:          alert.StartsAt,
 		Summary:           alert.Annotations["summary"],
 		Description:       alert.Annotations["description"],
 		Labels:            models.JSONBMap(alert.Labels),
+		Annotations:       models.JSONBMap(alert.Annotations),
+		GeneratorURL:      alert.GeneratorURL,
+		AffectedResources: affectedResourcesFromLabels(alert.Labels),
+		AuditLog:          []models.AuditRecord{},
+	})
+}
+
+// ResolveIncidentFromAlert resolves the active incident of an alert that stopped
+// firing, on behalf of the system user. It returns ErrIncidentNotActive when the
+// alert has no active incident and ErrAutoResolveDisabled when the auto_resolve
+// flag is off.
+func (s *IncidentService) ResolveIncidentFromAlert(ctx context.Context, alert models.Alert) (*models.Incident, error) {
+	if s.flags != nil && !s.flags.IsEnabled(models.FlagAutoResolve) {
+		return nil, ErrAutoResolveDisabled
+	}
+	fingerprint, err := s.alertFingerprint(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := s.repo.FindByFingerprint(ctx, fingerprint)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrIncidentNotActive
+	}
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != models.StatusActive {
+		return nil, ErrIncidentNotActive
+	}
+
+	systemUser, err := s.userRepo.FindOrCreateByTelegramID(ctx, systemTelegramID, systemUsername, "Alertmanager", "")
+	if err != nil {
+		return nil, err
+	}
+
+	record := s.applyStatus(incident, systemUser.ID, models.StatusResolved, "alert resolved", s.alertEndTime(alert, incident))
+	if err := s.saveStatus(ctx, incident, record); err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Incident resolved by alert", "incident_id", incident.ID, "fingerprint", fingerprint)
+	s.publish(s.updateChan, incident, "update")
+	return incident, nil
+}
+
+// NewIncident holds the fields of an incident created without Alertmanager, e.g.
+// by QA or runbook automation.
+type NewIncident struct {
+	Fingerprint       string
+	Summary           string
+	Description       string
+	Labels            map[string]string
+	AffectedResources map[string]string
+}
+
+// CreateIncident creates an active incident and announces it like an alert would.
+// A fingerprint is generated when none is given; an existing one is rejected with
+// ErrIncidentExists.
+func (s *IncidentService) CreateIncident(ctx context.Context, in NewIncident) (*models.Incident, error) {
+	if strings.TrimSpace(in.Summary) == "" {
+		return nil, ErrEmptySummary
+	}
+	fingerprint := in.Fingerprint
+	if fingerprint == "" {
+		generated, err := generateFingerprint()
+		if err != nil {
+			return nil, err
+		}
+		fingerprint = generated
+	}
+	_, err := s.repo.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return nil, ErrIncidentExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	labels := models.JSONBMap(in.Labels)
+	if labels == nil {
+		labels = models.JSONBMap{}
+	}
+	affectedResources := affectedResourcesFromLabels(in.Labels)
+	for key, value := range in.AffectedResources {
+		affectedResources[key] = value
+	}
+
+	return s.createIncident(ctx, &models.Incident{
+		Fingerprint:       fingerprint,
+		Status:            models.StatusActive,
+		StartsAt:          s.clock.Now(),
+		Summary:           in.Summary,
+		Description:       in.Description,
+		Labels:            labels,
 		AffectedResources: affectedResources,
 		AuditLog:          []models.AuditRecord{},
+	})
+}
+
+func (s *IncidentService) createIncident(ctx context.Context, incident *models.Incident) (*models.Incident, error) {
+	incident.ActiveSince = s.clock.Now()
+	incident.NotificationPending = s.holdsNotification(incident)
+	if err := s.repo.Create(ctx, incident); err != nil {
+		return nil, err
 	}
+	s.announce(ctx, incident)
+	return incident, nil
+}
 
-	err = s.repo.Create(ctx, incident)
+// affectedResourcesFromLabels picks the Kubernetes resources an incident is about
+// from its labels.
+func affectedResourcesFromLabels(labels map[string]string) models.JSONBMap {
+	affectedResources := make(models.JSONBMap)
+	for _, key := range []string{"deployment", "pod", "namespace", "node"} {
+		if val, ok := labels[key]; ok {
+			affectedResources[key] = val
+		}
+	}
+	return affectedResources
+}
+
+func generateFingerprint() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "manual-" + hex.EncodeToString(buf), nil
+}
+
+// reopenAction is the audit action recorded whenever a closed incident becomes active again.
+const reopenAction = "reopen"
+
+// reopenIncident brings a closed (or archived) incident back to active when its alert
+// fires again, since the fingerprint is unique and a new row cannot be created.
+func (s *IncidentService) reopenIncident(ctx context.Context, existing *models.Incident, alert models.Alert) (*models.Incident, error) {
+	if existing.DeletedAt.Valid {
+		if err := s.repo.Restore(ctx, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+	incident, err := s.repo.FindByID(ctx, existing.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	go func() {
-		s.notificationChan <- incident
-	}()
+	systemUser, err := s.userRepo.FindOrCreateByTelegramID(ctx, systemTelegramID, systemUsername, "Alertmanager", "")
+	if err != nil {
+		return nil, err
+	}
 
+	previousStatus := incident.Status
+	incident.Status = models.StatusActive
+	incident.StartsAt = alert.StartsAt
+	incident.EndsAt = nil
+	incident.Summary = alert.Annotations["summary"]
+	incident.Description = alert.Annotations["description"]
+	incident.Labels = withCustomTags(alert.Labels, incident.Labels)
+	incident.Annotations = models.JSONBMap(alert.Annotations)
+	incident.GeneratorURL = alert.GeneratorURL
+	incident.ResolvedBy = nil
+	incident.ResolvedByUser = models.User{}
+	incident.RejectionReason = ""
+	incident.AcknowledgedBy = nil
+	incident.AcknowledgedByUser = models.User{}
+	incident.AcknowledgedAt = nil
+	incident.EscalatedAt = nil
+	incident.EscalationLevel = 0
+	incident.SnoozedUntil = nil
+	incident.OccurrenceCount++
+	incident.ActiveSince = s.clock.Now()
+	incident.LastRemindedAt = nil
+	incident.NotificationPending = s.holdsNotification(incident)
+
+	record := &models.AuditRecord{
+		IncidentID: incident.ID,
+		UserID:     systemUser.ID,
+		Action:     reopenAction,
+		Parameters: map[string]string{
+			"previous_status":  string(previousStatus),
+			"occurrence_count": strconv.Itoa(incident.OccurrenceCount),
+		},
+		Timestamp: s.clock.Now(),
+		Success:   true,
+		Result:    "Alert fired again, incident reopened",
+	}
+	err = s.saveFields(ctx, incident, record, map[string]interface{}{
+		"status":               incident.Status,
+		"starts_at":            incident.StartsAt,
+		"ends_at":              nil,
+		"summary":              incident.Summary,
+		"description":          incident.Description,
+		"labels":               incident.Labels,
+		"annotations":          incident.Annotations,
+		"generator_url":        incident.GeneratorURL,
+		"resolved_by":          nil,
+		"rejection_reason":     "",
+		"acknowledged_by":      nil,
+		"acknowledged_at":      nil,
+		"escalated_at":         nil,
+		"escalation_level":     0,
+		"snoozed_until":        nil,
+		"occurrence_count":     incident.OccurrenceCount,
+		"active_since":         incident.ActiveSince,
+		"last_reminded_at":     nil,
+		"notification_pending": incident.NotificationPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Incident reopened by a re-fired alert", "incident_id", incident.ID, "occurrence", incident.OccurrenceCount)
+
+	s.announce(ctx, incident)
 	return incident, nil
 }
 
+// Reopen makes a closed incident active again, e.g. when it was resolved too early
+// and recurred before the alert fired again. The incident is announced anew.
+func (s *IncidentService) Reopen(ctx context.Context, userID, incidentID uint) (*models.Incident, error) {
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == models.StatusActive {
+		return nil, ErrIncidentActive
+	}
+
+	previousStatus := incident.Status
+	incident.Status = models.StatusActive
+	incident.EndsAt = nil
+	incident.ResolvedBy = nil
+	incident.ResolvedByUser = models.User{}
+	incident.RejectionReason = ""
+	incident.AcknowledgedBy = nil
+	incident.AcknowledgedByUser = models.User{}
+	incident.AcknowledgedAt = nil
+	incident.SnoozedUntil = nil
+	incident.EscalatedAt = nil
+	incident.EscalationLevel = 0
+	incident.ActiveSince = s.clock.Now()
+	incident.LastRemindedAt = nil
+	incident.NotificationPending = s.holdsNotification(incident)
+
+	record := &models.AuditRecord{
+		IncidentID: incident.ID,
+		UserID:     userID,
+		Action:     reopenAction,
+		Parameters: map[string]string{"previous_status": string(previousStatus)},
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+		Result:     "Incident reopened manually",
+	}
+	err = s.saveFields(ctx, incident, record, map[string]interface{}{
+		"status":               incident.Status,
+		"ends_at":              nil,
+		"resolved_by":          nil,
+		"rejection_reason":     "",
+		"acknowledged_by":      nil,
+		"acknowledged_at":      nil,
+		"snoozed_until":        nil,
+		"escalated_at":         nil,
+		"escalation_level":     0,
+		"active_since":         incident.ActiveSince,
+		"last_reminded_at":     nil,
+		"notification_pending": incident.NotificationPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Incident reopened manually", "incident_id", incident.ID, "user_id", userID)
+
+	s.announce(ctx, incident)
+	return incident, nil
+}
+
+const setSeverityAction = "set_severity"
+
+// SetSeverity overrides the incident's severity, stored under the label given to
+// SetMinSeverity (the configured telegram.severity_label) so routing and stats see
+// the new value. It returns the severity the incident had before; setting the same
+// value is a no-op.
+func (s *IncidentService) SetSeverity(ctx context.Context, userID, incidentID uint, severity string) (*models.Incident, string, error) {
+	if severity == "" {
+		return nil, "", ErrEmptySeverity
+	}
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, "", err
+	}
+	if incident.Status != models.StatusActive {
+		return nil, "", ErrIncidentNotActive
+	}
+
+	if incident.Labels[s.severityLabel] == severity {
+		return incident, severity, nil
+	}
+
+	var previous string
+	record := &models.AuditRecord{
+		IncidentID: incident.ID,
+		UserID:     userID,
+		Action:     setSeverityAction,
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+	}
+	err = s.repo.UpdateLabels(ctx, incident.ID, func(labels models.JSONBMap) error {
+		previous = labels[s.severityLabel]
+		labels[s.severityLabel] = severity
+		record.Parameters = map[string]string{"previous": previous, "severity": severity}
+		record.Result = fmt.Sprintf("Severity changed from %q to %q", previous, severity)
+		return nil
+	}, record)
+	if err != nil {
+		return nil, "", err
+	}
+	incident, err = s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, "", err
+	}
+	s.logger.InfoContext(ctx, "Incident severity changed", "incident_id", incident.ID, "previous", previous, "severity", severity, "user_id", userID)
+
+	s.publish(s.updateChan, incident, "update")
+	return incident, previous, nil
+}
+
 func (s *IncidentService) SetTelegramMessageID(ctx context.Context, incidentID uint, chatID, messageID int64) error {
 	return s.repo.SetTelegramMessageID(ctx, incidentID, chatID, messageID)
 }
 
+func (s *IncidentService) AddTelegramMessage(ctx context.Context, message *models.TelegramMessage) error {
+	return s.repo.AddTelegramMessage(ctx, message)
+}
+
+func (s *IncidentService) ListTelegramMessages(ctx context.Context, incidentID uint) ([]*models.TelegramMessage, error) {
+	return s.repo.ListTelegramMessages(ctx, incidentID)
+}
+
+func (s *IncidentService) DeleteTelegramMessage(ctx context.Context, chatID, messageID int64) error {
+	return s.repo.DeleteTelegramMessage(ctx, chatID, messageID)
+}
+
 func (s *IncidentService) SetTelegramTopicID(ctx context.Context, incidentID uint, topicID int64) error {
 	return s.repo.SetTelegramTopicID(ctx, incidentID, topicID)
 }
 
+// ExecuteAction runs the action and records it in the audit log. Requests carrying
+// an idempotency key that was already seen are rejected with ErrDuplicateAction.
 func (s *IncidentService) ExecuteAction(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	if req.IdempotencyKey != "" {
+		if _, running := s.inFlight.LoadOrStore(req.IdempotencyKey, struct{}{}); running {
+			return models.ActionResult{}, ErrDuplicateAction
+		}
+		defer s.inFlight.Delete(req.IdempotencyKey)
+
+		seen, err := s.repo.HasIdempotencyKey(ctx, req.IdempotencyKey)
+		if err != nil {
+			return models.ActionResult{}, err
+		}
+		if seen {
+			s.logger.WarnContext(ctx, "Rejecting replayed action", "incident_id", req.IncidentID, "action", req.Action)
+			return models.ActionResult{}, ErrDuplicateAction
+		}
+	}
+
 	incident, err := s.repo.FindByID(ctx, req.IncidentID)
 	if err != nil {
 		return models.ActionResult{Error: "Incident not found"}, err
 	}
 
-	result := s.executor.ExecuteAction(req)
+	dryRun := req.DryRun && !models.ActionType(req.Action).IsReadOnly()
+	var result models.ActionResult
+	if dryRun {
+		result = dryRunResult(req)
+		s.logger.InfoContext(ctx, "Dry run, skipping executor", "incident_id", req.IncidentID, "action", req.Action)
+	} else {
+		result, err = s.executeOnExecutor(ctx, req)
+		if err != nil {
+			return result, err
+		}
+	}
 
 	entry := models.AuditRecord{
-		IncidentID: req.IncidentID,
-		UserID:     req.UserID,
-		Action:     req.Action,
-		Parameters: models.JSONBMap(req.Parameters),
-		Timestamp:  time.Now(),
-		Success:    result.Error == "",
-		Result:     result.Message,
+		IncidentID:     req.IncidentID,
+		UserID:         req.UserID,
+		Action:         req.Action,
+		Parameters:     models.JSONBMap(req.Parameters),
+		Timestamp:      s.clock.Now(),
+		Success:        result.Error == "",
+		Result:         result.Message,
+		IdempotencyKey: req.IdempotencyKey,
+		DryRun:         dryRun,
 	}
 
 	addAffectedResourceToAudit(&entry, req)
 
-	incident.AuditLog = append(incident.AuditLog, entry)
-
-	if err := s.repo.Update(ctx, incident); err != nil {
+	// Insert the record on its own instead of saving the whole incident: actions
+	// run concurrently with each other and with status changes, and saving the
+	// incident loaded before the (possibly slow) executor call would overwrite
+	// whatever changed in the meantime.
+	if err := s.repo.AddAuditRecord(ctx, &entry); err != nil {
 		return result, err
 	}
 
-	s.updateChan <- incident
+	if fresh, err := s.repo.FindByID(ctx, req.IncidentID); err == nil {
+		incident = fresh
+	} else {
+		s.logger.WarnContext(ctx, "Failed to reload incident after action", "incident_id", req.IncidentID, "error", err)
+		incident.AuditLog = append(incident.AuditLog, entry)
+	}
+	s.publish(s.updateChan, incident, "update")
 
 	return result, nil
 }
 
+// RecordDeniedAction writes a failed audit entry for an action the user was not
+// allowed to run, so there is a trail of who tried what.
+func (s *IncidentService) RecordDeniedAction(ctx context.Context, req models.ActionRequest) error {
+	return s.repo.AddAuditRecord(ctx, &models.AuditRecord{
+		IncidentID: req.IncidentID,
+		UserID:     req.UserID,
+		Action:     req.Action,
+		Parameters: models.JSONBMap(req.Parameters),
+		Timestamp:  s.clock.Now(),
+		Success:    false,
+		Result:     "permission denied",
+	})
+}
+
+// dryRunResult describes what a mutating action would have done.
+func dryRunResult(req models.ActionRequest) models.ActionResult {
+	keys := make([]string, 0, len(req.Parameters))
+	for key := range req.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", key, req.Parameters[key]))
+	}
+	return models.ActionResult{
+		Message: fmt.Sprintf("[DRY RUN] would have run %s (%s)", req.Action, strings.Join(params, ", ")),
+	}
+}
+
+// ExecuteReadOnlyAction runs a read-only action without touching the audit log or
+// publishing an update. It is meant for polling, e.g. live log tailing.
+func (s *IncidentService) ExecuteReadOnlyAction(ctx context.Context, req models.ActionRequest) (models.ActionResult, error) {
+	if !models.ActionType(req.Action).IsReadOnly() {
+		return models.ActionResult{}, fmt.Errorf("action %s is not read-only", req.Action)
+	}
+	return s.executeOnExecutor(ctx, req)
+}
+
 func (s *IncidentService) GetResourceDetails(ctx context.Context, req models.ResourceDetailsRequest) (*models.ResourceDetails, error) {
-	return s.executor.GetResourceDetails(req)
+	return s.executor.GetResourceDetails(ctx, req)
+}
+
+// detailsResourceTypes are the resource types the executor reports details for.
+var detailsResourceTypes = map[string]bool{"pod": true, "deployment": true, "node": true}
+
+// GetIncidentResourceDetails fetches live details of a resource of the incident,
+// resolving its namespace from the incident's labels.
+func (s *IncidentService) GetIncidentResourceDetails(ctx context.Context, incidentID uint, resourceType, resourceName string) (*models.ResourceDetails, error) {
+	if !detailsResourceTypes[resourceType] {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedResource, resourceType)
+	}
+	incident, err := s.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.executor.GetResourceDetails(ctx, models.ResourceDetailsRequest{
+		IncidentID:   incident.ID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Labels:       incident.Labels,
+	})
 }
 
 func (s *IncidentService) GetAvailableResources(ctx context.Context) (*models.AvailableResources, error) {
-	return s.executor.GetAvailableResources()
+	return s.executor.GetAvailableResources(ctx)
 }
 
 func (s *IncidentService) DeleteOldIncidentTopics(ctx context.Context, retention time.Duration) {
-	threshold := time.Now().Add(-retention)
+	threshold := s.clock.Now().Add(-retention)
 	incidents, err := s.repo.FindClosedBefore(ctx, threshold)
 	if err != nil {
-		log.Printf("Error finding old incidents to delete topics: %v", err)
+		s.logger.ErrorContext(ctx, "Failed to find old incidents to delete topics", "error", err)
 		return
 	}
 
 	for _, incident := range incidents {
 		if incident.TelegramTopicID.Valid {
-			log.Printf("Scheduling topic deletion for incident #%d", incident.ID)
-			s.topicDeletionChan <- incident
+			s.logger.InfoContext(ctx, "Scheduling topic deletion", "incident_id", incident.ID)
+			s.publish(s.topicDeletionChan, incident, "topic_deletion")
+		}
+	}
+}
+
+type ArchiveOptions struct {
+	KeepAuditRecords bool
+	DryRun           bool
+}
+
+// ArchiveOldIncidents soft-deletes incidents closed more than olderThan ago.
+// In dry-run mode it only logs the incidents that would be archived.
+func (s *IncidentService) ArchiveOldIncidents(ctx context.Context, olderThan time.Duration, opts ArchiveOptions) {
+	threshold := s.clock.Now().Add(-olderThan)
+
+	if opts.DryRun {
+		incidents, err := s.repo.FindClosedBefore(ctx, threshold)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to find old incidents to archive", "error", err)
+			return
+		}
+		for _, incident := range incidents {
+			s.logger.InfoContext(ctx, "Dry run: would archive incident", "incident_id", incident.ID, "closed_at", incident.EndsAt.Format(time.RFC3339))
+		}
+		s.logger.InfoContext(ctx, "Dry run: incidents would be archived", "count", len(incidents), "closed_before", threshold.Format(time.RFC3339))
+		return
+	}
+
+	archived, err := s.repo.ArchiveClosedBefore(ctx, threshold, opts.KeepAuditRecords)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to archive old incidents", "error", err)
+		return
+	}
+	if archived > 0 {
+		s.logger.InfoContext(ctx, "Archived old incidents", "count", archived, "closed_before", threshold.Format(time.RFC3339))
+	}
+}
+
+// EscalateUnacknowledgedIncidents raises the escalation level of active,
+// unacknowledged and not snoozed incidents whose age (since they were last opened)
+// passed the next threshold of the policy. Each level fires once; an incident that is several
+// levels behind catches up one level per run.
+func (s *IncidentService) EscalateUnacknowledgedIncidents(ctx context.Context, policy EscalationPolicy) {
+	if !policy.Enabled() {
+		return
+	}
+	now := s.clock.Now()
+	incidents, err := s.repo.FindUnacknowledgedActiveBefore(ctx, now.Add(-policy.minThreshold()))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to find incidents to escalate", "error", err)
+		return
+	}
+
+	for _, incident := range incidents {
+		if isAcknowledged(incident) || incident.IsSnoozed(now) || incident.NotificationPending {
+			continue
+		}
+		if policy.dueLevel(incident, now.Sub(incident.ActiveSince)) <= incident.EscalationLevel {
+			continue
 		}
+		advanced, err := s.repo.AdvanceEscalationLevel(ctx, incident.ID, incident.EscalationLevel, now)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to mark incident as escalated", "incident_id", incident.ID, "error", err)
+			continue
+		}
+		if !advanced {
+			continue
+		}
+		incident.EscalationLevel++
+		incident.EscalatedAt = &now
+		escalations.Add(strconv.Itoa(incident.EscalationLevel), 1)
+		s.logger.InfoContext(ctx, "Escalating unacknowledged incident", "incident_id", incident.ID, "level", incident.EscalationLevel)
+		s.publish(s.escalationChan, incident, "escalation")
 	}
 }
 
+func isAcknowledged(incident *models.Incident) bool {
+	return incident.AcknowledgedBy != nil
+}
+
+// Acknowledge marks the incident as taken by userID. Repeated acknowledgement by the
+// same user is a no-op; taking over from another user requires force.
+func (s *IncidentService) Acknowledge(ctx context.Context, userID, incidentID uint, force bool) error {
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return err
+	}
+	if incident.Status != models.StatusActive {
+		return ErrIncidentNotActive
+	}
+	if incident.AcknowledgedBy != nil {
+		if *incident.AcknowledgedBy == userID {
+			return nil
+		}
+		if !force {
+			return ErrAlreadyAcknowledged
+		}
+	}
+
+	now := s.clock.Now()
+	params := map[string]string{}
+	if incident.AcknowledgedBy != nil {
+		params["previous_user_id"] = fmt.Sprintf("%d", *incident.AcknowledgedBy)
+	}
+	incident.AcknowledgedBy = &userID
+	incident.AcknowledgedByUser = models.User{}
+	incident.AcknowledgedAt = &now
+
+	entry := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     userID,
+		Action:     "acknowledge",
+		Parameters: params,
+		Timestamp:  now,
+		Success:    true,
+		Result:     "Incident acknowledged",
+	}
+	err = s.saveFields(ctx, incident, entry, map[string]interface{}{
+		"acknowledged_by": userID,
+		"acknowledged_at": now,
+	})
+	if err != nil {
+		return err
+	}
+	s.publish(s.updateChan, incident, "update")
+	return nil
+}
+
+// AcknowledgeAll acknowledges every active incident that nobody has taken yet on
+// behalf of userID, e.g. when a new shift takes over. An empty filter matches all
+// active incidents. It returns how many incidents were acknowledged.
+func (s *IncidentService) AcknowledgeAll(ctx context.Context, userID uint, labels map[string]string) (int, error) {
+	var incidents []*models.Incident
+	var err error
+	if len(labels) == 0 {
+		incidents, err = s.repo.ListActive(ctx)
+	} else {
+		incidents, err = s.FindIncidentsByLabels(ctx, labels, models.StatusActive)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	acknowledged := 0
+	var errs []error
+	for _, incident := range incidents {
+		if incident.AcknowledgedBy != nil {
+			continue
+		}
+		err := s.Acknowledge(ctx, userID, incident.ID, false)
+		// Taken by someone else, or closed, since the list was read.
+		if errors.Is(err, ErrAlreadyAcknowledged) || errors.Is(err, ErrIncidentNotActive) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("incident %d: %w", incident.ID, err))
+			continue
+		}
+		acknowledged++
+	}
+	return acknowledged, errors.Join(errs...)
+}
+
+func (s *IncidentService) Snooze(ctx context.Context, userID, incidentID uint, duration time.Duration) (*models.Incident, error) {
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != models.StatusActive {
+		return nil, ErrIncidentNotActive
+	}
+
+	now := s.clock.Now()
+	until := now.Add(duration)
+	incident.SnoozedUntil = &until
+
+	entry := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     userID,
+		Action:     "snooze",
+		Parameters: map[string]string{"duration": duration.String()},
+		Timestamp:  now,
+		Success:    true,
+		Result:     fmt.Sprintf("Notifications snoozed until %s", until.Format("15:04")),
+	}
+	if err := s.saveFields(ctx, incident, entry, map[string]interface{}{"snoozed_until": until}); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// NotifyExpiredSnoozes clears lapsed snoozes and re-notifies about incidents that are
+// still active. They go to the snooze channel rather than the notification one, so
+// the bot does not mistake them for new or reopened incidents.
+func (s *IncidentService) NotifyExpiredSnoozes(ctx context.Context) {
+	incidents, err := s.repo.FindSnoozeExpired(ctx, s.clock.Now())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to find incidents with expired snooze", "error", err)
+		return
+	}
+
+	for _, incident := range incidents {
+		if err := s.repo.ClearSnooze(ctx, incident.ID); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to clear snooze", "incident_id", incident.ID, "error", err)
+			continue
+		}
+		incident.SnoozedUntil = nil
+		s.logger.InfoContext(ctx, "Snooze expired, re-notifying", "incident_id", incident.ID)
+		s.publish(s.snoozeChan, incident, "snooze_expired")
+	}
+}
+
+// reminderAction is the audit action recorded for every "still firing" reminder.
+const reminderAction = "reminder"
+
+// RemindUnacknowledgedIncidents re-announces active incidents that nobody has
+// acknowledged, once per interval counted from the last reminder or, before the
+// first one, from when the incident was last opened. Snoozed incidents and
+// incidents held back by quiet hours are skipped. Each reminder is stored with the incident and in the audit log, so a
+// restart does not send it again.
+func (s *IncidentService) RemindUnacknowledgedIncidents(ctx context.Context, interval time.Duration) {
+	now := s.clock.Now()
+	incidents, err := s.repo.FindDueForReminder(ctx, now.Add(-interval))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to find incidents to remind about", "error", err)
+		return
+	}
+	if len(incidents) == 0 {
+		return
+	}
+
+	systemUser, err := s.userRepo.FindOrCreateByTelegramID(ctx, systemTelegramID, systemUsername, "Alertmanager", "")
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to resolve system user", "error", err)
+		return
+	}
+
+	for _, incident := range incidents {
+		if isAcknowledged(incident) || incident.IsSnoozed(now) || incident.NotificationPending || !incident.TelegramMessageID.Valid {
+			continue
+		}
+		record := &models.AuditRecord{
+			IncidentID: incident.ID,
+			UserID:     systemUser.ID,
+			Action:     reminderAction,
+			Parameters: map[string]string{"interval": interval.String()},
+			Timestamp:  now,
+			Success:    true,
+			Result:     "Reminder sent",
+		}
+		if err := s.repo.RecordReminder(ctx, record); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to record reminder", "incident_id", incident.ID, "error", err)
+			continue
+		}
+		s.logger.InfoContext(ctx, "Reminding about unacknowledged incident", "incident_id", incident.ID)
+		s.publish(s.reminderChan, incident, "reminder")
+	}
+}
+
+const commentAction = "comment"
+
+// AddComment attaches a free-text note to the incident's audit log.
+func (s *IncidentService) AddComment(ctx context.Context, userID, incidentID uint, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrEmptyComment
+	}
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return err
+	}
+
+	record := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     userID,
+		Action:     commentAction,
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+		Result:     text,
+	}
+	if err := s.repo.AddAuditRecord(ctx, record); err != nil {
+		return err
+	}
+	incident.AuditLog = append(incident.AuditLog, *record)
+	if incident.Status == models.StatusActive {
+		s.publish(s.updateChan, incident, "update")
+	}
+	return nil
+}
+
+// FindUserByUsername resolves a Telegram username to a known user.
+func (s *IncidentService) FindUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Assign makes the user with assigneeTelegramID the owner of the incident. The
+// assignee must have interacted with the bot before so that a user record exists.
+func (s *IncidentService) Assign(ctx context.Context, assignerID, incidentID uint, assigneeTelegramID int64) (*models.Incident, error) {
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != models.StatusActive {
+		return nil, ErrIncidentNotActive
+	}
+
+	assignee, err := s.userRepo.FindByTelegramID(ctx, assigneeTelegramID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{"assignee_id": fmt.Sprintf("%d", assignee.ID)}
+	if incident.AssignedTo != nil {
+		params["previous_assignee_id"] = fmt.Sprintf("%d", *incident.AssignedTo)
+	}
+	incident.AssignedTo = &assignee.ID
+	incident.AssignedToUser = models.User{}
+
+	entry := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     assignerID,
+		Action:     "assign",
+		Parameters: params,
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+		Result:     fmt.Sprintf("Assigned to user %d", assignee.TelegramID),
+	}
+	if err := s.saveFields(ctx, incident, entry, map[string]interface{}{"assigned_to": assignee.ID}); err != nil {
+		return nil, err
+	}
+	incident.AssignedToUser = *assignee
+	s.publish(s.updateChan, incident, "update")
+	return incident, nil
+}
+
 func (s *IncidentService) UpdateStatus(ctx context.Context, userID, incidentID uint, status models.IncidentStatus, reason string) error {
+	return s.UpdateStatusAt(ctx, userID, incidentID, status, reason, s.clock.Now())
+}
+
+// UpdateStatusAt is UpdateStatus with an explicit end time for closing statuses,
+// e.g. when the problem is known to have cleared before it was reported.
+func (s *IncidentService) UpdateStatusAt(ctx context.Context, userID, incidentID uint, status models.IncidentStatus, reason string, endsAt time.Time) error {
 	incident, err := s.repo.FindByID(ctx, incidentID)
 	if err != nil {
 		return err
 	}
 
+	record := s.applyStatus(incident, userID, status, reason, endsAt)
+	err = s.saveStatus(ctx, incident, record)
+	if err == nil {
+		s.publish(s.updateChan, incident, "update")
+	}
+	return err
+}
+
+// applyStatus sets the incident status and returns the audit record of the
+// change. endsAt is used as the end time of closing statuses.
+func (s *IncidentService) applyStatus(incident *models.Incident, userID uint, status models.IncidentStatus, reason string, endsAt time.Time) *models.AuditRecord {
 	incident.Status = status
-	now := time.Now()
 	if status == models.StatusResolved || status == models.StatusRejected {
-		incident.EndsAt = &now
+		incident.EndsAt = &endsAt
 	}
 
 	if status == models.StatusResolved {
@@ -173,25 +1174,56 @@ func (s *IncidentService) UpdateStatus(ctx context.Context, userID, incidentID u
 		incident.RejectionReason = reason
 	}
 
-	entry := models.AuditRecord{
-		IncidentID: incidentID,
+	return &models.AuditRecord{
+		IncidentID: incident.ID,
 		UserID:     userID,
 		Action:     "update_status",
 		Parameters: map[string]string{
 			"new_status": string(status),
 			"reason":     reason,
 		},
-		Timestamp: time.Now(),
+		Timestamp: s.clock.Now(),
 		Success:   true,
 		Result:    fmt.Sprintf("Status updated to %s", status),
 	}
-	incident.AuditLog = append(incident.AuditLog, entry)
+}
 
-	err = s.repo.Update(ctx, incident)
-	if err == nil {
-		s.updateChan <- incident
+// saveStatus persists the columns set by applyStatus together with its audit
+// record, without rewriting the rest of the incident, and adds the record to the
+// incident's audit log.
+func (s *IncidentService) saveStatus(ctx context.Context, incident *models.Incident, record *models.AuditRecord) error {
+	if err := s.repo.UpdateStatus(ctx, incident, record); err != nil {
+		return err
 	}
-	return err
+	incident.AuditLog = append(incident.AuditLog, *record)
+	return nil
+}
+
+// saveFields persists the given columns of the incident together with record and
+// appends the record to the in-memory audit log. Unlike a full save it cannot
+// overwrite columns changed concurrently, e.g. the status.
+func (s *IncidentService) saveFields(ctx context.Context, incident *models.Incident, record *models.AuditRecord, fields map[string]interface{}) error {
+	if err := s.repo.UpdateFields(ctx, incident.ID, fields, record); err != nil {
+		return err
+	}
+	incident.AuditLog = append(incident.AuditLog, *record)
+	return nil
+}
+
+// alertEndTime returns when a resolved alert stopped firing: its EndsAt, or now if
+// the alert has none. A time in the future (clock skew between Alertmanager and
+// us) is replaced by now, and one before the incident started by its start, so
+// durations never come out negative.
+func (s *IncidentService) alertEndTime(alert models.Alert, incident *models.Incident) time.Time {
+	now := s.clock.Now()
+	endsAt := alert.EndsAt
+	switch {
+	case endsAt.IsZero(), endsAt.After(now):
+		endsAt = now
+	case endsAt.Before(incident.StartsAt):
+		endsAt = incident.StartsAt
+	}
+	return endsAt
 }
 
 func addAffectedResourceToAudit(entry *models.AuditRecord, req models.ActionRequest) {
@@ -11,22 +11,81 @@ type IncidentRepository interface {
 	Create(ctx context.Context, incident *models.Incident) error
 	FindByID(ctx context.Context, id uint) (*models.Incident, error)
 	FindByFingerprint(ctx context.Context, fingerprint string) (*models.Incident, error)
-	Update(ctx context.Context, incident *models.Incident) error
+	Restore(ctx context.Context, incidentID uint) error
 	ListActive(ctx context.Context) ([]*models.Incident, error)
+	// ListActivePage returns a page of active incidents, most severe (by the
+	// severityLabel label) first, and the total number of active incidents, both
+	// read in one transaction.
+	ListActivePage(ctx context.Context, severityLabel string, limit, offset int) ([]*models.Incident, int64, error)
+	FindByLabel(ctx context.Context, key, value string, status models.IncidentStatus) ([]*models.Incident, error)
 	ListClosed(ctx context.Context, limit int, offset int) ([]*models.Incident, error)
+	CountClosed(ctx context.Context) (int64, error)
 	SetTelegramMessageID(ctx context.Context, incidentID uint, chatID, messageID int64) error
+	AddTelegramMessage(ctx context.Context, message *models.TelegramMessage) error
+	ListTelegramMessages(ctx context.Context, incidentID uint) ([]*models.TelegramMessage, error)
+	DeleteTelegramMessage(ctx context.Context, chatID, messageID int64) error
 	SetTelegramTopicID(ctx context.Context, incidentID uint, topicID int64) error
 	FindClosedBefore(ctx context.Context, t time.Time) ([]*models.Incident, error)
+	ArchiveClosedBefore(ctx context.Context, t time.Time, keepAuditRecords bool) (int64, error)
+	FindUnacknowledgedActiveBefore(ctx context.Context, t time.Time) ([]*models.Incident, error)
+	AdvanceEscalationLevel(ctx context.Context, incidentID uint, fromLevel int, t time.Time) (bool, error)
+	FindSnoozeExpired(ctx context.Context, t time.Time) ([]*models.Incident, error)
+	ClearSnooze(ctx context.Context, incidentID uint) error
+	FindNotificationPending(ctx context.Context) ([]*models.Incident, error)
+	ClearNotificationPending(ctx context.Context, incidentID uint) error
+	HasIdempotencyKey(ctx context.Context, key string) (bool, error)
+	AddAuditRecord(ctx context.Context, record *models.AuditRecord) error
+	// UpdateStatus writes the status, end time, resolver and rejection reason of
+	// the incident and inserts record in one transaction; other columns and the
+	// audit log association are left untouched.
+	UpdateStatus(ctx context.Context, incident *models.Incident, record *models.AuditRecord) error
+	// UpdateFields writes only the given columns (by name) of the incident and
+	// inserts record in one transaction.
+	UpdateFields(ctx context.Context, incidentID uint, fields map[string]interface{}, record *models.AuditRecord) error
+	// UpdateLabels reloads the incident's labels, applies fn and saves the result
+	// together with record in one transaction, so concurrent edits of different
+	// labels do not overwrite each other.
+	UpdateLabels(ctx context.Context, incidentID uint, fn func(labels models.JSONBMap) error, record *models.AuditRecord) error
+	FindDueForReminder(ctx context.Context, t time.Time) ([]*models.Incident, error)
+	// RecordReminder sets the incident's last reminder time to the record's
+	// timestamp and inserts the record in one transaction.
+	RecordReminder(ctx context.Context, record *models.AuditRecord) error
+	CountByStatusSince(ctx context.Context, t time.Time) (map[models.IncidentStatus]int64, error)
+	CountByLabelSince(ctx context.Context, key string, t time.Time) (map[string]int64, error)
+	ResolveDurations(ctx context.Context, t time.Time) ([]time.Duration, error)
+	// ExportRange calls fn for every incident that started in [from, to), oldest
+	// first, reading them one at a time.
+	ExportRange(ctx context.Context, from, to time.Time, fn func(row *models.IncidentExportRow) error) error
+	CreateSilence(ctx context.Context, silence *models.Silence) error
+	// ListSilences returns all stored silences, including ended ones, ending soonest first.
+	ListSilences(ctx context.Context) ([]*models.Silence, error)
+	DeleteSilence(ctx context.Context, id uint) (bool, error)
+	DeleteSilencesEndedBefore(ctx context.Context, t time.Time) (int64, error)
 }
 
 type UserRepository interface {
 	FindOrCreateByTelegramID(ctx context.Context, telegramID int64, username, firstName, lastName string) (*models.User, error)
 	ListAll(ctx context.Context) ([]*models.User, error)
 	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByTelegramID(ctx context.Context, telegramID int64) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	SetAdmin(ctx context.Context, id uint, isAdmin bool) error
+	// ChangeAdmin applies and records a permission change made from chat. It
+	// fails with ErrLastAdmin if the change would leave no admins.
+	ChangeAdmin(ctx context.Context, change *models.PermissionChange) error
+	SetLanguage(ctx context.Context, id uint, language string) error
+	SetNotificationPrefs(ctx context.Context, id uint, prefs models.NotificationPrefs) error
+}
+
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]*models.FeatureFlag, error)
+	Set(ctx context.Context, name string, enabled bool) error
 }
 
 type ExecutorClient interface {
-	ExecuteAction(req models.ActionRequest) models.ActionResult
-	GetResourceDetails(req models.ResourceDetailsRequest) (*models.ResourceDetails, error)
-	GetAvailableResources() (*models.AvailableResources, error)
+	ExecuteAction(ctx context.Context, req models.ActionRequest) models.ActionResult
+	GetResourceDetails(ctx context.Context, req models.ResourceDetailsRequest) (*models.ResourceDetails, error)
+	GetAvailableResources(ctx context.Context) (*models.AvailableResources, error)
+	// Ping checks that the executor is reachable and healthy.
+	Ping(ctx context.Context) error
 }
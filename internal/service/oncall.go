@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OnCallRotation struct {
+	users []string
+	shift time.Duration
+	start time.Time
+}
+
+func NewOnCallRotation(cfg config.OnCallConfig) (*OnCallRotation, error) {
+	rotation := &OnCallRotation{
+		users: cfg.Rotation,
+		shift: time.Duration(cfg.ShiftHours) * time.Hour,
+	}
+	if rotation.shift <= 0 {
+		rotation.shift = 7 * 24 * time.Hour
+	}
+	if cfg.Start != "" {
+		start, err := time.Parse(time.RFC3339, cfg.Start)
+		if err != nil {
+			return nil, err
+		}
+		rotation.start = start
+	}
+	return rotation, nil
+}
+
+func (r *OnCallRotation) Configured() bool {
+	return len(r.users) > 0
+}
+
+// Current returns the on-call username at t and the end of their shift.
+func (r *OnCallRotation) Current(t time.Time) (string, time.Time) {
+	if !r.Configured() {
+		return "", time.Time{}
+	}
+	elapsed := t.Sub(r.start)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	shiftIndex := int64(elapsed / r.shift)
+	user := r.users[shiftIndex%int64(len(r.users))]
+	shiftEnd := r.start.Add(time.Duration(shiftIndex+1) * r.shift)
+	return user, shiftEnd
+}
+
+// OnCallLoad is the "are we keeping up" view of the /oncall command.
+type OnCallLoad struct {
+	// User is the current on-call username without "@"; empty without a rotation.
+	User     string
+	ShiftEnd time.Time
+	// Assigned counts the active incidents assigned to User.
+	Assigned int
+	Active   int
+	// Oldest is the longest-running unacknowledged active incident, if any.
+	Oldest *models.Incident
+	// OldestAge is how long Oldest has been open at the time of the query.
+	OldestAge time.Duration
+}
+
+// OnCallLoad reports who is on call according to rotation (which may be nil) and
+// how many active incidents they and the team have.
+func (s *IncidentService) OnCallLoad(ctx context.Context, rotation *OnCallRotation) (OnCallLoad, error) {
+	now := s.clock.Now()
+	incidents, err := s.repo.ListActive(ctx)
+	if err != nil {
+		return OnCallLoad{}, err
+	}
+	load := OnCallLoad{Active: len(incidents)}
+
+	var onCallID uint
+	if rotation != nil && rotation.Configured() {
+		user, shiftEnd := rotation.Current(now)
+		load.User, load.ShiftEnd = strings.TrimPrefix(user, "@"), shiftEnd
+		onCallUser, err := s.userRepo.FindByUsername(ctx, load.User)
+		switch {
+		case err == nil:
+			onCallID = onCallUser.ID
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return OnCallLoad{}, err
+		}
+	}
+
+	for _, incident := range incidents {
+		if onCallID != 0 && incident.AssignedTo != nil && *incident.AssignedTo == onCallID {
+			load.Assigned++
+		}
+		if isAcknowledged(incident) {
+			continue
+		}
+		if load.Oldest == nil || incident.StartsAt.Before(load.Oldest.StartsAt) {
+			load.Oldest = incident
+		}
+	}
+	if load.Oldest != nil {
+		load.OldestAge = now.Sub(load.Oldest.StartsAt)
+	}
+	return load, nil
+}
@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/service"
+)
+
+func TestOnCallLoad(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	onCall := env.user(t, 1)
+	other := env.user(t, 2)
+
+	first := env.fire(t, "fp-first", map[string]string{"alertname": "First"})
+	env.clock.Advance(10 * time.Minute)
+	second := env.fire(t, "fp-second", map[string]string{"alertname": "Second"})
+	env.clock.Advance(10 * time.Minute)
+	third := env.fire(t, "fp-third", map[string]string{"alertname": "Third"})
+	env.clock.Advance(10 * time.Minute)
+
+	for _, incident := range []uint{first.ID, second.ID} {
+		if _, err := env.svc.Assign(ctx, other.ID, incident, onCall.TelegramID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := env.svc.Assign(ctx, other.ID, third.ID, other.TelegramID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.Acknowledge(ctx, onCall.ID, first.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	rotation, err := service.NewOnCallRotation(config.OnCallConfig{
+		Rotation:   []string{"@" + onCall.Username, other.Username},
+		ShiftHours: 24,
+		Start:      testStart.Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load, err := env.svc.OnCallLoad(ctx, rotation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if load.User != onCall.Username {
+		t.Errorf("User = %q, want %q", load.User, onCall.Username)
+	}
+	if want := testStart.Add(24 * time.Hour); !load.ShiftEnd.Equal(want) {
+		t.Errorf("ShiftEnd = %s, want %s", load.ShiftEnd, want)
+	}
+	if load.Assigned != 2 || load.Active != 3 {
+		t.Errorf("Assigned, Active = %d, %d; want 2, 3", load.Assigned, load.Active)
+	}
+	if load.Oldest == nil || load.Oldest.ID != second.ID {
+		t.Fatalf("Oldest = %v, want incident %d", load.Oldest, second.ID)
+	}
+	if load.OldestAge != 20*time.Minute {
+		t.Errorf("OldestAge = %s, want 20m (from the service clock)", load.OldestAge)
+	}
+
+	t.Run("no rotation", func(t *testing.T) {
+		load, err := env.svc.OnCallLoad(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if load.User != "" || load.Assigned != 0 || load.Active != 3 {
+			t.Errorf("load = %+v, want no on-call user and 3 active", load)
+		}
+	})
+}
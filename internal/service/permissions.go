@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"chatops-bot/internal/models"
+)
+
+// SetUserAdmin grants or revokes the admin rights of target on behalf of the
+// actor and records the change. Revoking the rights of the only remaining admin
+// fails with ErrLastAdmin.
+func (s *IncidentService) SetUserAdmin(ctx context.Context, actorID uint, target *models.User, isAdmin bool) error {
+	change := &models.PermissionChange{
+		ActorID:   actorID,
+		UserID:    target.ID,
+		IsAdmin:   isAdmin,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.userRepo.ChangeAdmin(ctx, change); err != nil {
+		return err
+	}
+	target.IsAdmin = isAdmin
+	s.logger.InfoContext(ctx, "Admin rights changed", "user_id", target.ID, "user", target.Username, "is_admin", isAdmin, "actor_id", actorID)
+	return nil
+}
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"chatops-bot/internal/service"
+)
+
+func TestSetUserAdmin(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alice := env.user(t, 1)
+	bob := env.user(t, 2)
+	if err := env.users.SetAdmin(ctx, alice.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	isAdmin := func(id uint) bool {
+		t.Helper()
+		user, err := env.users.FindByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return user.IsAdmin
+	}
+
+	if err := env.svc.SetUserAdmin(ctx, alice.ID, bob, true); err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+	if !bob.IsAdmin || !isAdmin(bob.ID) {
+		t.Error("bob is not an admin after the grant")
+	}
+
+	if err := env.svc.SetUserAdmin(ctx, bob.ID, alice, false); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if alice.IsAdmin || isAdmin(alice.ID) {
+		t.Error("alice is still an admin after the revoke")
+	}
+
+	// bob is the only admin left, so his rights cannot be revoked.
+	err := env.svc.SetUserAdmin(ctx, bob.ID, bob, false)
+	if !errors.Is(err, service.ErrLastAdmin) {
+		t.Fatalf("revoke of the last admin error = %v, want %v", err, service.ErrLastAdmin)
+	}
+	if !bob.IsAdmin || !isAdmin(bob.ID) {
+		t.Error("the last admin lost the rights")
+	}
+}
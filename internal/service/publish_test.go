@@ -0,0 +1,80 @@
+package service
+
+import (
+	"expvar"
+	"runtime"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/testutil"
+)
+
+func droppedCount(kind string) int64 {
+	if v, ok := droppedUpdates.Get(kind).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+// TestPublishWithoutConsumerDoesNotLeak checks that messages for a channel nobody
+// reads are dropped after the retry timeout instead of parking a goroutine each
+// forever.
+func TestPublishWithoutConsumerDoesNotLeak(t *testing.T) {
+	defer func(timeout time.Duration) { publishRetryTimeout = timeout }(publishRetryTimeout)
+	publishRetryTimeout = 20 * time.Millisecond
+
+	s := &IncidentService{logger: testutil.DiscardLogger()}
+	ch := make(chan *models.Incident)
+	before := runtime.NumGoroutine()
+	dropped := droppedCount("leak-test")
+
+	const messages = 10
+	start := time.Now()
+	for i := 0; i < messages; i++ {
+		s.publish(ch, &models.Incident{}, "leak-test")
+	}
+	if elapsed := time.Since(start); elapsed > publishRetryTimeout {
+		t.Errorf("publish blocked for %v", elapsed)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d, want %d once the retries give up", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := droppedCount("leak-test") - dropped; got != messages {
+		t.Errorf("dropped = %d, want %d", got, messages)
+	}
+}
+
+func TestPublishDeliversToLateConsumer(t *testing.T) {
+	s := &IncidentService{logger: testutil.DiscardLogger()}
+	ch := make(chan *models.Incident)
+	incident := &models.Incident{}
+	incident.ID = 7
+
+	s.publish(ch, incident, "late-test")
+	select {
+	case got := <-ch:
+		if got.ID != 7 {
+			t.Errorf("received incident %d, want 7", got.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered to a consumer that started late")
+	}
+	if got := droppedCount("late-test"); got != 0 {
+		t.Errorf("dropped = %d, want 0", got)
+	}
+}
+
+func TestPublishToNilChannel(t *testing.T) {
+	s := &IncidentService{logger: testutil.DiscardLogger()}
+	before := runtime.NumGoroutine()
+	s.publish(nil, &models.Incident{}, "nil-test")
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines = %d after publishing to a nil channel, want %d", after, before)
+	}
+}
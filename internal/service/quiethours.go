@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+// quietHours is a daily window during which incidents of severities outside
+// alwaysNotify are not announced. start and end are minutes since midnight in loc.
+type quietHours struct {
+	start, end   int
+	loc          *time.Location
+	alwaysNotify map[string]bool
+}
+
+// SetQuietHours holds back announcements of new and reopened incidents during
+// the configured daily window, unless their severity is listed in
+// cfg.AlwaysNotify (highSeverities when empty, then
+// config.DefaultHighSeverityValues). Incidents without a severity
+// are always announced. Held incidents are announced by ReleaseHeldNotifications
+// once the window is over. An empty window disables quiet hours. Call it before
+// serving requests.
+func (s *IncidentService) SetQuietHours(cfg config.QuietHoursConfig, highSeverities []string) error {
+	if cfg.Start == "" && cfg.End == "" {
+		s.quietHours = nil
+		return nil
+	}
+	start, err := time.Parse("15:04", cfg.Start)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours start %q: %w", cfg.Start, err)
+	}
+	end, err := time.Parse("15:04", cfg.End)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours end %q: %w", cfg.End, err)
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours timezone: %w", err)
+	}
+
+	severities := cfg.AlwaysNotify
+	if len(severities) == 0 {
+		severities = highSeverities
+	}
+	if len(severities) == 0 {
+		severities = config.DefaultHighSeverityValues
+	}
+	alwaysNotify := make(map[string]bool, len(severities))
+	for _, severity := range severities {
+		alwaysNotify[strings.ToLower(severity)] = true
+	}
+	s.quietHours = &quietHours{
+		start:        start.Hour()*60 + start.Minute(),
+		end:          end.Hour()*60 + end.Minute(),
+		loc:          loc,
+		alwaysNotify: alwaysNotify,
+	}
+	return nil
+}
+
+// active reports whether now falls into the window. A nil window is never active.
+func (q *quietHours) active(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+	local := now.In(q.loc)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// holdsNotification reports whether the incident should not be announced now
+// because of quiet hours.
+func (s *IncidentService) holdsNotification(incident *models.Incident) bool {
+	if !s.quietHours.active(s.clock.Now()) {
+		return false
+	}
+	severity, ok := incident.Labels[s.severityLabel]
+	return ok && !s.quietHours.alwaysNotify[strings.ToLower(severity)]
+}
+
+// announce hands a new or reopened incident to the bot, unless quiet hours hold
+// its notification back.
+func (s *IncidentService) announce(ctx context.Context, incident *models.Incident) {
+	if incident.NotificationPending {
+		s.logger.InfoContext(ctx, "Quiet hours, holding back incident notification", "incident_id", incident.ID)
+		return
+	}
+	s.publish(s.notificationChan, incident, "notification")
+}
+
+// ReleaseHeldNotifications announces the active incidents held back by quiet
+// hours once the window is over.
+func (s *IncidentService) ReleaseHeldNotifications(ctx context.Context) {
+	if s.quietHours == nil || s.quietHours.active(s.clock.Now()) {
+		return
+	}
+	incidents, err := s.repo.FindNotificationPending(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to find incidents with held notifications", "error", err)
+		return
+	}
+
+	for _, incident := range incidents {
+		if err := s.repo.ClearNotificationPending(ctx, incident.ID); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to release held notification", "incident_id", incident.ID, "error", err)
+			continue
+		}
+		incident.NotificationPending = false
+		s.logger.InfoContext(ctx, "Quiet hours are over, announcing incident", "incident_id", incident.ID)
+		s.publish(s.notificationChan, incident, "notification")
+	}
+}
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+func setQuietHours(t *testing.T, env *testEnv, start, end string) {
+	t.Helper()
+	env.svc.SetMinSeverity("severity", "", nil)
+	err := env.svc.SetQuietHours(config.QuietHoursConfig{Start: start, End: end, Timezone: "Europe/Moscow"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuietHoursWindow(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("timezone data unavailable:", err)
+	}
+	tests := []struct {
+		start, end string
+		at         string
+		held       bool
+	}{
+		{"22:00", "08:00", "21:59", false},
+		{"22:00", "08:00", "22:00", true},
+		{"22:00", "08:00", "03:00", true},
+		{"22:00", "08:00", "07:59", true},
+		{"22:00", "08:00", "08:00", false},
+		{"13:00", "14:00", "12:59", false},
+		{"13:00", "14:00", "13:30", true},
+		{"13:00", "14:00", "14:00", false},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%s@%s", tt.start, tt.end, tt.at), func(t *testing.T) {
+			env := newTestEnv(t)
+			setQuietHours(t, env, tt.start, tt.end)
+			clock, _ := time.ParseInLocation("15:04", tt.at, moscow)
+			env.clock.Set(time.Date(2024, 5, 1, clock.Hour(), clock.Minute(), 0, 0, moscow))
+
+			incident := env.fire(t, fmt.Sprintf("fp-%d", i), map[string]string{"alertname": "Slow", "severity": "warning"})
+			if incident.NotificationPending != tt.held {
+				t.Fatalf("held = %v, want %v", incident.NotificationPending, tt.held)
+			}
+			if sent := len(drain(env.notifications)); (sent == 0) != tt.held {
+				t.Fatalf("notifications sent = %d, held = %v", sent, tt.held)
+			}
+		})
+	}
+}
+
+func TestQuietHoursAlwaysNotifyHighSeverity(t *testing.T) {
+	env := newTestEnv(t)
+	setQuietHours(t, env, "00:00", "23:59")
+	env.clock.Set(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	critical := env.fire(t, "critical", map[string]string{"alertname": "Down", "severity": "critical"})
+	unlabelled := env.fire(t, "unlabelled", map[string]string{"alertname": "Unknown"})
+	low := env.fire(t, "low", map[string]string{"alertname": "Slow", "severity": "warning"})
+
+	if critical.NotificationPending || unlabelled.NotificationPending || !low.NotificationPending {
+		t.Fatalf("pending: critical=%v unlabelled=%v low=%v", critical.NotificationPending, unlabelled.NotificationPending, low.NotificationPending)
+	}
+	if sent := drain(env.notifications); len(sent) != 2 {
+		t.Fatalf("notifications = %v, want critical and unlabelled", sent)
+	}
+}
+
+func TestQuietHoursReleaseAfterWindow(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	setQuietHours(t, env, "22:00", "08:00")
+	labels := map[string]string{"alertname": "Slow", "severity": "warning"}
+
+	// Announced during the day, resolved, then re-fired at night.
+	env.clock.Set(time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC))
+	reopened := env.fire(t, "reopened", labels)
+	if err := env.svc.SetTelegramMessageID(ctx, reopened.ID, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.UpdateStatus(ctx, env.user(t, 1).ID, reopened.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+	env.clock.Set(time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)) // 23:00 in Moscow
+	env.fire(t, "reopened", labels)
+	created := env.fire(t, "created", labels)
+	drain(env.notifications)
+
+	// Held incidents are neither reminded about nor released inside the window.
+	env.clock.Advance(2 * time.Hour)
+	env.svc.RemindUnacknowledgedIncidents(ctx, time.Hour)
+	if sent := drain(env.reminders); len(sent) != 0 {
+		t.Fatalf("reminded about held incidents: %v", sent)
+	}
+	env.svc.ReleaseHeldNotifications(ctx)
+	if sent := drain(env.notifications); len(sent) != 0 {
+		t.Fatalf("released inside the window: %v", sent)
+	}
+
+	env.clock.Set(time.Date(2024, 5, 2, 5, 0, 0, 0, time.UTC)) // 08:00 in Moscow
+	env.svc.ReleaseHeldNotifications(ctx)
+	released := map[uint]*models.Incident{}
+	for len(env.notifications) > 0 {
+		incident := <-env.notifications
+		released[incident.ID] = incident
+	}
+	if len(released) != 2 || released[reopened.ID] == nil || released[created.ID] == nil {
+		t.Fatalf("released = %v, want both held incidents", released)
+	}
+	if action := released[reopened.ID].LastAuditAction(); action != "reopen" {
+		t.Fatalf("released reopened incident's last action = %q, want reopen", action)
+	}
+
+	env.svc.ReleaseHeldNotifications(ctx)
+	if sent := drain(env.notifications); len(sent) != 0 {
+		t.Fatalf("released twice: %v", sent)
+	}
+	stored, err := env.svc.GetIncidentByID(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.NotificationPending {
+		t.Fatal("incident still pending after release")
+	}
+}
+
+// TestManualReopenRespectsQuietHours checks that an incident reopened by hand at
+// night is held back like one reopened by a re-fired alert.
+func TestManualReopenRespectsQuietHours(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	setQuietHours(t, env, "22:00", "08:00")
+	user := env.user(t, 1)
+
+	env.clock.Set(time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC))
+	incident := env.fire(t, "manual", map[string]string{"alertname": "Slow", "severity": "warning"})
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+	drain(env.notifications)
+
+	env.clock.Set(time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)) // 23:00 in Moscow
+	reopened, err := env.svc.Reopen(ctx, user.ID, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.NotificationPending {
+		t.Error("notification of an incident reopened in quiet hours is not held")
+	}
+	if sent := drain(env.notifications); len(sent) != 0 {
+		t.Fatalf("announced inside the window: %v", sent)
+	}
+
+	env.clock.Set(time.Date(2024, 5, 2, 5, 0, 0, 0, time.UTC)) // 08:00 in Moscow
+	env.svc.ReleaseHeldNotifications(ctx)
+	if sent := drain(env.notifications); len(sent) != 1 || sent[0] != incident.ID {
+		t.Errorf("released = %v, want [%d]", sent, incident.ID)
+	}
+}
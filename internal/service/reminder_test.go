@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+func TestRemindersCountFromLastReminderAndReopen(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	const interval = 30 * time.Minute
+	labels := map[string]string{"alertname": "Down"}
+	incident := env.fire(t, "fp", labels)
+	if err := env.svc.SetTelegramMessageID(ctx, incident.ID, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	remind := func() int {
+		env.svc.RemindUnacknowledgedIncidents(ctx, interval)
+		return len(drain(env.reminders))
+	}
+
+	steps := []struct {
+		advance time.Duration
+		want    int
+	}{
+		{29 * time.Minute, 0},
+		{1 * time.Minute, 1},
+		{20 * time.Minute, 0},
+		{10 * time.Minute, 1},
+		{10 * time.Minute, 0},
+	}
+	for i, step := range steps {
+		env.clock.Advance(step.advance)
+		if got := remind(); got != step.want {
+			t.Fatalf("step %d: reminders = %d, want %d", i, got, step.want)
+		}
+	}
+
+	// A reopened incident is not reminded about right away.
+	if err := env.svc.UpdateStatus(ctx, env.user(t, 1).ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+	env.clock.Advance(24 * time.Hour)
+	env.fire(t, "fp", labels)
+	if got := remind(); got != 0 {
+		t.Fatalf("reopened incident reminded immediately")
+	}
+	env.clock.Advance(interval)
+	if got := remind(); got != 1 {
+		t.Fatalf("reminders after reopen = %d, want 1", got)
+	}
+}
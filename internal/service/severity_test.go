@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestSetSeverity(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-sev", map[string]string{"alertname": "X", "severity": "warning"})
+	drain(env.updates)
+
+	got, previous, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, "critical")
+	if err != nil {
+		t.Fatalf("SetSeverity: %v", err)
+	}
+	if previous != "warning" {
+		t.Errorf("previous = %q, want warning", previous)
+	}
+	if got.Labels["severity"] != "critical" {
+		t.Errorf("severity = %q, want critical", got.Labels["severity"])
+	}
+	if ids := drain(env.updates); len(ids) != 1 || ids[0] != incident.ID {
+		t.Errorf("updates = %v, want [%d]", ids, incident.ID)
+	}
+	entry := got.AuditLog[len(got.AuditLog)-1]
+	if entry.Action != "set_severity" || entry.UserID != user.ID {
+		t.Errorf("audit = %s by %d, want set_severity by %d", entry.Action, entry.UserID, user.ID)
+	}
+	if entry.Parameters["previous"] != "warning" || entry.Parameters["severity"] != "critical" {
+		t.Errorf("audit parameters = %v", entry.Parameters)
+	}
+
+	// Setting the current value changes nothing.
+	if _, previous, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, "critical"); err != nil || previous != "critical" {
+		t.Fatalf("repeat SetSeverity = %q, %v", previous, err)
+	}
+	if ids := drain(env.updates); len(ids) != 0 {
+		t.Errorf("repeat published updates %v", ids)
+	}
+	after, err := env.svc.GetAuditLog(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(got.AuditLog) {
+		t.Errorf("repeat added %d audit records", len(after)-len(got.AuditLog))
+	}
+
+	if _, _, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, ""); !errors.Is(err, service.ErrEmptySeverity) {
+		t.Errorf("empty severity error = %v, want %v", err, service.ErrEmptySeverity)
+	}
+}
+
+func TestSetSeverityUsesConfiguredLabel(t *testing.T) {
+	env := newTestEnv(t)
+	env.svc.SetMinSeverity("priority", "", nil)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-prio", map[string]string{"alertname": "X", "priority": "P3", "severity": "warning"})
+
+	got, previous, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, "P1")
+	if err != nil {
+		t.Fatalf("SetSeverity: %v", err)
+	}
+	if previous != "P3" || got.Labels["priority"] != "P1" {
+		t.Errorf("priority = %q (previous %q), want P1 (previous P3)", got.Labels["priority"], previous)
+	}
+	if got.Labels["severity"] != "warning" {
+		t.Errorf("severity = %q, want it left at warning", got.Labels["severity"])
+	}
+}
+
+func TestSetSeverityOnClosedIncident(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-closed", map[string]string{"alertname": "X", "severity": "warning"})
+	if _, err := env.svc.BulkResolve(ctx, user.ID, map[string]string{"alertname": "X"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, "critical"); !errors.Is(err, service.ErrIncidentNotActive) {
+		t.Fatalf("error = %v, want %v", err, service.ErrIncidentNotActive)
+	}
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusResolved || got.Labels["severity"] != "warning" {
+		t.Errorf("incident = %s/%s, want resolved with severity warning", got.Status, got.Labels["severity"])
+	}
+}
+
+func TestMinSeverityFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		min      string
+		order    []string
+		labels   map[string]string
+		filtered bool
+	}{
+		{name: "below the default order", min: "high", labels: map[string]string{"severity": "warning"}, filtered: true},
+		{name: "at the minimum", min: "high", labels: map[string]string{"severity": "high"}},
+		{name: "above the minimum", min: "high", labels: map[string]string{"severity": "critical"}},
+		{name: "case-insensitive", min: "HIGH", labels: map[string]string{"severity": "Critical"}},
+		{name: "no severity label", min: "high", labels: map[string]string{}},
+		{name: "value outside the order", min: "warning", labels: map[string]string{"severity": "info"}, filtered: true},
+		{name: "filter disabled", labels: map[string]string{"severity": "info"}},
+		{
+			name:     "custom order",
+			min:      "p2",
+			order:    []string{"p3", "p2", "p1"},
+			labels:   map[string]string{"severity": "p3"},
+			filtered: true,
+		},
+		{name: "custom order accepts", min: "p2", order: []string{"p3", "p2", "p1"}, labels: map[string]string{"severity": "p1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.svc.SetMinSeverity("severity", tt.min, tt.order)
+			tt.labels["alertname"] = "X"
+
+			incident, err := env.svc.CreateIncidentFromAlert(context.Background(), models.Alert{Status: "firing", Fingerprint: "fp-min", Labels: tt.labels})
+			if tt.filtered {
+				if !errors.Is(err, service.ErrBelowMinSeverity) {
+					t.Fatalf("error = %v, want %v", err, service.ErrBelowMinSeverity)
+				}
+				if ids := drain(env.notifications); len(ids) != 0 {
+					t.Errorf("filtered alert notified %v", ids)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateIncidentFromAlert: %v", err)
+			}
+			if incident == nil || incident.Status != models.StatusActive {
+				t.Errorf("incident = %+v, want an active incident", incident)
+			}
+		})
+	}
+}
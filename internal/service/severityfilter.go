@@ -0,0 +1,57 @@
+package service
+
+import (
+	"strings"
+
+	"chatops-bot/internal/config"
+	"chatops-bot/internal/models"
+)
+
+// severityFilter drops alerts whose severity ranks below a minimum.
+type severityFilter struct {
+	label string
+	rank  map[string]int
+	min   int
+}
+
+// SetMinSeverity makes CreateIncidentFromAlert skip alerts whose severity label
+// ranks below minSeverity in order (least severe first; defaults to warning < high
+// < critical). Alerts without the label are let through; values missing from the
+// order rank below all others. An empty minSeverity disables the filter. Call it
+// before serving requests. The label is also used for the severity column of
+// exports and by SetSeverity.
+func (s *IncidentService) SetMinSeverity(severityLabel, minSeverity string, order []string) {
+	if severityLabel == "" {
+		severityLabel = "severity"
+	}
+	s.severityLabel = severityLabel
+	if minSeverity == "" {
+		s.severityFilter = nil
+		return
+	}
+	if len(order) == 0 {
+		order = config.DefaultSeverityOrder
+	}
+	rank := make(map[string]int, len(order))
+	for i, severity := range order {
+		rank[strings.ToLower(severity)] = i + 1
+	}
+	s.severityFilter = &severityFilter{
+		label: severityLabel,
+		rank:  rank,
+		min:   rank[strings.ToLower(minSeverity)],
+	}
+}
+
+// allows reports whether an alert with these labels is severe enough. A nil
+// filter allows everything.
+func (f *severityFilter) allows(labels models.Labels) bool {
+	if f == nil {
+		return true
+	}
+	severity, ok := labels[f.label]
+	if !ok {
+		return true
+	}
+	return f.rank[strings.ToLower(severity)] >= f.min
+}
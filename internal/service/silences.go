@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+var (
+	ErrSilenced        = errors.New("alert matches an active silence")
+	ErrInvalidSilence  = errors.New("silence needs at least one matcher and an end in the future")
+	ErrSilenceNotFound = errors.New("silence not found")
+)
+
+// AddSilence suppresses incident creation for alerts whose labels match all of
+// matchers until the given time.
+func (s *IncidentService) AddSilence(ctx context.Context, userID uint, matchers map[string]string, until time.Time) (*models.Silence, error) {
+	now := s.clock.Now()
+	if len(matchers) == 0 || !until.After(now) {
+		return nil, ErrInvalidSilence
+	}
+	for name, value := range matchers {
+		if name == "" || value == "" {
+			return nil, ErrInvalidSilence
+		}
+	}
+	silence := &models.Silence{
+		Matchers:  models.JSONBMap(matchers),
+		CreatedBy: userID,
+		EndsAt:    until,
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateSilence(ctx, silence); err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Silence added", "silence_id", silence.ID, "matchers", matchers, "ends_at", until, "user_id", userID)
+	return silence, nil
+}
+
+// RemoveSilence deletes a silence before it ends.
+func (s *IncidentService) RemoveSilence(ctx context.Context, userID, silenceID uint) error {
+	deleted, err := s.repo.DeleteSilence(ctx, silenceID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSilenceNotFound
+	}
+	s.logger.InfoContext(ctx, "Silence removed", "silence_id", silenceID, "user_id", userID)
+	return nil
+}
+
+// ListActiveSilences returns the silences that have not ended yet, ending soonest
+// first. Ended silences found on the way are deleted.
+func (s *IncidentService) ListActiveSilences(ctx context.Context) ([]*models.Silence, error) {
+	silences, err := s.repo.ListSilences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	active := silences[:0]
+	expired := false
+	for _, silence := range silences {
+		if silence.EndsAt.After(now) {
+			active = append(active, silence)
+		} else {
+			expired = true
+		}
+	}
+	if expired {
+		s.ExpireSilences(ctx)
+	}
+	return active, nil
+}
+
+// ExpireSilences deletes the silences that have ended.
+func (s *IncidentService) ExpireSilences(ctx context.Context) {
+	deleted, err := s.repo.DeleteSilencesEndedBefore(ctx, s.clock.Now())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete expired silences", "error", err)
+		return
+	}
+	if deleted > 0 {
+		s.logger.InfoContext(ctx, "Expired silences deleted", "count", deleted)
+	}
+}
+
+// matchingSilence returns the active silence matching the labels, if any.
+func (s *IncidentService) matchingSilence(ctx context.Context, labels map[string]string) (*models.Silence, error) {
+	silences, err := s.ListActiveSilences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, silence := range silences {
+		if silence.Matches(labels) {
+			return silence, nil
+		}
+	}
+	return nil, nil
+}
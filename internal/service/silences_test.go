@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestSilenceSuppressesMatchingAlerts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+
+	silence, err := env.svc.AddSilence(ctx, user.ID, map[string]string{"alertname": "HighCPU", "namespace": "staging"}, env.clock.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fingerprint string
+		labels      map[string]string
+		wantErr     error
+	}{
+		{"silenced", map[string]string{"alertname": "HighCPU", "namespace": "staging", "pod": "api-0"}, service.ErrSilenced},
+		{"other namespace", map[string]string{"alertname": "HighCPU", "namespace": "prod"}, nil},
+		{"other alert", map[string]string{"alertname": "DiskFull", "namespace": "staging"}, nil},
+	}
+	for _, tt := range tests {
+		_, err := env.svc.CreateIncidentFromAlert(ctx, models.Alert{Status: "firing", Fingerprint: tt.fingerprint, Labels: tt.labels, StartsAt: env.clock.Now()})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.fingerprint, err, tt.wantErr)
+		}
+	}
+	if sent := drain(env.notifications); len(sent) != 2 {
+		t.Errorf("notifications = %v, want the two unsilenced incidents", sent)
+	}
+
+	// Once removed, the silence no longer applies.
+	if err := env.svc.RemoveSilence(ctx, user.ID, silence.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.RemoveSilence(ctx, user.ID, silence.ID); !errors.Is(err, service.ErrSilenceNotFound) {
+		t.Errorf("second RemoveSilence error = %v, want %v", err, service.ErrSilenceNotFound)
+	}
+	if _, err := env.svc.CreateIncidentFromAlert(ctx, models.Alert{Status: "firing", Fingerprint: "silenced", Labels: tests[0].labels, StartsAt: env.clock.Now()}); err != nil {
+		t.Errorf("alert after RemoveSilence: %v", err)
+	}
+}
+
+func TestSilenceExpires(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	labels := map[string]string{"alertname": "Flapping"}
+
+	short, err := env.svc.AddSilence(ctx, user.ID, labels, env.clock.Now().Add(30*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	long, err := env.svc.AddSilence(ctx, user.ID, map[string]string{"alertname": "Other"}, env.clock.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.clock.Advance(29 * time.Minute)
+	if _, err := env.svc.CreateIncidentFromAlert(ctx, models.Alert{Status: "firing", Fingerprint: "flap", Labels: labels, StartsAt: env.clock.Now()}); !errors.Is(err, service.ErrSilenced) {
+		t.Fatalf("alert before the silence ends: error = %v, want %v", err, service.ErrSilenced)
+	}
+
+	env.clock.Advance(time.Minute)
+	active, err := env.svc.ListActiveSilences(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0].ID != long.ID {
+		t.Errorf("active silences = %v, want only %d", active, long.ID)
+	}
+	// The ended silence was deleted on the way.
+	if err := env.svc.RemoveSilence(ctx, user.ID, short.ID); !errors.Is(err, service.ErrSilenceNotFound) {
+		t.Errorf("RemoveSilence of an expired silence error = %v, want %v", err, service.ErrSilenceNotFound)
+	}
+	if _, err := env.svc.CreateIncidentFromAlert(ctx, models.Alert{Status: "firing", Fingerprint: "flap", Labels: labels, StartsAt: env.clock.Now()}); err != nil {
+		t.Errorf("alert after the silence ended: %v", err)
+	}
+}
+
+func TestAddSilenceValidation(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	future := env.clock.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		matchers map[string]string
+		until    time.Time
+	}{
+		{"no matchers", nil, future},
+		{"empty value", map[string]string{"alertname": ""}, future},
+		{"empty name", map[string]string{"": "HighCPU"}, future},
+		{"ends now", map[string]string{"alertname": "HighCPU"}, env.clock.Now()},
+		{"ended", map[string]string{"alertname": "HighCPU"}, env.clock.Now().Add(-time.Minute)},
+	}
+	for _, tt := range tests {
+		if _, err := env.svc.AddSilence(ctx, user.ID, tt.matchers, tt.until); !errors.Is(err, service.ErrInvalidSilence) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, service.ErrInvalidSilence)
+		}
+	}
+}
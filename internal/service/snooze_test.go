@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNotifyExpiredSnoozes(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		expired bool
+	}{
+		{"still snoozed", 30 * time.Minute, false},
+		{"snooze lapsed", time.Hour + time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			user := env.user(t, 1)
+			incident := env.fire(t, "fp-snooze", map[string]string{"alertname": "Snooze"})
+			if _, err := env.svc.Snooze(ctx, user.ID, incident.ID, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+			drain(env.notifications)
+			drain(env.updates)
+
+			env.clock.Advance(tt.elapsed)
+			env.svc.NotifyExpiredSnoozes(ctx)
+
+			snoozes := drain(env.snoozes)
+			if got := slices.Contains(snoozes, incident.ID); got != tt.expired {
+				t.Errorf("snooze expiry published = %v, want %v", got, tt.expired)
+			}
+			// Expiry is a distinct event: it must not look like a new incident.
+			if notified := drain(env.notifications); len(notified) != 0 {
+				t.Errorf("notification channel got %v, want nothing", notified)
+			}
+
+			got, err := env.repo.FindByID(ctx, incident.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cleared := got.SnoozedUntil == nil; cleared != tt.expired {
+				t.Errorf("snooze cleared = %v, want %v", cleared, tt.expired)
+			}
+		})
+	}
+}
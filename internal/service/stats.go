@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"chatops-bot/internal/models"
+)
+
+// IncidentStats summarizes the incidents that started within a time window.
+type IncidentStats struct {
+	Since    time.Time
+	Created  int64
+	Active   int64
+	Resolved int64
+	Rejected int64
+	// MeanTimeToResolve is averaged over ResolvedWithDuration incidents, i.e. the
+	// resolved ones that have an end time.
+	MeanTimeToResolve    time.Duration
+	ResolvedWithDuration int
+	// BySeverity counts incidents per value of the severity label; "" holds the
+	// incidents without one.
+	BySeverity map[string]int64
+}
+
+func (s *IncidentService) GetStats(ctx context.Context, window time.Duration, severityLabel string) (*IncidentStats, error) {
+	since := s.clock.Now().Add(-window)
+	stats := &IncidentStats{Since: since}
+
+	counts, err := s.repo.CountByStatusSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	for status, count := range counts {
+		stats.Created += count
+		switch status {
+		case models.StatusActive:
+			stats.Active = count
+		case models.StatusResolved:
+			stats.Resolved = count
+		case models.StatusRejected:
+			stats.Rejected = count
+		}
+	}
+
+	durations, err := s.repo.ResolveDurations(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	stats.MeanTimeToResolve = meanDuration(durations)
+	stats.ResolvedWithDuration = len(durations)
+
+	stats.BySeverity, err = s.repo.CountByLabelSince(ctx, severityLabel, since)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func meanDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
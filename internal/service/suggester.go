@@ -1,49 +1,43 @@
 package service
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 
 	"chatops-bot/internal/models"
 )
 
-type ActionSuggester struct{}
+type ActionSuggester struct {
+	rules *RuleSet
+}
 
-func NewActionSuggester() *ActionSuggester {
-	return &ActionSuggester{}
+func NewActionSuggester(rules *RuleSet) *ActionSuggester {
+	if rules == nil {
+		rules = DefaultRuleSet()
+	}
+	return &ActionSuggester{rules: rules}
 }
 
 func (s *ActionSuggester) SuggestActions(incident *models.Incident) []models.SuggestedAction {
 	var suggestions []models.SuggestedAction
 
-	if alertName, ok := incident.Labels["alertname"]; ok && alertName == "KubeDeploymentReplicasMismatch" {
-		if deploymentName, ok := incident.AffectedResources["deployment"]; ok {
-			params := map[string]string{
-				"deployment": incident.AffectedResources["deployment"],
-				"namespace":  incident.AffectedResources["namespace"],
-			}
-			suggestions = append(suggestions, models.SuggestedAction{
-				HumanReadable: fmt.Sprintf("⏪ Откатить %s", deploymentName),
-				Action:        string(models.ActionRollbackDeployment),
-				Parameters:    params,
-			})
+	data := ruleTemplateData{Labels: incident.Labels, Resources: incident.AffectedResources}
+	for _, rule := range s.rules.Rules {
+		if !rule.matches(incident) {
+			continue
 		}
-	}
-
-	if alertName, ok := incident.Labels["alertname"]; ok && alertName == "KubePodCrashLooping" {
-		if podName, ok := incident.AffectedResources["pod"]; ok {
-			suggestions = append(suggestions, models.SuggestedAction{
-				HumanReadable: fmt.Sprintf("📄 Логи пода %s", podName),
-				Action:        string(models.ActionGetPodLogs),
-				Parameters: map[string]string{
-					"pod":       incident.AffectedResources["pod"],
-					"namespace": incident.AffectedResources["namespace"],
-				},
-			})
+		for _, action := range rule.Actions {
+			suggestion, err := action.render(data)
+			if err != nil {
+				slog.Error("Failed to render suggestion", "action", action.Action, "incident_id", incident.ID, "error", err)
+				continue
+			}
+			if suggestion != nil {
+				suggestions = append(suggestions, *suggestion)
+			}
 		}
 	}
 
-	log.Printf("Generated %d suggestions for incident %d", len(suggestions), incident.ID)
+	slog.Debug("Generated suggestions", "count", len(suggestions), "incident_id", incident.ID)
 	return suggestions
 }
 
@@ -55,6 +49,11 @@ func (s *ActionSuggester) SuggestActionsForResource(incident *models.Incident, r
 	case "deployment":
 		params := map[string]string{"deployment": resourceName, "namespace": namespace}
 		suggestions = append(suggestions,
+			models.SuggestedAction{
+				HumanReadable: "🔄 Перезапустить",
+				Action:        string(models.ActionRestartDeployment),
+				Parameters:    params,
+			},
 			models.SuggestedAction{
 				HumanReadable: "📦 Список подов",
 				Action:        string(models.ActionListPodsForDeployment),
@@ -70,6 +69,25 @@ func (s *ActionSuggester) SuggestActionsForResource(incident *models.Incident, r
 				Parameters:    params,
 			},
 		)
+	case "node":
+		params := map[string]string{"node": resourceName}
+		suggestions = append(suggestions,
+			models.SuggestedAction{
+				HumanReadable: "🚧 Cordon",
+				Action:        string(models.ActionCordonNode),
+				Parameters:    params,
+			},
+			models.SuggestedAction{
+				HumanReadable: "✅ Uncordon",
+				Action:        string(models.ActionUncordonNode),
+				Parameters:    params,
+			},
+			models.SuggestedAction{
+				HumanReadable: "🚚 Drain",
+				Action:        string(models.ActionDrainNode),
+				Parameters:    params,
+			},
+		)
 	}
 
 	return suggestions
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"chatops-bot/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSet maps alerts to suggested actions. Label and parameter values are
+// text/template strings rendered against the incident's Labels and Resources.
+type RuleSet struct {
+	Rules []SuggestionRule `json:"rules" yaml:"rules"`
+}
+
+type SuggestionRule struct {
+	AlertNames []string          `json:"alertnames" yaml:"alertnames"`
+	Labels     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Actions    []RuleAction      `json:"actions" yaml:"actions"`
+}
+
+type RuleAction struct {
+	Label      string            `json:"label" yaml:"label"`
+	Action     string            `json:"action" yaml:"action"`
+	Parameters map[string]string `json:"parameters" yaml:"parameters"`
+	Requires   []string          `json:"requires,omitempty" yaml:"requires,omitempty"`
+	// RequiresLabels lists alert labels that must be present and non-empty.
+	RequiresLabels []string `json:"requires_labels,omitempty" yaml:"requires_labels,omitempty"`
+}
+
+type ruleTemplateData struct {
+	Labels    map[string]string
+	Resources map[string]string
+}
+
+// LoadRuleSet reads suggestion rules from path: YAML for .yaml and .yml files,
+// JSON otherwise.
+func LoadRuleSet(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules RuleSet
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &rules)
+	default:
+		err = json.Unmarshal(data, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode suggestion rules: %w", err)
+	}
+	for i, rule := range rules.Rules {
+		if len(rule.AlertNames) == 0 {
+			return nil, fmt.Errorf("suggestion rule %d: at least one alertname is required", i)
+		}
+		for _, action := range rule.Actions {
+			if _, err := parseRuleTemplate(action.Label); err != nil {
+				return nil, fmt.Errorf("suggestion rule %d: %w", i, err)
+			}
+			for _, value := range action.Parameters {
+				if _, err := parseRuleTemplate(value); err != nil {
+					return nil, fmt.Errorf("suggestion rule %d: %w", i, err)
+				}
+			}
+		}
+	}
+	return &rules, nil
+}
+
+func DefaultRuleSet() *RuleSet {
+	return &RuleSet{Rules: []SuggestionRule{
+		{
+			AlertNames: []string{"KubeDeploymentReplicasMismatch"},
+			Actions: []RuleAction{
+				{
+					Label:  "⏪ Откатить {{.Resources.deployment}}",
+					Action: string(models.ActionRollbackDeployment),
+					Parameters: map[string]string{
+						"deployment": "{{.Resources.deployment}}",
+						"namespace":  "{{.Resources.namespace}}",
+					},
+					Requires: []string{"deployment"},
+				},
+				{
+					Label:  "⏸ Приостановить выкатку {{.Resources.deployment}}",
+					Action: string(models.ActionPauseRollout),
+					Parameters: map[string]string{
+						"deployment": "{{.Resources.deployment}}",
+						"namespace":  "{{.Resources.namespace}}",
+					},
+					Requires: []string{"deployment"},
+				},
+			},
+		},
+		{
+			AlertNames: []string{"KubePodCrashLooping"},
+			Actions: []RuleAction{{
+				Label:  "📄 Логи пода {{.Resources.pod}}",
+				Action: string(models.ActionGetPodLogs),
+				Parameters: map[string]string{
+					"pod":       "{{.Resources.pod}}",
+					"namespace": "{{.Resources.namespace}}",
+				},
+				Requires: []string{"pod"},
+			}},
+		},
+		{
+			AlertNames: []string{"KubePodOOMKilled", "KubeContainerOOMKilled"},
+			Actions: []RuleAction{
+				{
+					Label:  "📖 Описать под",
+					Action: string(models.ActionDescribePod),
+					Parameters: map[string]string{
+						"pod_name":  "{{.Resources.pod}}",
+						"namespace": "{{.Resources.namespace}}",
+					},
+					Requires: []string{"pod"},
+				},
+				{
+					Label:  "📄 Логи (предыдущий)",
+					Action: string(models.ActionGetPodLogs),
+					Parameters: map[string]string{
+						"pod_name":  "{{.Resources.pod}}",
+						"namespace": "{{.Resources.namespace}}",
+						"container": "{{.Labels.container}}",
+						"tail":      "100",
+						"previous":  "true",
+					},
+					Requires: []string{"pod"},
+				},
+				{
+					Label:  "⚙️ Выделить память",
+					Action: string(models.ActionAllocateHardware),
+					Parameters: map[string]string{
+						"pod":       "{{.Resources.pod}}",
+						"namespace": "{{.Resources.namespace}}",
+						"container": "{{.Labels.container}}",
+					},
+					Requires:       []string{"pod"},
+					RequiresLabels: []string{"container"},
+				},
+			},
+		},
+		{
+			AlertNames: []string{"KubeMemoryOvercommit", "ContainerHighMemoryUsage"},
+			Actions: []RuleAction{{
+				Label:  "↔️ Масштабировать {{.Resources.deployment}}",
+				Action: string(models.ActionScaleDeployment),
+				Parameters: map[string]string{
+					"deployment": "{{.Resources.deployment}}",
+					"namespace":  "{{.Resources.namespace}}",
+				},
+				Requires: []string{"deployment"},
+			}},
+		},
+		{
+			AlertNames: []string{"KubeNodeNotReady", "KubeNodeUnreachable", "NodeDiskPressure", "KubeNodePressure"},
+			Actions: []RuleAction{{
+				Label:  "🚧 Cordon {{.Resources.node}}",
+				Action: string(models.ActionCordonNode),
+				Parameters: map[string]string{
+					"node": "{{.Resources.node}}",
+				},
+				Requires: []string{"node"},
+			}},
+		},
+	}}
+}
+
+func (r SuggestionRule) matches(incident *models.Incident) bool {
+	alertName := incident.Labels["alertname"]
+	matched := false
+	for _, name := range r.AlertNames {
+		if name == alertName {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	for key, value := range r.Labels {
+		if incident.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// render returns nil if the incident lacks one of the affected resources listed in
+// Requires or one of the labels listed in RequiresLabels.
+func (a RuleAction) render(data ruleTemplateData) (*models.SuggestedAction, error) {
+	for _, key := range a.Requires {
+		if _, ok := data.Resources[key]; !ok {
+			return nil, nil
+		}
+	}
+	for _, key := range a.RequiresLabels {
+		if data.Labels[key] == "" {
+			return nil, nil
+		}
+	}
+	label, err := renderRuleTemplate(a.Label, data)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]string, len(a.Parameters))
+	for key, value := range a.Parameters {
+		rendered, err := renderRuleTemplate(value, data)
+		if err != nil {
+			return nil, err
+		}
+		params[key] = rendered
+	}
+	return &models.SuggestedAction{HumanReadable: label, Action: a.Action, Parameters: params}, nil
+}
+
+func parseRuleTemplate(text string) (*template.Template, error) {
+	return template.New("rule").Option("missingkey=zero").Parse(text)
+}
+
+func renderRuleTemplate(text string, data ruleTemplateData) (string, error) {
+	tmpl, err := parseRuleTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
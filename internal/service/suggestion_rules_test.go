@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+const yamlRules = `
+rules:
+  - alertnames: [KubePodCrashLooping]
+    labels:
+      severity: critical
+    actions:
+      - label: "Logs of {{.Resources.pod}}"
+        action: get_pod_logs
+        parameters:
+          pod_name: "{{.Resources.pod}}"
+          namespace: "{{.Resources.namespace}}"
+          container: "{{.Labels.container}}"
+        requires: [pod]
+      - label: "Scale {{.Resources.deployment}}"
+        action: scale_deployment
+        parameters:
+          deployment: "{{.Resources.deployment}}"
+        requires: [deployment]
+`
+
+const jsonRules = `{"rules": [{
+	"alertnames": ["KubePodCrashLooping"],
+	"labels": {"severity": "critical"},
+	"actions": [
+		{"label": "Logs of {{.Resources.pod}}", "action": "get_pod_logs",
+		 "parameters": {"pod_name": "{{.Resources.pod}}", "namespace": "{{.Resources.namespace}}", "container": "{{.Labels.container}}"},
+		 "requires": ["pod"]},
+		{"label": "Scale {{.Resources.deployment}}", "action": "scale_deployment",
+		 "parameters": {"deployment": "{{.Resources.deployment}}"},
+		 "requires": ["deployment"]}
+	]
+}]}`
+
+func writeRules(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRuleSetFormats(t *testing.T) {
+	crashLoop := &models.Incident{
+		Labels:            models.JSONBMap{"alertname": "KubePodCrashLooping", "severity": "critical", "container": "app"},
+		AffectedResources: models.JSONBMap{"pod": "api-0", "namespace": "prod"},
+	}
+	want := []models.SuggestedAction{{
+		HumanReadable: "Logs of api-0",
+		Action:        string(models.ActionGetPodLogs),
+		Parameters:    map[string]string{"pod_name": "api-0", "namespace": "prod", "container": "app"},
+	}}
+
+	for _, name := range []string{"rules.yaml", "rules.yml", "rules.json"} {
+		t.Run(name, func(t *testing.T) {
+			content := yamlRules
+			if strings.HasSuffix(name, ".json") {
+				content = jsonRules
+			}
+			rules, err := service.LoadRuleSet(writeRules(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			suggester := service.NewActionSuggester(rules)
+
+			// The deployment action is skipped: the incident has no deployment.
+			if got := suggester.SuggestActions(crashLoop); !reflect.DeepEqual(got, want) {
+				t.Errorf("SuggestActions = %+v, want %+v", got, want)
+			}
+
+			warning := &models.Incident{
+				Labels:            models.JSONBMap{"alertname": "KubePodCrashLooping", "severity": "warning"},
+				AffectedResources: crashLoop.AffectedResources,
+			}
+			if got := suggester.SuggestActions(warning); len(got) != 0 {
+				t.Errorf("label condition not applied: got %+v", got)
+			}
+		})
+	}
+}
+
+func TestLoadRuleSetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"malformed yaml", "rules.yaml", "rules: [", "decode"},
+		{"yaml read as json", "rules.txt", yamlRules, "decode"},
+		{"missing alertname", "rules.yaml", "rules:\n  - actions: []\n", "alertname"},
+		{"bad template", "rules.json", `{"rules": [{"alertnames": ["A"], "actions": [{"label": "{{.Labels", "action": "x"}]}]}`, "rule 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.LoadRuleSet(writeRules(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadRuleSet error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExampleRuleSetLoads(t *testing.T) {
+	rules, err := service.LoadRuleSet(filepath.Join("..", "..", "suggestion-rules.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Rules) == 0 {
+		t.Error("example rule set is empty")
+	}
+}
+
+func TestDefaultOOMSuggestions(t *testing.T) {
+	suggester := service.NewActionSuggester(nil)
+	resources := models.JSONBMap{"pod": "api-0", "namespace": "prod"}
+
+	tests := []struct {
+		name         string
+		labels       models.JSONBMap
+		wantAllocate map[string]string
+	}{
+		{
+			name:         "with container",
+			labels:       models.JSONBMap{"alertname": "KubePodOOMKilled", "container": "app"},
+			wantAllocate: map[string]string{"pod": "api-0", "namespace": "prod", "container": "app"},
+		},
+		{
+			name:   "without container",
+			labels: models.JSONBMap{"alertname": "KubeContainerOOMKilled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var allocate map[string]string
+			actions := suggester.SuggestActions(&models.Incident{Labels: tt.labels, AffectedResources: resources})
+			for _, action := range actions {
+				if action.Action == string(models.ActionAllocateHardware) {
+					allocate = action.Parameters
+				}
+			}
+			if !reflect.DeepEqual(allocate, tt.wantAllocate) {
+				t.Errorf("allocate_hardware parameters = %v, want %v", allocate, tt.wantAllocate)
+			}
+			if len(actions) < 2 {
+				t.Errorf("got %d suggestions, want describe and logs at least", len(actions))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"chatops-bot/internal/models"
+)
+
+var (
+	ErrInvalidTag  = errors.New("tag names may only contain letters, digits, '_', '-' and '.'")
+	ErrTagNotFound = errors.New("incident has no such tag")
+)
+
+var tagNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// AddLabel sets an operator tag on the incident. Tags are stored among the
+// incident labels under models.CustomTagPrefix, so they can be searched with
+// /find like alert labels, and survive the incident being reopened.
+func (s *IncidentService) AddLabel(ctx context.Context, userID, incidentID uint, name, value string) (*models.Incident, error) {
+	if !tagNamePattern.MatchString(name) || value == "" {
+		return nil, ErrInvalidTag
+	}
+	record := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     userID,
+		Action:     "add_tag",
+		Parameters: models.JSONBMap{"tag": name, "value": value},
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+		Result:     name + "=" + value,
+	}
+	return s.updateLabels(ctx, incidentID, record, func(labels models.JSONBMap) error {
+		labels[models.CustomTagPrefix+name] = value
+		return nil
+	})
+}
+
+// RemoveLabel removes an operator tag from the incident.
+func (s *IncidentService) RemoveLabel(ctx context.Context, userID, incidentID uint, name string) (*models.Incident, error) {
+	record := &models.AuditRecord{
+		IncidentID: incidentID,
+		UserID:     userID,
+		Action:     "remove_tag",
+		Parameters: models.JSONBMap{"tag": name},
+		Timestamp:  s.clock.Now(),
+		Success:    true,
+		Result:     name,
+	}
+	return s.updateLabels(ctx, incidentID, record, func(labels models.JSONBMap) error {
+		key := models.CustomTagPrefix + name
+		if _, ok := labels[key]; !ok {
+			return ErrTagNotFound
+		}
+		delete(labels, key)
+		return nil
+	})
+}
+
+func (s *IncidentService) updateLabels(ctx context.Context, incidentID uint, record *models.AuditRecord, fn func(labels models.JSONBMap) error) (*models.Incident, error) {
+	if err := s.repo.UpdateLabels(ctx, incidentID, fn, record); err != nil {
+		return nil, err
+	}
+	incident, err := s.repo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == models.StatusActive {
+		s.publish(s.updateChan, incident, "update")
+	}
+	return incident, nil
+}
+
+// withCustomTags returns the alert labels plus the operator tags from previous,
+// so reopening an incident keeps its tags.
+func withCustomTags(alertLabels map[string]string, previous models.JSONBMap) models.JSONBMap {
+	labels := make(models.JSONBMap, len(alertLabels))
+	for key, value := range alertLabels {
+		labels[key] = value
+	}
+	for key, value := range previous {
+		if strings.HasPrefix(key, models.CustomTagPrefix) {
+			labels[key] = value
+		}
+	}
+	return labels
+}
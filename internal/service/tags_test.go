@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+func TestAddAndRemoveLabel(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-tag", map[string]string{"alertname": "X"})
+	drain(env.updates)
+
+	got, err := env.svc.AddLabel(ctx, user.ID, incident.ID, "postmortem", "required")
+	if err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+	if got.Labels["tag:postmortem"] != "required" || got.Labels["alertname"] != "X" {
+		t.Errorf("labels = %v, want the tag next to the alert labels", got.Labels)
+	}
+	if tags := got.CustomTags(); len(tags) != 1 || tags["postmortem"] != "required" {
+		t.Errorf("custom tags = %v", tags)
+	}
+	if ids := drain(env.updates); len(ids) != 1 {
+		t.Errorf("updates = %v, want one", ids)
+	}
+
+	if _, err := env.svc.AddLabel(ctx, user.ID, incident.ID, "customer impact", "true"); !errors.Is(err, service.ErrInvalidTag) {
+		t.Errorf("invalid name error = %v, want %v", err, service.ErrInvalidTag)
+	}
+	if _, err := env.svc.AddLabel(ctx, user.ID, incident.ID, "empty", ""); !errors.Is(err, service.ErrInvalidTag) {
+		t.Errorf("empty value error = %v, want %v", err, service.ErrInvalidTag)
+	}
+
+	got, err = env.svc.RemoveLabel(ctx, user.ID, incident.ID, "postmortem")
+	if err != nil {
+		t.Fatalf("RemoveLabel: %v", err)
+	}
+	if tags := got.CustomTags(); tags != nil {
+		t.Errorf("custom tags after removal = %v, want none", tags)
+	}
+	if _, err := env.svc.RemoveLabel(ctx, user.ID, incident.ID, "postmortem"); !errors.Is(err, service.ErrTagNotFound) {
+		t.Errorf("second removal error = %v, want %v", err, service.ErrTagNotFound)
+	}
+
+	var actions []string
+	for _, entry := range got.AuditLog {
+		actions = append(actions, entry.Action)
+	}
+	if fmt.Sprint(actions) != "[add_tag remove_tag]" {
+		t.Errorf("audit actions = %v, want [add_tag remove_tag]", actions)
+	}
+}
+
+// TestConcurrentLabelEdits checks that tags added at the same time are all kept:
+// each edit reloads the labels inside its transaction instead of saving a stale
+// copy.
+func TestConcurrentLabelEdits(t *testing.T) {
+	const editors = 8
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-tags", map[string]string{"alertname": "X"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < editors; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := env.svc.AddLabel(ctx, user.ID, incident.ID, fmt.Sprintf("t%d", i), "1"); err != nil {
+				t.Errorf("AddLabel t%d: %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := got.CustomTags(); len(tags) != editors {
+		t.Errorf("custom tags = %v, want %d", tags, editors)
+	}
+	if got.Labels["alertname"] != "X" {
+		t.Errorf("alert labels were lost: %v", got.Labels)
+	}
+	if len(got.AuditLog) != editors {
+		t.Errorf("audit records = %d, want %d", len(got.AuditLog), editors)
+	}
+}
+
+func TestTagsSurviveReopen(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	labels := map[string]string{"alertname": "X"}
+	incident := env.fire(t, "fp-reopen", labels)
+	if _, err := env.svc.AddLabel(ctx, user.ID, incident.ID, "customer-impacting", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := env.fire(t, "fp-reopen", labels)
+	if reopened.ID != incident.ID {
+		t.Fatalf("reopened incident %d, want %d", reopened.ID, incident.ID)
+	}
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CustomTags()["customer-impacting"] != "true" {
+		t.Errorf("labels after reopen = %v, want the tag kept", got.Labels)
+	}
+}
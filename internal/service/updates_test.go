@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+)
+
+// TestFieldUpdatesKeepConcurrentStatus checks that writing some columns from a
+// stale copy of an incident does not revert a status change made in between.
+func TestFieldUpdatesKeepConcurrentStatus(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	incident := env.fire(t, "fp-stale", map[string]string{"alertname": "Stale"})
+
+	stale, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	now := env.clock.Now()
+	err = env.repo.UpdateFields(ctx, stale.ID, map[string]interface{}{
+		"acknowledged_by": user.ID,
+		"acknowledged_at": now,
+	}, &models.AuditRecord{IncidentID: stale.ID, UserID: user.ID, Action: "acknowledge", Timestamp: now, Success: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusResolved {
+		t.Errorf("status = %s, want %s", got.Status, models.StatusResolved)
+	}
+	if got.AcknowledgedBy == nil || *got.AcknowledgedBy != user.ID {
+		t.Errorf("acknowledged_by = %v, want %d", got.AcknowledgedBy, user.ID)
+	}
+}
+
+func TestIncidentActionsWriteAuditRecords(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	assignee := env.user(t, 2)
+	incident := env.fire(t, "fp-actions", map[string]string{"alertname": "Actions", "severity": "warning"})
+
+	if err := env.svc.Acknowledge(ctx, user.ID, incident.ID, false); err != nil {
+		t.Fatalf("Acknowledge: %v", err)
+	}
+	if _, err := env.svc.Snooze(ctx, user.ID, incident.ID, time.Hour); err != nil {
+		t.Fatalf("Snooze: %v", err)
+	}
+	if err := env.svc.AddComment(ctx, user.ID, incident.ID, "looking"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if _, err := env.svc.Assign(ctx, user.ID, incident.ID, assignee.TelegramID); err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+	if _, _, err := env.svc.SetSeverity(ctx, user.ID, incident.ID, "critical"); err != nil {
+		t.Fatalf("SetSeverity: %v", err)
+	}
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusActive {
+		t.Errorf("status = %s, want %s", got.Status, models.StatusActive)
+	}
+	if got.AcknowledgedBy == nil || *got.AcknowledgedBy != user.ID {
+		t.Errorf("acknowledged_by = %v, want %d", got.AcknowledgedBy, user.ID)
+	}
+	if got.SnoozedUntil == nil || !got.SnoozedUntil.Equal(testStart.Add(time.Hour)) {
+		t.Errorf("snoozed_until = %v, want %v", got.SnoozedUntil, testStart.Add(time.Hour))
+	}
+	if got.AssignedTo == nil || *got.AssignedTo != assignee.ID {
+		t.Errorf("assigned_to = %v, want %d", got.AssignedTo, assignee.ID)
+	}
+	if got.Labels["severity"] != "critical" {
+		t.Errorf("severity = %q, want critical", got.Labels["severity"])
+	}
+
+	var actions []string
+	for _, entry := range got.AuditLog {
+		actions = append(actions, entry.Action)
+	}
+	for _, want := range []string{"acknowledge", "snooze", "comment", "assign", "set_severity"} {
+		if !contains(actions, want) {
+			t.Errorf("audit log %v has no %q record", actions, want)
+		}
+	}
+}
+
+func TestReopenClearsResolution(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user := env.user(t, 1)
+	reopener := env.user(t, 2)
+	incident := env.fire(t, "fp-reopen", map[string]string{"alertname": "Reopen"})
+
+	if err := env.svc.Acknowledge(ctx, user.ID, incident.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.svc.UpdateStatus(ctx, user.ID, incident.ID, models.StatusResolved, ""); err != nil {
+		t.Fatal(err)
+	}
+	drain(env.notifications)
+	env.clock.Advance(time.Minute)
+	if _, err := env.svc.Reopen(ctx, reopener.ID, incident.ID); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+
+	got, err := env.repo.FindByID(ctx, incident.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusActive {
+		t.Errorf("status = %s, want %s", got.Status, models.StatusActive)
+	}
+	if got.EndsAt != nil || got.ResolvedBy != nil {
+		t.Errorf("ends_at = %v, resolved_by = %v, want both nil", got.EndsAt, got.ResolvedBy)
+	}
+	if got.AcknowledgedBy != nil || got.AcknowledgedAt != nil {
+		t.Errorf("acknowledged_by = %v, acknowledged_at = %v, want both nil", got.AcknowledgedBy, got.AcknowledgedAt)
+	}
+	if !got.ActiveSince.Equal(testStart.Add(time.Minute)) {
+		t.Errorf("active_since = %v, want %v", got.ActiveSince, testStart.Add(time.Minute))
+	}
+	if sent := drain(env.notifications); len(sent) != 1 || sent[0] != incident.ID {
+		t.Errorf("notifications = %v, want [%d]", sent, incident.ID)
+	}
+
+	entry := got.AuditLog[len(got.AuditLog)-1]
+	if entry.Action != "reopen" || entry.UserID != reopener.ID || !entry.Success {
+		t.Errorf("last audit entry = %s by %d (success %v), want a successful reopen by %d", entry.Action, entry.UserID, entry.Success, reopener.ID)
+	}
+	if entry.Parameters["previous_status"] != string(models.StatusResolved) {
+		t.Errorf("previous_status = %q, want %s", entry.Parameters["previous_status"], models.StatusResolved)
+	}
+
+	// The reopened incident is open for the next on-call to take.
+	if err := env.svc.Acknowledge(ctx, reopener.ID, incident.ID, false); err != nil {
+		t.Errorf("Acknowledge after reopen: %v", err)
+	}
+	if _, err := env.svc.Reopen(ctx, reopener.ID, incident.ID); !errors.Is(err, service.ErrIncidentActive) {
+		t.Errorf("Reopen of an active incident error = %v, want %v", err, service.ErrIncidentActive)
+	}
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
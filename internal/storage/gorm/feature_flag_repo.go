@@ -0,0 +1,34 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"chatops-bot/internal/models"
+	"chatops-bot/internal/service"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormFeatureFlagRepository struct {
+	db *gorm.DB
+}
+
+func NewGormFeatureFlagRepository(db *gorm.DB) (service.FeatureFlagRepository, error) {
+	return &GormFeatureFlagRepository{db: db}, nil
+}
+
+func (r *GormFeatureFlagRepository) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	var flags []*models.FeatureFlag
+	err := r.db.WithContext(ctx).Order("name").Find(&flags).Error
+	return flags, err
+}
+
+func (r *GormFeatureFlagRepository) Set(ctx context.Context, name string, enabled bool) error {
+	flag := &models.FeatureFlag{Name: name, Enabled: enabled, UpdatedAt: time.Now()}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(flag).Error
+}
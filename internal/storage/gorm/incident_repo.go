@@ -2,12 +2,14 @@ package gorm
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"chatops-bot/internal/models"
 	"chatops-bot/internal/service"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormIncidentRepository struct {
@@ -24,18 +26,28 @@ func (r *GormIncidentRepository) Create(ctx context.Context, incident *models.In
 
 func (r *GormIncidentRepository) FindByID(ctx context.Context, id uint) (*models.Incident, error) {
 	var incident models.Incident
-	err := r.db.WithContext(ctx).Preload("AuditLog.User").Preload("ResolvedByUser").First(&incident, id).Error
+	err := r.db.WithContext(ctx).Preload("AuditLog.User").Preload("ResolvedByUser").Preload("AcknowledgedByUser").Preload("AssignedToUser").First(&incident, id).Error
 	return &incident, err
 }
 
 func (r *GormIncidentRepository) FindByFingerprint(ctx context.Context, fingerprint string) (*models.Incident, error) {
 	var incident models.Incident
-	err := r.db.WithContext(ctx).Where("fingerprint = ?", fingerprint).First(&incident).Error
+	err := r.db.WithContext(ctx).Unscoped().Where("fingerprint = ?", fingerprint).First(&incident).Error
 	return &incident, err
 }
 
-func (r *GormIncidentRepository) Update(ctx context.Context, incident *models.Incident) error {
-	return r.db.WithContext(ctx).Save(incident).Error
+// Restore clears the soft-delete marker left by archival on the incident and its
+// audit records, so a reopened incident gets its history back.
+func (r *GormIncidentRepository) Restore(ctx context.Context, incidentID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Incident{}).Where("id = ?", incidentID).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.AuditRecord{}).
+			Where("incident_id = ? AND deleted_at IS NOT NULL", incidentID).
+			Update("deleted_at", nil).Error
+	})
 }
 
 func (r *GormIncidentRepository) ListActive(ctx context.Context) ([]*models.Incident, error) {
@@ -44,17 +56,128 @@ func (r *GormIncidentRepository) ListActive(ctx context.Context) ([]*models.Inci
 	return incidents, err
 }
 
+func (r *GormIncidentRepository) ListActivePage(ctx context.Context, severityLabel string, limit, offset int) ([]*models.Incident, int64, error) {
+	var incidents []*models.Incident
+	var total int64
+	order := clause.OrderBy{Expression: clause.Expr{
+		SQL:                "CASE ? WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END, starts_at DESC, id DESC",
+		Vars:               []interface{}{r.jsonField("labels", severityLabel), "critical", "high", "warning"},
+		WithoutParentheses: true,
+	}}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Incident{}).Where("status = ?", models.StatusActive).Count(&total).Error; err != nil {
+			return err
+		}
+		return tx.Where("status = ?", models.StatusActive).
+			Order(order).
+			Limit(limit).Offset(offset).
+			Find(&incidents).Error
+	})
+	return incidents, total, err
+}
+
+func (r *GormIncidentRepository) FindByLabel(ctx context.Context, key, value string, status models.IncidentStatus) ([]*models.Incident, error) {
+	var incidents []*models.Incident
+	query := r.db.WithContext(ctx).Where(r.jsonFieldEquals("labels", key, value))
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at desc").Find(&incidents).Error
+	return incidents, err
+}
+
+// jsonFieldEquals builds a condition matching a top-level key of a JSONBMap column.
+func (r *GormIncidentRepository) jsonFieldEquals(column, key, value string) clause.Expr {
+	return gorm.Expr("? = ?", r.jsonField(column, key), value)
+}
+
+// jsonField extracts a top-level key of a JSONBMap column as text.
+func (r *GormIncidentRepository) jsonField(column, key string) clause.Expr {
+	if r.db.Dialector.Name() == "postgres" {
+		return gorm.Expr(column+" ->> ?", key)
+	}
+	return gorm.Expr("json_extract("+column+", ?)", fmt.Sprintf(`$."%s"`, key))
+}
+
+// CountByStatusSince counts incidents that started at or after t, grouped by status.
+func (r *GormIncidentRepository) CountByStatusSince(ctx context.Context, t time.Time) (map[models.IncidentStatus]int64, error) {
+	var rows []struct {
+		Status models.IncidentStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Incident{}).
+		Select("status, COUNT(*) AS count").
+		Where("starts_at >= ?", t).
+		Group("status").
+		Scan(&rows).Error
+	counts := make(map[models.IncidentStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, err
+}
+
+// CountByLabelSince counts incidents that started at or after t, grouped by the
+// value of the given label. Incidents without the label are counted under "".
+func (r *GormIncidentRepository) CountByLabelSince(ctx context.Context, key string, t time.Time) (map[string]int64, error) {
+	var rows []struct {
+		Value *string
+		Count int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Incident{}).
+		Select("? AS value, COUNT(*) AS count", r.jsonField("labels", key)).
+		Where("starts_at >= ?", t).
+		Group("value").
+		Scan(&rows).Error
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		value := ""
+		if row.Value != nil {
+			value = *row.Value
+		}
+		counts[value] += row.Count
+	}
+	return counts, err
+}
+
+// ResolveDurations returns how long each resolved incident that started at or after
+// t was open. Incidents without an end time are skipped.
+func (r *GormIncidentRepository) ResolveDurations(ctx context.Context, t time.Time) ([]time.Duration, error) {
+	var rows []struct {
+		StartsAt time.Time
+		EndsAt   time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&models.Incident{}).
+		Select("starts_at, ends_at").
+		Where("status = ? AND ends_at IS NOT NULL AND starts_at >= ?", models.StatusResolved, t).
+		Scan(&rows).Error
+	durations := make([]time.Duration, 0, len(rows))
+	for _, row := range rows {
+		if row.EndsAt.Before(row.StartsAt) {
+			continue
+		}
+		durations = append(durations, row.EndsAt.Sub(row.StartsAt))
+	}
+	return durations, err
+}
+
 func (r *GormIncidentRepository) ListClosed(ctx context.Context, limit int, offset int) ([]*models.Incident, error) {
 	var incidents []*models.Incident
 	err := r.db.WithContext(ctx).
 		Where("status IN (?, ?)", models.StatusResolved, models.StatusRejected).
-		Order("created_at desc").
+		Order("created_at desc, id desc").
 		Limit(limit).
 		Offset(offset).
 		Find(&incidents).Error
 	return incidents, err
 }
 
+func (r *GormIncidentRepository) CountClosed(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Incident{}).Where("status IN (?, ?)", models.StatusResolved, models.StatusRejected).Count(&count).Error
+	return count, err
+}
+
 func (r *GormIncidentRepository) SetTelegramMessageID(ctx context.Context, incidentID uint, chatID, messageID int64) error {
 	return r.db.WithContext(ctx).Model(&models.Incident{}).Where("id = ?", incidentID).Updates(map[string]interface{}{
 		"telegram_chat_id":    chatID,
@@ -62,6 +185,25 @@ func (r *GormIncidentRepository) SetTelegramMessageID(ctx context.Context, incid
 	}).Error
 }
 
+// AddTelegramMessage records a message showing the incident; re-adding the same
+// chat/message pair is a no-op.
+func (r *GormIncidentRepository) AddTelegramMessage(ctx context.Context, message *models.TelegramMessage) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "message_id"}},
+		DoNothing: true,
+	}).Create(message).Error
+}
+
+func (r *GormIncidentRepository) ListTelegramMessages(ctx context.Context, incidentID uint) ([]*models.TelegramMessage, error) {
+	var messages []*models.TelegramMessage
+	err := r.db.WithContext(ctx).Where("incident_id = ?", incidentID).Order("id").Find(&messages).Error
+	return messages, err
+}
+
+func (r *GormIncidentRepository) DeleteTelegramMessage(ctx context.Context, chatID, messageID int64) error {
+	return r.db.WithContext(ctx).Where("chat_id = ? AND message_id = ?", chatID, messageID).Delete(&models.TelegramMessage{}).Error
+}
+
 func (r *GormIncidentRepository) SetTelegramTopicID(ctx context.Context, incidentID uint, topicID int64) error {
 	return r.db.WithContext(ctx).Model(&models.Incident{}).Where("id = ?", incidentID).Update("telegram_topic_id", topicID).Error
 }
@@ -73,3 +215,199 @@ func (r *GormIncidentRepository) FindClosedBefore(ctx context.Context, t time.Ti
 		Find(&incidents).Error
 	return incidents, err
 }
+
+// ArchiveClosedBefore soft-deletes incidents closed before t. Their audit records
+// are soft-deleted as well unless keepAuditRecords is set.
+func (r *GormIncidentRepository) ArchiveClosedBefore(ctx context.Context, t time.Time, keepAuditRecords bool) (int64, error) {
+	var archived int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		closed := tx.Model(&models.Incident{}).
+			Select("id").
+			Where("status IN (?, ?) AND ends_at < ?", models.StatusResolved, models.StatusRejected, t)
+
+		if !keepAuditRecords {
+			if err := tx.Where("incident_id IN (?)", closed).Delete(&models.AuditRecord{}).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Where("status IN (?, ?) AND ends_at < ?", models.StatusResolved, models.StatusRejected, t).Delete(&models.Incident{})
+		archived = result.RowsAffected
+		return result.Error
+	})
+	return archived, err
+}
+
+func (r *GormIncidentRepository) FindUnacknowledgedActiveBefore(ctx context.Context, t time.Time) ([]*models.Incident, error) {
+	var incidents []*models.Incident
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND acknowledged_by IS NULL AND active_since < ?", models.StatusActive, t).
+		Find(&incidents).Error
+	return incidents, err
+}
+
+// AdvanceEscalationLevel moves the incident from fromLevel to the next level. It
+// reports false if the level was already changed, so a level is never fired twice.
+func (r *GormIncidentRepository) AdvanceEscalationLevel(ctx context.Context, incidentID uint, fromLevel int, t time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Incident{}).
+		Where("id = ? AND escalation_level = ?", incidentID, fromLevel).
+		Updates(map[string]interface{}{"escalation_level": fromLevel + 1, "escalated_at": t})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *GormIncidentRepository) FindSnoozeExpired(ctx context.Context, t time.Time) ([]*models.Incident, error) {
+	var incidents []*models.Incident
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND snoozed_until IS NOT NULL AND snoozed_until <= ?", models.StatusActive, t).
+		Find(&incidents).Error
+	return incidents, err
+}
+
+func (r *GormIncidentRepository) ClearSnooze(ctx context.Context, incidentID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Incident{}).Where("id = ?", incidentID).Update("snoozed_until", nil).Error
+}
+
+// FindNotificationPending returns active incidents whose announcement was held
+// back by quiet hours, with their audit log so reopened ones are recognised.
+func (r *GormIncidentRepository) FindNotificationPending(ctx context.Context) ([]*models.Incident, error) {
+	var incidents []*models.Incident
+	err := r.db.WithContext(ctx).
+		Preload("AuditLog.User").Preload("ResolvedByUser").Preload("AcknowledgedByUser").Preload("AssignedToUser").
+		Where("status = ? AND notification_pending = ?", models.StatusActive, true).
+		Find(&incidents).Error
+	return incidents, err
+}
+
+func (r *GormIncidentRepository) ClearNotificationPending(ctx context.Context, incidentID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Incident{}).Where("id = ?", incidentID).Update("notification_pending", false).Error
+}
+
+// HasIdempotencyKey reports whether an action with the given key was already
+// recorded in the audit log, including archived entries.
+func (r *GormIncidentRepository) HasIdempotencyKey(ctx context.Context, key string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.AuditRecord{}).Where("idempotency_key = ?", key).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormIncidentRepository) UpdateLabels(ctx context.Context, incidentID uint, fn func(labels models.JSONBMap) error, record *models.AuditRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Incident{}).Select("labels")
+		if r.db.Dialector.Name() == "postgres" {
+			// SQLite serializes writers on its own and has no row locks.
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		var incident models.Incident
+		if err := query.First(&incident, incidentID).Error; err != nil {
+			return err
+		}
+		if incident.Labels == nil {
+			incident.Labels = make(models.JSONBMap)
+		}
+		if err := fn(incident.Labels); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Incident{}).Where("id = ?", incidentID).Update("labels", incident.Labels).Error; err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+func (r *GormIncidentRepository) ExportRange(ctx context.Context, from, to time.Time, fn func(row *models.IncidentExportRow) error) error {
+	rows, err := r.db.WithContext(ctx).Model(&models.Incident{}).
+		Select("incidents.id, incidents.fingerprint, incidents.status, incidents.summary, incidents.labels, incidents.starts_at, incidents.ends_at, "+
+			"COALESCE(users.username, '') AS resolved_by, "+
+			"(SELECT COUNT(*) FROM audit_records WHERE audit_records.incident_id = incidents.id AND audit_records.deleted_at IS NULL) AS action_count").
+		Joins("LEFT JOIN users ON users.id = incidents.resolved_by").
+		Where("incidents.starts_at >= ? AND incidents.starts_at < ?", from, to).
+		Order("incidents.starts_at, incidents.id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.IncidentExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *GormIncidentRepository) AddAuditRecord(ctx context.Context, record *models.AuditRecord) error {
+	return r.db.WithContext(ctx).Create(record).Error
+}
+
+func (r *GormIncidentRepository) UpdateStatus(ctx context.Context, incident *models.Incident, record *models.AuditRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Incident{}).Where("id = ?", incident.ID).Updates(map[string]interface{}{
+			"status":           incident.Status,
+			"ends_at":          incident.EndsAt,
+			"resolved_by":      incident.ResolvedBy,
+			"rejection_reason": incident.RejectionReason,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+// UpdateFields writes only the given columns of the incident and inserts record
+// in one transaction, so concurrent changes of other columns are kept.
+func (r *GormIncidentRepository) UpdateFields(ctx context.Context, incidentID uint, fields map[string]interface{}, record *models.AuditRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Incident{}).Where("id = ?", incidentID).Updates(fields).Error; err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+// FindDueForReminder returns active, unacknowledged and announced incidents that
+// were neither reminded about nor (re)opened after t.
+func (r *GormIncidentRepository) FindDueForReminder(ctx context.Context, t time.Time) ([]*models.Incident, error) {
+	var incidents []*models.Incident
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND acknowledged_by IS NULL AND notification_pending = ? AND COALESCE(last_reminded_at, active_since) <= ?", models.StatusActive, false, t).
+		Find(&incidents).Error
+	return incidents, err
+}
+
+// RecordReminder stores the reminder time of the incident and its audit record in
+// one transaction.
+func (r *GormIncidentRepository) RecordReminder(ctx context.Context, record *models.AuditRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Incident{}).Where("id = ?", record.IncidentID).Update("last_reminded_at", record.Timestamp).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+func (r *GormIncidentRepository) CreateSilence(ctx context.Context, silence *models.Silence) error {
+	return r.db.WithContext(ctx).Create(silence).Error
+}
+
+func (r *GormIncidentRepository) ListSilences(ctx context.Context) ([]*models.Silence, error) {
+	var silences []*models.Silence
+	err := r.db.WithContext(ctx).Order("ends_at, id").Find(&silences).Error
+	return silences, err
+}
+
+func (r *GormIncidentRepository) DeleteSilence(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&models.Silence{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *GormIncidentRepository) DeleteSilencesEndedBefore(ctx context.Context, t time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("ends_at <= ?", t).Delete(&models.Silence{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,270 @@
+package gorm_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"chatops-bot/internal/models"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestFindByLabel(t *testing.T) {
+	ctx := context.Background()
+	repo, err := gormrepo.NewGormIncidentRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := []struct {
+		fingerprint string
+		status      models.IncidentStatus
+		labels      models.JSONBMap
+	}{
+		{"fp-api", models.StatusActive, models.JSONBMap{"service": "api", "app.kubernetes.io/name": "gateway"}},
+		{"fp-api-old", models.StatusResolved, models.JSONBMap{"service": "api"}},
+		{"fp-db", models.StatusActive, models.JSONBMap{"service": "db", "team": "api"}},
+		{"fp-none", models.StatusActive, nil},
+	}
+	for _, s := range seed {
+		incident := &models.Incident{Fingerprint: s.fingerprint, Status: s.status, Labels: s.labels, StartsAt: time.Now()}
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("create %s: %v", s.fingerprint, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		value  string
+		status models.IncidentStatus
+		want   []string
+	}{
+		{"any status", "service", "api", "", []string{"fp-api", "fp-api-old"}},
+		{"status filter", "service", "api", models.StatusActive, []string{"fp-api"}},
+		{"value of another key does not match", "team", "db", "", nil},
+		{"key with dots and slash", "app.kubernetes.io/name", "gateway", "", []string{"fp-api"}},
+		{"missing key", "cluster", "prod", "", nil},
+		{"value is not a pattern", "service", "a%", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents, err := repo.FindByLabel(ctx, tt.key, tt.value, tt.status)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, incident := range incidents {
+				got = append(got, incident.Fingerprint)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindByLabel(%q, %q, %q) = %v, want %v", tt.key, tt.value, tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListActivePage(t *testing.T) {
+	ctx := context.Background()
+	repo, err := gormrepo.NewGormIncidentRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	seed := []struct {
+		fingerprint string
+		status      models.IncidentStatus
+		severity    string
+		age         time.Duration
+	}{
+		{"fp-warning", models.StatusActive, "warning", time.Hour},
+		{"fp-critical-old", models.StatusActive, "critical", 2 * time.Hour},
+		{"fp-none", models.StatusActive, "", 3 * time.Hour},
+		{"fp-critical-new", models.StatusActive, "critical", time.Minute},
+		{"fp-high", models.StatusActive, "high", 4 * time.Hour},
+		{"fp-resolved", models.StatusResolved, "critical", time.Minute},
+	}
+	for _, s := range seed {
+		labels := models.JSONBMap{"alertname": s.fingerprint}
+		if s.severity != "" {
+			labels["level"] = s.severity
+		}
+		incident := &models.Incident{Fingerprint: s.fingerprint, Status: s.status, Labels: labels, StartsAt: start.Add(-s.age)}
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("create %s: %v", s.fingerprint, err)
+		}
+	}
+
+	tests := []struct {
+		limit, offset int
+		want          []string
+	}{
+		{2, 0, []string{"fp-critical-new", "fp-critical-old"}},
+		{2, 2, []string{"fp-high", "fp-warning"}},
+		{2, 4, []string{"fp-none"}},
+		{2, 6, nil},
+	}
+	for _, tt := range tests {
+		incidents, total, err := repo.ListActivePage(ctx, "level", tt.limit, tt.offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 5 {
+			t.Errorf("offset %d: total = %d, want 5", tt.offset, total)
+		}
+		var got []string
+		for _, incident := range incidents {
+			got = append(got, incident.Fingerprint)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("offset %d: page = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestIncidentAggregates(t *testing.T) {
+	ctx := context.Background()
+	repo, err := gormrepo.NewGormIncidentRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	seed := []struct {
+		fingerprint string
+		status      models.IncidentStatus
+		severity    string
+		start       time.Duration
+		resolveIn   time.Duration
+	}{
+		{"fp-active", models.StatusActive, "critical", time.Hour, 0},
+		{"fp-resolved-fast", models.StatusResolved, "critical", 2 * time.Hour, 10 * time.Minute},
+		{"fp-resolved-slow", models.StatusResolved, "warning", 3 * time.Hour, 50 * time.Minute},
+		{"fp-resolved-no-end", models.StatusResolved, "warning", 4 * time.Hour, 0},
+		{"fp-rejected", models.StatusRejected, "", 5 * time.Hour, 5 * time.Minute},
+		{"fp-before-window", models.StatusResolved, "critical", -time.Hour, time.Hour},
+	}
+	for _, s := range seed {
+		labels := models.JSONBMap{"alertname": s.fingerprint}
+		if s.severity != "" {
+			labels["severity"] = s.severity
+		}
+		incident := &models.Incident{Fingerprint: s.fingerprint, Status: s.status, Labels: labels, StartsAt: since.Add(s.start)}
+		if s.resolveIn > 0 {
+			endsAt := incident.StartsAt.Add(s.resolveIn)
+			incident.EndsAt = &endsAt
+		}
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("create %s: %v", s.fingerprint, err)
+		}
+	}
+
+	counts, err := repo.CountByStatusSince(ctx, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[models.IncidentStatus]int64{models.StatusActive: 1, models.StatusResolved: 3, models.StatusRejected: 1}
+	if len(counts) != len(want) {
+		t.Errorf("CountByStatusSince = %v, want %v", counts, want)
+	}
+	for status, n := range want {
+		if counts[status] != n {
+			t.Errorf("CountByStatusSince[%s] = %d, want %d", status, counts[status], n)
+		}
+	}
+
+	durations, err := repo.ResolveDurations(ctx, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(durations)
+	if wantDurations := []time.Duration{10 * time.Minute, 50 * time.Minute}; !slices.Equal(durations, wantDurations) {
+		t.Errorf("ResolveDurations = %v, want %v (without the open-ended and rejected incidents)", durations, wantDurations)
+	}
+
+	bySeverity, err := repo.CountByLabelSince(ctx, "severity", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSeverity := map[string]int64{"critical": 2, "warning": 2, "": 1}
+	if len(bySeverity) != len(wantSeverity) {
+		t.Errorf("CountByLabelSince = %v, want %v", bySeverity, wantSeverity)
+	}
+	for value, n := range wantSeverity {
+		if bySeverity[value] != n {
+			t.Errorf("CountByLabelSince[%q] = %d, want %d", value, bySeverity[value], n)
+		}
+	}
+
+	empty, err := repo.CountByStatusSince(ctx, since.AddDate(1, 0, 0))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("empty window = %v, %v; want no counts", empty, err)
+	}
+	if durations, err := repo.ResolveDurations(ctx, since.AddDate(1, 0, 0)); err != nil || len(durations) != 0 {
+		t.Errorf("empty window durations = %v, %v; want none", durations, err)
+	}
+}
+
+func TestListClosedPage(t *testing.T) {
+	ctx := context.Background()
+	repo, err := gormrepo.NewGormIncidentRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	seed := []struct {
+		fingerprint string
+		status      models.IncidentStatus
+		age         time.Duration
+	}{
+		{"fp-resolved-old", models.StatusResolved, 3 * time.Hour},
+		{"fp-rejected", models.StatusRejected, time.Hour},
+		{"fp-active", models.StatusActive, 0},
+		{"fp-resolved-new", models.StatusResolved, time.Minute},
+		{"fp-resolved-mid", models.StatusResolved, 2 * time.Hour},
+	}
+	for _, s := range seed {
+		incident := &models.Incident{Fingerprint: s.fingerprint, Status: s.status, StartsAt: created.Add(-s.age)}
+		incident.CreatedAt = created.Add(-s.age)
+		if err := repo.Create(ctx, incident); err != nil {
+			t.Fatalf("create %s: %v", s.fingerprint, err)
+		}
+	}
+
+	total, err := repo.CountClosed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 4 {
+		t.Errorf("CountClosed = %d, want 4", total)
+	}
+
+	tests := []struct {
+		limit, offset int
+		want          []string
+	}{
+		{2, 0, []string{"fp-resolved-new", "fp-rejected"}},
+		{2, 2, []string{"fp-resolved-mid", "fp-resolved-old"}},
+		{3, 3, []string{"fp-resolved-old"}},
+		{2, 4, nil},
+	}
+	for _, tt := range tests {
+		incidents, err := repo.ListClosed(ctx, tt.limit, tt.offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, incident := range incidents {
+			got = append(got, incident.Fingerprint)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ListClosed(%d, %d) = %v, want %v", tt.limit, tt.offset, got, tt.want)
+		}
+	}
+}
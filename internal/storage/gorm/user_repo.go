@@ -61,3 +61,46 @@ func (r *GormUserRepository) FindByID(ctx context.Context, id uint) (*models.Use
 	err := r.db.WithContext(ctx).First(&user, id).Error
 	return &user, err
 }
+
+func (r *GormUserRepository) FindByTelegramID(ctx context.Context, telegramID int64) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("telegram_id = ?", telegramID).First(&user).Error
+	return &user, err
+}
+
+// FindByUsername looks a user up by Telegram username, ignoring case.
+func (r *GormUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("LOWER(username) = LOWER(?)", username).First(&user).Error
+	return &user, err
+}
+
+func (r *GormUserRepository) SetAdmin(ctx context.Context, id uint, isAdmin bool) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("is_admin", isAdmin).Error
+}
+
+func (r *GormUserRepository) ChangeAdmin(ctx context.Context, change *models.PermissionChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if !change.IsAdmin {
+			var remaining int64
+			if err := tx.Model(&models.User{}).Where("is_admin = ? AND id <> ?", true, change.UserID).Count(&remaining).Error; err != nil {
+				return err
+			}
+			if remaining == 0 {
+				return service.ErrLastAdmin
+			}
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", change.UserID).Update("is_admin", change.IsAdmin).Error; err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *GormUserRepository) SetLanguage(ctx context.Context, id uint, language string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("language", language).Error
+}
+
+func (r *GormUserRepository) SetNotificationPrefs(ctx context.Context, id uint, prefs models.NotificationPrefs) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("notify_prefs", prefs).Error
+}
@@ -0,0 +1,48 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+
+	"chatops-bot/internal/models"
+	gormrepo "chatops-bot/internal/storage/gorm"
+	"chatops-bot/internal/testutil"
+)
+
+func TestSetNotificationPrefs(t *testing.T) {
+	ctx := context.Background()
+	repo, err := gormrepo.NewGormUserRepository(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := repo.FindOrCreateByTelegramID(ctx, 42, "alice", "Alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.NotifyPrefs != 0 {
+		t.Fatalf("new user prefs = %b, want none", user.NotifyPrefs)
+	}
+
+	prefs := models.NotifyAssigned | models.NotifyReminder
+	if err := repo.SetNotificationPrefs(ctx, user.ID, prefs); err != nil {
+		t.Fatal(err)
+	}
+	got, err := repo.FindOrCreateByTelegramID(ctx, 42, "alice", "Alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.NotifyPrefs != prefs {
+		t.Errorf("prefs after reload = %b, want %b", got.NotifyPrefs, prefs)
+	}
+
+	if err := repo.SetNotificationPrefs(ctx, user.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	got, err = repo.FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.NotifyPrefs != 0 {
+		t.Errorf("prefs after clearing = %b, want none", got.NotifyPrefs)
+	}
+}
@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP COLUMN acknowledged_at;
+ALTER TABLE incidents DROP COLUMN acknowledged_by;
@@ -0,0 +1,2 @@
+ALTER TABLE incidents ADD COLUMN acknowledged_by INTEGER REFERENCES users(id);
+ALTER TABLE incidents ADD COLUMN acknowledged_at DATETIME;
@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN snoozed_until DATETIME;
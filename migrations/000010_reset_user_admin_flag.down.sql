@@ -0,0 +1 @@
+UPDATE users SET is_admin = TRUE;
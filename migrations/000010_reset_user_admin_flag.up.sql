@@ -0,0 +1,2 @@
+-- Users used to be created as admins by default. Admins are now seeded from telegram.admin_ids.
+UPDATE users SET is_admin = FALSE;
@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN occurrence_count INTEGER NOT NULL DEFAULT 1;
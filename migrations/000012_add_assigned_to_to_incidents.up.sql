@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN assigned_to INTEGER REFERENCES users(id);
@@ -0,0 +1,17 @@
+CREATE TABLE telegram_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    incident_id INTEGER NOT NULL,
+    chat_id INTEGER NOT NULL,
+    message_id INTEGER NOT NULL,
+    thread_id INTEGER NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (incident_id) REFERENCES incidents(id)
+);
+CREATE UNIQUE INDEX idx_telegram_messages_chat_message ON telegram_messages (chat_id, message_id);
+CREATE INDEX idx_telegram_messages_incident_id ON telegram_messages (incident_id);
+
+INSERT INTO telegram_messages (incident_id, chat_id, message_id, thread_id, kind, created_at)
+SELECT id, telegram_chat_id, telegram_message_id, COALESCE(telegram_topic_id, 0), 'primary', CURRENT_TIMESTAMP
+FROM incidents
+WHERE telegram_chat_id IS NOT NULL AND telegram_message_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE audit_records ADD COLUMN dry_run BOOLEAN NOT NULL DEFAULT FALSE;
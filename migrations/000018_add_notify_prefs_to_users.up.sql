@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN notify_prefs INTEGER NOT NULL DEFAULT 0;
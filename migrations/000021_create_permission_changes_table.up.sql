@@ -0,0 +1,10 @@
+CREATE TABLE permission_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    is_admin BOOLEAN NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (actor_id) REFERENCES users(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX idx_permission_changes_user_id ON permission_changes (user_id);
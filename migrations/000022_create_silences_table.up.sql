@@ -0,0 +1,9 @@
+CREATE TABLE silences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    matchers TEXT NOT NULL,
+    created_by INTEGER NOT NULL,
+    ends_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (created_by) REFERENCES users(id)
+);
+CREATE INDEX idx_silences_ends_at ON silences (ends_at);
@@ -0,0 +1,2 @@
+ALTER TABLE incidents ADD COLUMN active_since DATETIME;
+UPDATE incidents SET active_since = created_at;
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN last_reminded_at;
@@ -0,0 +1,53 @@
+-- Users Table
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    telegram_id BIGINT UNIQUE NOT NULL,
+    username TEXT UNIQUE,
+    first_name TEXT,
+    last_name TEXT,
+    is_admin BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_users_telegram_id ON users(telegram_id);
+
+-- Incidents Table
+CREATE TABLE IF NOT EXISTS incidents (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    fingerprint TEXT UNIQUE NOT NULL,
+    status TEXT NOT NULL,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    summary TEXT,
+    description TEXT,
+    labels JSONB,
+    affected_resources JSONB,
+    resolved_by BIGINT REFERENCES users(id),
+    rejection_reason TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_incidents_deleted_at ON incidents(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_incidents_status ON incidents(status);
+CREATE INDEX IF NOT EXISTS idx_incidents_fingerprint ON incidents(fingerprint);
+CREATE INDEX IF NOT EXISTS idx_incidents_labels ON incidents USING GIN (labels);
+
+-- AuditRecords Table
+CREATE TABLE IF NOT EXISTS audit_records (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    incident_id BIGINT NOT NULL REFERENCES incidents(id),
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    action TEXT,
+    parameters JSONB,
+    timestamp TIMESTAMPTZ NOT NULL,
+    success BOOLEAN,
+    result TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_audit_records_deleted_at ON audit_records(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_audit_records_incident_id ON audit_records(incident_id);
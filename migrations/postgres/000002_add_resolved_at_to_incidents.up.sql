@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN resolved_at TIMESTAMPTZ;
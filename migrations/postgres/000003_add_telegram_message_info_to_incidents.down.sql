@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP COLUMN telegram_chat_id;
+ALTER TABLE incidents DROP COLUMN telegram_message_id;
@@ -0,0 +1,2 @@
+ALTER TABLE incidents ADD COLUMN telegram_chat_id BIGINT;
+ALTER TABLE incidents ADD COLUMN telegram_message_id BIGINT;
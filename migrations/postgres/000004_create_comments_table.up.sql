@@ -0,0 +1,9 @@
+CREATE TABLE comments (
+    id BIGSERIAL PRIMARY KEY,
+    incident_id BIGINT NOT NULL REFERENCES incidents(id),
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    text TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ
+);
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN telegram_topic_id;
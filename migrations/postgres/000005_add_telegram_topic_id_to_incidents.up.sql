@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN telegram_topic_id BIGINT;
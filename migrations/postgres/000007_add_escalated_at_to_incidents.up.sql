@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN escalated_at TIMESTAMPTZ;
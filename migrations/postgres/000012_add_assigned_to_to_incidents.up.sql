@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN assigned_to BIGINT REFERENCES users(id);
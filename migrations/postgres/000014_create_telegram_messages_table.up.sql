@@ -0,0 +1,16 @@
+CREATE TABLE telegram_messages (
+    id BIGSERIAL PRIMARY KEY,
+    incident_id BIGINT NOT NULL REFERENCES incidents(id),
+    chat_id BIGINT NOT NULL,
+    message_id BIGINT NOT NULL,
+    thread_id BIGINT NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX idx_telegram_messages_chat_message ON telegram_messages (chat_id, message_id);
+CREATE INDEX idx_telegram_messages_incident_id ON telegram_messages (incident_id);
+
+INSERT INTO telegram_messages (incident_id, chat_id, message_id, thread_id, kind, created_at)
+SELECT id, telegram_chat_id, telegram_message_id, COALESCE(telegram_topic_id, 0), 'primary', NOW()
+FROM incidents
+WHERE telegram_chat_id IS NOT NULL AND telegram_message_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX idx_audit_records_idempotency_key;
+ALTER TABLE audit_records DROP COLUMN idempotency_key;
@@ -0,0 +1,2 @@
+ALTER TABLE audit_records ADD COLUMN idempotency_key TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX idx_audit_records_idempotency_key ON audit_records (idempotency_key) WHERE idempotency_key <> '';
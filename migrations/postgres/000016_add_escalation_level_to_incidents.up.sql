@@ -0,0 +1,2 @@
+ALTER TABLE incidents ADD COLUMN escalation_level INTEGER NOT NULL DEFAULT 0;
+UPDATE incidents SET escalation_level = 1 WHERE escalated_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN notify_prefs;
@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN generator_url TEXT NOT NULL DEFAULT '';
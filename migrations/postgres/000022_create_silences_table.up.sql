@@ -0,0 +1,8 @@
+CREATE TABLE silences (
+    id BIGSERIAL PRIMARY KEY,
+    matchers JSONB NOT NULL,
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    ends_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_silences_ends_at ON silences (ends_at);
@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN notification_pending BOOLEAN NOT NULL DEFAULT FALSE;
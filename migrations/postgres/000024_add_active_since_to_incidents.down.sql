@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN active_since;
@@ -0,0 +1,2 @@
+ALTER TABLE incidents ADD COLUMN last_reminded_at TIMESTAMPTZ;
+UPDATE incidents SET last_reminded_at = (SELECT MAX(timestamp) FROM audit_records WHERE audit_records.incident_id = incidents.id AND audit_records.action = 'reminder');